@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package archive
+
+func getWhiteoutConverter(format WhiteoutFormat, inUserNS bool) (tarWhiteoutConverter, error) {
+	return nil, nil
+}
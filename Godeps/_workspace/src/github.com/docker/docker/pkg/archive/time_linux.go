@@ -9,7 +9,7 @@ func timeToTimespec(time time.Time) (ts syscall.Timespec) {
 	if time.IsZero() {
 		// Return UTIME_OMIT special value
 		ts.Sec = 0
-		ts.Nsec = ((1 << 30) - 2)
+		ts.Nsec = (1 << 30) - 2
 		return
 	}
 	return syscall.NsecToTimespec(time.UnixNano())
@@ -8,8 +8,7 @@ import (
 // Chtimes changes the access time and modified time of a file at the given path
 func Chtimes(name string, atime time.Time, mtime time.Time) error {
 	unixMinTime := time.Unix(0, 0)
-	// The max Unix time is 33 bits set
-	unixMaxTime := unixMinTime.Add((1<<33 - 1) * time.Second)
+	unixMaxTime := maxTime
 
 	// If the modified time is prior to the Unix Epoch, or after the
 	// end of Unix Time, os.Chtimes has undefined behavior
@@ -27,5 +26,6 @@ func Chtimes(name string, atime time.Time, mtime time.Time) error {
 		return err
 	}
 
-	return nil
+	// Take platform specific action for setting create time.
+	return setCTime(name, mtime)
 }
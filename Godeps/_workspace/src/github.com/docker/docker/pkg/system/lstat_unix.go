@@ -1,8 +1,10 @@
+//go:build !windows
 // +build !windows
 
 package system
 
 import (
+	"os"
 	"syscall"
 )
 
@@ -13,7 +15,7 @@ import (
 func Lstat(path string) (*StatT, error) {
 	s := &syscall.Stat_t{}
 	if err := syscall.Lstat(path, s); err != nil {
-		return nil, err
+		return nil, &os.PathError{Op: "Lstat", Path: path, Err: err}
 	}
 	return fromStatT(s)
 }
@@ -1,8 +1,11 @@
+//go:build !linux && !freebsd
+// +build !linux,!freebsd
+
 package system
 
 import "syscall"
 
-// LUtimesNano is not supported by darwin platform.
+// LUtimesNano is only supported on linux and freebsd.
 func LUtimesNano(path string, ts []syscall.Timespec) error {
 	return ErrNotSupportedPlatform
 }
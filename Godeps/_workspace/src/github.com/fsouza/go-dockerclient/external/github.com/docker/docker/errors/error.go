@@ -1,6 +0,0 @@
-package errors
-
-// This file contains all of the errors that can be generated from the
-// docker engine but are not tied to any specific top-level component.
-
-const errGroup = "engine"
@@ -1,6 +0,0 @@
-package system
-
-// Unmount is a platform-specific helper function to call
-// the unmount syscall. Not supported on Windows
-func Unmount(dest string) {
-}
@@ -1,4 +1,4 @@
-// Copyright 2015 go-dockerclient authors. All rights reserved.
+// Copyright 2014 go-dockerclient authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
@@ -7,17 +7,21 @@ package docker
 import (
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
 
-	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient/external/github.com/docker/docker/pkg/archive"
-	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient/external/github.com/docker/docker/pkg/fileutils"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/docker/docker/pkg/archive"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/moby/patternmatcher"
 )
 
 func createTarStream(srcPath, dockerfilePath string) (io.ReadCloser, error) {
+	srcPath, err := filepath.Abs(srcPath)
+	if err != nil {
+		return nil, err
+	}
+
 	excludes, err := parseDockerignore(srcPath)
 	if err != nil {
 		return nil, err
@@ -40,9 +44,9 @@ func createTarStream(srcPath, dockerfilePath string) (io.ReadCloser, error) {
 		if includeFile == "" {
 			continue
 		}
-		keepThem, err := fileutils.Matches(includeFile, excludes)
+		keepThem, err := patternmatcher.Matches(includeFile, excludes)
 		if err != nil {
-			return nil, fmt.Errorf("cannot match .dockerfile: '%s', error: %s", includeFile, err)
+			return nil, fmt.Errorf("cannot match .dockerfileignore: '%s', error: %w", includeFile, err)
 		}
 		if keepThem {
 			includes = append(includes, includeFile)
@@ -67,10 +71,10 @@ func createTarStream(srcPath, dockerfilePath string) (io.ReadCloser, error) {
 func validateContextDirectory(srcPath string, excludes []string) error {
 	return filepath.Walk(filepath.Join(srcPath, "."), func(filePath string, f os.FileInfo, err error) error {
 		// skip this directory/file if it's not in the path, it won't get added to the context
-		if relFilePath, err := filepath.Rel(srcPath, filePath); err != nil {
-			return err
-		} else if skip, err := fileutils.Matches(relFilePath, excludes); err != nil {
-			return err
+		if relFilePath, relErr := filepath.Rel(srcPath, filePath); relErr != nil {
+			return relErr
+		} else if skip, matchErr := patternmatcher.Matches(relFilePath, excludes); matchErr != nil {
+			return matchErr
 		} else if skip {
 			if f.IsDir() {
 				return filepath.SkipDir
@@ -80,7 +84,7 @@ func validateContextDirectory(srcPath string, excludes []string) error {
 
 		if err != nil {
 			if os.IsPermission(err) {
-				return fmt.Errorf("can't stat '%s'", filePath)
+				return fmt.Errorf("cannot stat %q: %w", filePath, err)
 			}
 			if os.IsNotExist(err) {
 				return nil
@@ -96,8 +100,8 @@ func validateContextDirectory(srcPath string, excludes []string) error {
 
 		if !f.IsDir() {
 			currentFile, err := os.Open(filePath)
-			if err != nil && os.IsPermission(err) {
-				return fmt.Errorf("no permission to read from '%s'", filePath)
+			if err != nil {
+				return fmt.Errorf("cannot open %q for reading: %w", filePath, err)
 			}
 			currentFile.Close()
 		}
@@ -107,9 +111,9 @@ func validateContextDirectory(srcPath string, excludes []string) error {
 
 func parseDockerignore(root string) ([]string, error) {
 	var excludes []string
-	ignore, err := ioutil.ReadFile(path.Join(root, ".dockerignore"))
+	ignore, err := os.ReadFile(path.Join(root, ".dockerignore"))
 	if err != nil && !os.IsNotExist(err) {
-		return excludes, fmt.Errorf("error reading .dockerignore: '%s'", err)
+		return excludes, fmt.Errorf("error reading .dockerignore: %w", err)
 	}
 	excludes = strings.Split(string(ignore), "\n")
 
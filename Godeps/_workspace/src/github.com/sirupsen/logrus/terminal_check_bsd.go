@@ -0,0 +1,13 @@
+// +build darwin dragonfly freebsd netbsd openbsd hurd
+// +build !js
+
+package logrus
+
+import "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/golang.org/x/sys/unix"
+
+const ioctlReadTermios = unix.TIOCGETA
+
+func isTerminal(fd int) bool {
+	_, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	return err == nil
+}
@@ -64,8 +64,24 @@ func ImportAction(do *definitions.Do) error {
 		return nil
 	}
 
-	if strings.Contains(s[0], "github") {
-		logger.Println("https://twitter.com/ryaneshea/status/595957712040628224")
+	if util.IsGitLocation(do.Path) {
+		// action definition files are named like the action, with _ for spaces,
+		// which LoadActionDefinition reads them by
+		name := strings.Replace(do.Name, " ", "_", -1)
+
+		var err error
+		if logger.Level > 0 {
+			_, err = util.GetFromGit(do.Path, name, ActionsPath, nil, logger.Writer)
+		} else {
+			_, err = util.GetFromGit(do.Path, name, ActionsPath, nil, bytes.NewBuffer([]byte{}))
+		}
+		if err != nil {
+			return err
+		}
+		if _, _, err := LoadActionDefinition(name); err != nil {
+			return fmt.Errorf("Your action definition file looks improperly formatted and will not marshal: %v", err)
+		}
+		do.Result = "success"
 		return nil
 	}
 
@@ -0,0 +1,607 @@
+// Package agent implements `eris agent`, a long-lived HTTP server which
+// exposes eris operations as JSON endpoints.
+//
+// Operation endpoints take a definitions.Do encoded as JSON (the same
+// structure the CLI fills from its flags) and answer with
+// {"result": do.Result, "output": "..."}:
+//
+//	POST /services/{start,kill,rm,update,ports,cat,export}
+//	POST /chains/{start,kill,rm,update,inspect,ports,cat,checkout,current,register,export}
+//	POST /data/{import,export,rename,inspect,rm}
+//	POST /keys/{gen,pub,import,convert}
+//	POST /files/{get,put,pin,cat,ls,cached}
+//
+// Perform endpoints take a perform.HTTPRequest and are what
+// perform.HTTPClient talks to. logs and exec stream their output:
+//
+//	POST /services/{run,stop,remove,rebuild,pull,inspect,logs,exec}
+//	POST /chains/logs
+//	GET  /containers[?all=true&label=K=V]
+//	GET  /containers/NAME
+//	GET  /version
+//
+// Errors are returned as perform.HTTPError with a non-2xx status.
+//
+// The agent serves this machine only (DefaultHost) unless it is given a
+// token, which clients send as "Authorization: Bearer TOKEN", and a TLS
+// certificate. Services and chains the agent starts may not be
+// privileged, add capabilities, share the host network or PID namespace
+// or bind host directories outside of the eris root. Operation requests
+// may not set environment variables or links, and the host paths they
+// name must lie in the eris root. Definitions cannot be written through
+// the agent.
+package agent
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/eris-ltd/eris-cli/chains"
+	"github.com/eris-ltd/eris-cli/config"
+	"github.com/eris-ltd/eris-cli/data"
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/files"
+	"github.com/eris-ltd/eris-cli/keys"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/services"
+	"github.com/eris-ltd/eris-cli/util"
+
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// Default agent address.
+const DefaultHost = "localhost:46651"
+
+// Response is the body of a successful operation endpoint call.
+type Response struct {
+	Result string `json:"result"`
+	Output string `json:"output,omitempty"`
+}
+
+// Server routes agent requests. eris keeps the command output writer
+// in a global, so operations which produce output run one at a time.
+// Logs and exec write their output straight to the response instead
+// and stream without holding the lock.
+type Server struct {
+	sync.Mutex
+	mux   *http.ServeMux
+	token string
+}
+
+// The operations exposed over Do-style endpoints.
+var operations = map[string]func(*definitions.Do) error{
+	"/services/start":  services.StartService,
+	"/services/kill":   services.KillService,
+	"/services/rm":     services.RmService,
+	"/services/update": services.UpdateService,
+	"/services/ports":  services.PortsService,
+	"/services/cat":    services.CatService,
+	"/services/export": services.ExportService,
+	"/chains/start":    chains.StartChain,
+	"/chains/kill":     chains.KillChain,
+	"/chains/rm":       chains.RmChain,
+	"/chains/update":   chains.UpdateChain,
+	"/chains/inspect":  chains.InspectChain,
+	"/chains/ports":    chains.PortsChain,
+	"/chains/cat":      chains.CatChain,
+	"/chains/checkout": chains.CheckoutChain,
+	"/chains/current":  chains.CurrentChain,
+	"/chains/register": chains.RegisterChain,
+	"/chains/export":   chains.ExportChain,
+	"/data/import":     data.ImportData,
+	"/data/export":     data.ExportData,
+	"/data/rename":     data.RenameData,
+	"/data/inspect":    data.InspectData,
+	"/data/rm":         data.RmData,
+	"/keys/gen":        keys.GenerateKey,
+	"/keys/pub":        keys.GetPubKey,
+	"/keys/import":     keys.ImportKey,
+	"/keys/convert":    keys.ConvertKey,
+	"/files/get":       files.GetFiles,
+	"/files/put":       files.PutFiles,
+	"/files/pin":       files.PinFiles,
+	"/files/cat":       files.CatFiles,
+	"/files/ls":        files.ListFiles,
+	"/files/cached":    files.ManagePinned,
+}
+
+// Host paths operations read or write, by endpoint.
+var hostPaths = map[string]func(*definitions.Do) []*string{
+	"/data/import": func(do *definitions.Do) []*string { return []*string{&do.Source} },
+	"/data/export": func(do *definitions.Do) []*string { return []*string{&do.Destination} },
+	"/keys/import": func(do *definitions.Do) []*string { return []*string{&do.Source} },
+	"/files/get":   func(do *definitions.Do) []*string { return []*string{&do.Path, &do.CSV, &do.NewName} },
+	"/files/put":   func(do *definitions.Do) []*string { return []*string{&do.Name} },
+	"/files/pin":   func(do *definitions.Do) []*string { return []*string{&do.CSV} },
+}
+
+// NewServer returns an agent server. If token is not empty, requests
+// must carry it in an "Authorization: Bearer TOKEN" header.
+func NewServer(token string) *Server {
+	s := &Server{mux: http.NewServeMux(), token: token}
+
+	// Services and chains started on behalf of an operation load their
+	// definitions on this host; check them as they are configured.
+	perform.ServiceCheck = checkService
+
+	for path, op := range operations {
+		s.mux.HandleFunc(path, s.operation(path, op))
+	}
+
+	s.mux.HandleFunc("/services/run", s.runService)
+	s.mux.HandleFunc("/services/stop", s.stopService)
+	s.mux.HandleFunc("/services/remove", s.removeService)
+	s.mux.HandleFunc("/services/rebuild", s.rebuildService)
+	s.mux.HandleFunc("/services/pull", s.pullService)
+	s.mux.HandleFunc("/services/inspect", s.inspectService)
+	s.mux.HandleFunc("/services/exec", s.execService)
+	s.mux.HandleFunc("/services/logs", s.logs(definitions.TypeService))
+	s.mux.HandleFunc("/chains/logs", s.logs(definitions.TypeChain))
+	s.mux.HandleFunc("/containers", s.listContainers)
+	s.mux.HandleFunc("/containers/", s.inspectContainer)
+	s.mux.HandleFunc("/version", s.version)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger.Infof("Agent request =>\t\t%s %s\n", r.Method, r.URL.Path)
+
+	if s.token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, fmt.Errorf("a valid agent token is required"))
+		return
+	}
+
+	// A failing operation must not take the agent down with it.
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Errorf("Agent operation failed =>\t%s: %v\n", r.URL.Path, err)
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("%v", err))
+		}
+	}()
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// Serve listens on host and serves agent requests until it fails. The
+// requests are served over TLS if certFile and keyFile are given. Other
+// than the loopback addresses, the agent only listens with both a token
+// and TLS.
+func Serve(host, token, certFile, keyFile string) error {
+	if host == "" {
+		host = DefaultHost
+	}
+	if (certFile == "") != (keyFile == "") {
+		return fmt.Errorf("The marmots need both a TLS certificate and a key to serve the agent over TLS.")
+	}
+	if !isLoopback(host) && (token == "" || certFile == "") {
+		return fmt.Errorf("The marmots will only serve the agent on %s with a token and TLS (--token, --tls-cert and --tls-key). Use --host=%s to serve this machine only.", host, DefaultHost)
+	}
+
+	logger.Printf("Eris agent listening on =>\t%s\n", host)
+	if certFile != "" {
+		return http.ListenAndServeTLS(host, certFile, keyFile, NewServer(token))
+	}
+	return http.ListenAndServe(host, NewServer(token))
+}
+
+// isLoopback reports whether host (HOST:PORT) only listens on this
+// machine. An empty HOST listens on all interfaces.
+func isLoopback(host string) bool {
+	name, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return false
+	}
+	if name == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(name)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) operation(path string, op func(*definitions.Do) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !post(w, r) {
+			return
+		}
+
+		do := definitions.NowDo()
+		if err := json.NewDecoder(r.Body).Decode(do); err != nil && err != io.EOF {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := checkDo(path, do); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+
+		var output bytes.Buffer
+		err := s.withWriter(&output, func() error {
+			return op(do)
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeJSON(w, Response{Result: do.Result, Output: output.String()})
+	}
+}
+
+func (s *Server) runService(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := checkService(req.Service, req.Operation); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	if err := s.withWriter(new(bytes.Buffer), func() error {
+		return perform.DockerRunService(req.Service, req.Operation)
+	}); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, Response{Result: "success"})
+}
+
+func (s *Server) stopService(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.withWriter(new(bytes.Buffer), func() error {
+		return perform.DockerStop(req.Service, req.Operation, req.Timeout)
+	}); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, Response{Result: "success"})
+}
+
+func (s *Server) removeService(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.withWriter(new(bytes.Buffer), func() error {
+		return perform.DockerRemove(req.Service, req.Operation, req.Data, req.Volumes)
+	}); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, Response{Result: "success"})
+}
+
+func (s *Server) rebuildService(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := checkService(req.Service, req.Operation); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	if err := s.withWriter(new(bytes.Buffer), func() error {
+		return perform.DockerRebuild(req.Service, req.Operation, req.Pull, req.Timeout)
+	}); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, Response{Result: "success"})
+}
+
+func (s *Server) pullService(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := checkService(req.Service, req.Operation); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	if err := s.withWriter(new(bytes.Buffer), func() error {
+		return perform.DockerPull(req.Service, req.Operation)
+	}); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, Response{Result: "success"})
+}
+
+func (s *Server) inspectService(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var output bytes.Buffer
+	if err := s.withWriter(&output, func() error {
+		return perform.DockerInspect(req.Service, req.Operation, req.Field)
+	}); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Write(output.Bytes())
+}
+
+func (s *Server) execService(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := checkService(req.Service, req.Operation); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	// Headers go out with the first byte of output, so errors after
+	// that point can only be appended to the stream.
+	out := &flushWriter{w: w}
+	if err := perform.DockerExecServiceTo(req.Service, req.Operation, out, out); err != nil {
+		if !out.written {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		fmt.Fprintf(out, "\n%v\n", err)
+	}
+}
+
+// logs streams the logs of a service or a chain container. The
+// container is resolved from the definition if the operation does not
+// name it. The logs are written to the response rather than the global
+// writer, so a followed log does not hold the lock.
+func (s *Server) logs(typ string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, ok := decodeRequest(w, r)
+		if !ok {
+			return
+		}
+
+		name := req.Operation.SrvContainerName
+		if name == "" {
+			name = util.ContainersName(typ, req.Service.Name, req.Operation.ContainerNumber)
+		}
+		if _, err := util.Backend.InspectContainer(name); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+
+		tail := req.Tail
+		if tail == "" {
+			tail = "all"
+		}
+
+		out := &flushWriter{w: w}
+		if err := util.Backend.Logs(docker.LogsOptions{
+			Container:    name,
+			OutputStream: out,
+			ErrorStream:  out,
+			Follow:       req.Follow,
+			Stdout:       true,
+			Stderr:       true,
+			Tail:         tail,
+			RawTerminal:  true,
+		}); err != nil && !out.written {
+			writeError(w, http.StatusInternalServerError, err)
+		}
+	}
+}
+
+func (s *Server) listContainers(w http.ResponseWriter, r *http.Request) {
+	opts := docker.ListContainersOptions{
+		All: r.URL.Query().Get("all") == "true",
+	}
+	if labels := r.URL.Query()["label"]; len(labels) != 0 {
+		opts.Filters = map[string][]string{"label": labels}
+	}
+
+	containers, err := util.Backend.ListContainers(opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if containers == nil {
+		containers = []docker.APIContainers{}
+	}
+	writeJSON(w, containers)
+}
+
+func (s *Server) inspectContainer(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/containers/")
+
+	container, err := util.Backend.InspectContainer(name)
+	if err != nil {
+		if _, ok := err.(*docker.NoSuchContainer); ok {
+			writeError(w, http.StatusNotFound, err)
+		} else {
+			writeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	writeJSON(w, container)
+}
+
+func (s *Server) version(w http.ResponseWriter, r *http.Request) {
+	env, err := util.Backend.Version()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, env)
+}
+
+// withWriter runs f with the global command output redirected to out.
+func (s *Server) withWriter(out io.Writer, f func() error) error {
+	s.Lock()
+	defer s.Unlock()
+
+	saved := config.GlobalConfig.Writer
+	config.GlobalConfig.Writer = out
+	defer func() { config.GlobalConfig.Writer = saved }()
+
+	return f()
+}
+
+// checkService refuses services which would give a remote caller
+// control of the agent's host: privileged ones, ones adding
+// capabilities, sharing the host network or PID namespace, or binding
+// host directories outside of the eris root.
+func checkService(srv *definitions.Service, ops *definitions.Operation) error {
+	if srv == nil {
+		srv = &definitions.Service{}
+	}
+	if ops == nil {
+		ops = definitions.BlankOperation()
+	}
+	if ops.Privileged || len(ops.CapAdd) != 0 {
+		return fmt.Errorf("The agent does not run privileged services or services adding capabilities (%s).", srv.Name)
+	}
+	if srv.Net == "host" || srv.PID == "host" {
+		return fmt.Errorf("The agent does not run services in the host network or PID namespace (%s).", srv.Name)
+	}
+	for _, volume := range srv.Volumes {
+		parts := strings.SplitN(volume, ":", 2)
+		if len(parts) != 2 || !strings.ContainsAny(parts[0], "/\\$") {
+			// Anonymous and named volumes.
+			continue
+		}
+		if !inErisRoot(strings.Replace(parts[0], "$eris", dirs.ErisRoot, 1)) {
+			return fmt.Errorf("The agent does not bind host directories outside of %s (%s: %s).", dirs.ErisRoot, srv.Name, parts[0])
+		}
+	}
+	if ops.Volume != "" && !inErisRoot(filepath.Join(dirs.ErisRoot, ops.Volume)) {
+		return fmt.Errorf("The agent does not bind host directories outside of %s (%s: %s).", dirs.ErisRoot, srv.Name, ops.Volume)
+	}
+	return nil
+}
+
+// checkDo vets an operation request: the services and operations it
+// carries must pass checkService, it may not set environment variables
+// or links, and the host paths the operation at path uses must lie in
+// the eris root. Relative host paths are taken from the eris root.
+func checkDo(path string, do *definitions.Do) error {
+	if err := checkService(do.Service, do.Operations); err != nil {
+		return err
+	}
+	if do.ServiceDefinition != nil {
+		if err := checkService(do.ServiceDefinition.Service, do.ServiceDefinition.Operations); err != nil {
+			return err
+		}
+	}
+	if len(do.Env) != 0 || len(do.Links) != 0 {
+		return fmt.Errorf("The agent does not set environment variables or links from requests.")
+	}
+
+	if paths, ok := hostPaths[path]; ok {
+		for _, p := range paths(do) {
+			if *p == "" {
+				continue
+			}
+			if !filepath.IsAbs(*p) {
+				*p = filepath.Join(dirs.ErisRoot, *p)
+			}
+			if !inErisRoot(*p) {
+				return fmt.Errorf("The agent does not use host paths outside of %s (%s).", dirs.ErisRoot, *p)
+			}
+		}
+	}
+	return nil
+}
+
+// inErisRoot reports whether the host path lies in dirs.ErisRoot.
+func inErisRoot(path string) bool {
+	rel, err := filepath.Rel(dirs.ErisRoot, filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// decodeRequest reads a perform.HTTPRequest, filling in the service
+// and chain container names from the definitions if they are missing
+// (which is the case for hand-made requests rather than ones sent by
+// perform.HTTPClient).
+func decodeRequest(w http.ResponseWriter, r *http.Request) (*perform.HTTPRequest, bool) {
+	if !post(w, r) {
+		return nil, false
+	}
+
+	req := &perform.HTTPRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	if req.Service == nil || req.Service.Name == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("a service with a name is required"))
+		return nil, false
+	}
+	if req.Operation == nil {
+		req.Operation = definitions.BlankOperation()
+	}
+	if req.Operation.ContainerNumber == 0 {
+		req.Operation.ContainerNumber = 1
+	}
+
+	if req.Operation.SrvContainerName == "" && strings.HasPrefix(r.URL.Path, "/services/") {
+		srv, err := loaders.LoadServiceDefinition(req.Service.Name, false, req.Operation.ContainerNumber)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return nil, false
+		}
+		util.Merge(srv.Operations, req.Operation)
+		req.Service, req.Operation = srv.Service, srv.Operations
+	}
+
+	return req, true
+}
+
+func post(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != "POST" {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s requires POST", r.URL.Path))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Errorf("Could not write the agent response: %v\n", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	logger.Infof("Agent error =>\t\t\t%v\n", err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(perform.HTTPError{Error: err.Error()})
+}
+
+// flushWriter sends output to the client as soon as it is written.
+type flushWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	f.written = true
+	n, err := f.w.Write(p)
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,344 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/util"
+
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+var (
+	fake   *util.FakeBackend
+	server *httptest.Server
+	client *perform.HTTPClient
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	config.GlobalConfig, err = config.SetGlobalObject(os.Stdout, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+
+	fake = util.NewFakeBackend()
+	util.Backend = fake
+
+	server = httptest.NewServer(NewServer(""))
+	client = perform.NewHTTPClient(server.URL)
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
+func createKeys(t *testing.T) {
+	fake.RemoveContainer(docker.RemoveContainerOptions{ID: "eris_service_keys_1", Force: true})
+
+	ops := def.BlankOperation()
+	ops.ContainerType = def.TypeService
+	ops.ContainerNumber = 1
+	if _, err := fake.CreateContainer(docker.CreateContainerOptions{
+		Name: "eris_service_keys_1",
+		Config: &docker.Config{
+			Image:  "quay.io/eris/keys",
+			Labels: util.Labels("keys", ops),
+		},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := fake.StartContainer("eris_service_keys_1", nil); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+}
+
+func TestContainers(t *testing.T) {
+	createKeys(t)
+
+	containers, err := client.ListContainers(docker.ListContainersOptions{All: true})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(containers) != 1 || containers[0].Names[0] != "/eris_service_keys_1" {
+		t.Fatalf("expected the keys container, got %v", containers)
+	}
+
+	containers, err = client.ListContainers(docker.ListContainersOptions{Filters: map[string][]string{"label": {def.LabelType + "=" + def.TypeChain}}})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(containers) != 0 {
+		t.Fatalf("expected no chain containers, got %v", containers)
+	}
+
+	container, err := client.InspectContainer("eris_service_keys_1")
+	if err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+	if !container.State.Running {
+		t.Fatalf("expected the keys container to be running")
+	}
+
+	if _, err := client.InspectContainer("eris_service_nope_1"); err == nil {
+		t.Fatalf("expected an error inspecting a missing container")
+	} else if _, ok := err.(*docker.NoSuchContainer); !ok {
+		t.Fatalf("expected NoSuchContainer, got %v", err)
+	}
+
+	if _, err := client.Version(); err != nil {
+		t.Fatalf("version: %v", err)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	createKeys(t)
+	fake.SetLogs("eris_service_keys_1", "hello marmots\n")
+
+	saved := config.GlobalConfig.Writer
+	defer func() { config.GlobalConfig.Writer = saved }()
+	out := new(bytes.Buffer)
+	config.GlobalConfig.Writer = out
+
+	ops := def.BlankOperation()
+	ops.SrvContainerName = "eris_service_keys_1"
+	if err := client.LogsService(&def.Service{Name: "keys"}, ops, false, "all"); err != nil {
+		t.Fatalf("logs: %v", err)
+	}
+	if out.String() != "hello marmots\n" {
+		t.Fatalf("expected the container logs, got %q", out.String())
+	}
+
+	ops.SrvContainerName = "eris_service_nope_1"
+	if err := client.LogsService(&def.Service{Name: "nope"}, ops, false, "all"); err == nil {
+		t.Fatalf("expected an error getting logs of a missing container")
+	}
+}
+
+func TestRemoveRebuildPull(t *testing.T) {
+	createKeys(t)
+
+	srv := &def.Service{Name: "keys", Image: "quay.io/eris/keys"}
+	ops := def.BlankOperation()
+	ops.SrvContainerName = "eris_service_keys_1"
+	ops.DataContainerName = "eris_data_keys_1"
+	ops.ContainerType = def.TypeService
+	ops.ContainerNumber = 1
+
+	if err := client.PullService(srv, ops); err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+	if container, err := fake.InspectContainer("eris_service_keys_1"); err != nil || !container.State.Running {
+		t.Fatalf("expected the keys container to be restarted, got %v", err)
+	}
+
+	if err := client.RebuildService(srv, ops, false, 10); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if container, err := fake.InspectContainer("eris_service_keys_1"); err != nil || !container.State.Running {
+		t.Fatalf("expected the keys container to be running after the rebuild, got %v", err)
+	}
+
+	if err := client.StopService(srv, ops, 10); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if err := client.RemoveService(srv, ops, true, false); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := fake.InspectContainer("eris_service_keys_1"); err == nil {
+		t.Fatalf("expected the keys container to be removed")
+	}
+
+	if err := client.RebuildService(&def.Service{Name: "etc", Volumes: []string{"/etc:/etc"}}, ops, false, 10); err == nil {
+		t.Fatalf("expected an error rebuilding a service binding /etc")
+	}
+}
+
+func TestRequests(t *testing.T) {
+	resp, err := http.Get(server.URL + "/services/logs")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %s", http.StatusMethodNotAllowed, resp.Status)
+	}
+
+	resp, err = http.Post(server.URL+"/services/logs", "application/json", strings.NewReader(`{"service": {}}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %s", http.StatusBadRequest, resp.Status)
+	}
+
+	resp, err = http.Post(server.URL+"/services/cat", "application/json", strings.NewReader(`{"Name": "no_such_service"}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected %d, got %s", http.StatusInternalServerError, resp.Status)
+	}
+	var e perform.HTTPError
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || !strings.Contains(e.Error, "no_such_service") {
+		t.Fatalf("expected an error naming the service, got %v (%v)", e, err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	secured := httptest.NewServer(NewServer("secret"))
+	defer secured.Close()
+
+	anonymous := perform.NewHTTPClient(secured.URL)
+	anonymous.Token = ""
+	if _, err := anonymous.Version(); err == nil || !strings.Contains(err.Error(), "token") {
+		t.Fatalf("expected a token error, got %v", err)
+	}
+
+	wrong := perform.NewHTTPClient(secured.URL)
+	wrong.Token = "guess"
+	if _, err := wrong.Version(); err == nil {
+		t.Fatalf("expected an error with a wrong token")
+	}
+
+	authorized := perform.NewHTTPClient(secured.URL)
+	authorized.Token = "secret"
+	if _, err := authorized.Version(); err != nil {
+		t.Fatalf("version: %v", err)
+	}
+}
+
+func TestServeHost(t *testing.T) {
+	for _, host := range []string{"0.0.0.0:46651", ":46651", "10.0.0.1:46651"} {
+		if err := Serve(host, "", "", ""); err == nil || !strings.Contains(err.Error(), "token and TLS") {
+			t.Fatalf("%s: expected a token and TLS error, got %v", host, err)
+		}
+		if err := Serve(host, "secret", "", ""); err == nil || !strings.Contains(err.Error(), "token and TLS") {
+			t.Fatalf("%s: expected a TLS error, got %v", host, err)
+		}
+	}
+	if err := Serve(DefaultHost, "", "agent.crt", ""); err == nil {
+		t.Fatalf("expected an error with a certificate but no key")
+	}
+
+	for host, loopback := range map[string]bool{
+		"localhost:46651": true,
+		"127.0.0.1:46651": true,
+		"[::1]:46651":     true,
+		"0.0.0.0:46651":   false,
+		":46651":          false,
+		"example.com:80":  false,
+	} {
+		if isLoopback(host) != loopback {
+			t.Fatalf("%s: expected loopback %v", host, loopback)
+		}
+	}
+}
+
+func TestCheckService(t *testing.T) {
+	for _, srv := range []*def.Service{
+		{Name: "plain", Volumes: []string{"/data", "named:/data", "$eris/keys:/home/eris/.eris/keys"}},
+		{Name: "root", Volumes: []string{dirs.ErisRoot + "/chains:/home/eris/.eris/chains"}},
+	} {
+		if err := checkService(srv, def.BlankOperation()); err != nil {
+			t.Fatalf("%s: expected no error, got %v", srv.Name, err)
+		}
+	}
+
+	for _, srv := range []*def.Service{
+		{Name: "etc", Volumes: []string{"/etc:/etc"}},
+		{Name: "escape", Volumes: []string{"$eris/../..:/host"}},
+		{Name: "pwd", Volumes: []string{"$pwd:/pwd"}},
+		{Name: "net", Net: "host"},
+		{Name: "pid", PID: "host"},
+	} {
+		if err := checkService(srv, def.BlankOperation()); err == nil {
+			t.Fatalf("%s: expected an error", srv.Name)
+		}
+	}
+
+	ops := def.BlankOperation()
+	ops.Privileged = true
+	if err := checkService(&def.Service{Name: "privileged"}, ops); err == nil {
+		t.Fatalf("expected an error for a privileged service")
+	}
+	ops = def.BlankOperation()
+	ops.CapAdd = []string{"SYS_ADMIN"}
+	if err := checkService(&def.Service{Name: "caps"}, ops); err == nil {
+		t.Fatalf("expected an error for added capabilities")
+	}
+
+	body := `{"service": {"name": "etc", "volumes": ["/etc:/etc"]}, "operation": {"SrvContainerName": "eris_service_etc_1"}}`
+	resp, err := http.Post(server.URL+"/services/run", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected %d, got %s", http.StatusForbidden, resp.Status)
+	}
+
+	resp, err = http.Post(server.URL+"/keys/export", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected /keys/export to be gone, got %s", resp.Status)
+	}
+}
+
+func TestCheckDo(t *testing.T) {
+	for _, tc := range []struct {
+		path, body string
+		status     int
+	}{
+		{"/services/start", `{"Operations": {"Privileged": true}}`, http.StatusForbidden},
+		{"/chains/start", `{"Env": ["A=B"]}`, http.StatusForbidden},
+		{"/chains/start", `{"Links": ["keys:keys"]}`, http.StatusForbidden},
+		{"/data/import", `{"Name": "etc", "Source": "/etc"}`, http.StatusForbidden},
+		{"/data/export", `{"Name": "up", "Destination": "../.."}`, http.StatusForbidden},
+		{"/files/get", `{"Path": "/etc/passwd"}`, http.StatusForbidden},
+		{"/services/new", `{}`, http.StatusNotFound},
+		{"/services/import", `{}`, http.StatusNotFound},
+		{"/chains/new", `{}`, http.StatusNotFound},
+		{"/chains/import", `{}`, http.StatusNotFound},
+	} {
+		resp, err := http.Post(server.URL+tc.path, "application/json", strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatalf("post: %v", err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Fatalf("%s %s: expected %d, got %s", tc.path, tc.body, tc.status, resp.Status)
+		}
+	}
+
+	do := def.NowDo()
+	do.Source = "scratch"
+	if err := checkDo("/data/import", do); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if do.Source != filepath.Join(dirs.ErisRoot, "scratch") {
+		t.Fatalf("expected the source to be taken from the eris root, got %q", do.Source)
+	}
+
+	ops := def.BlankOperation()
+	ops.Volume = "../../etc"
+	if err := checkService(&def.Service{Name: "volume"}, ops); err == nil {
+		t.Fatalf("expected an error binding a volume outside of the eris root")
+	}
+
+	ops = def.BlankOperation()
+	ops.SrvContainerName = "eris_service_etc_1"
+	ops.ContainerType = def.TypeService
+	if err := perform.DockerRunService(&def.Service{Name: "etc", Image: "quay.io/eris/base", Volumes: []string{"/etc:/etc"}}, ops); err == nil {
+		t.Fatalf("expected an error starting a service binding /etc")
+	}
+}
@@ -0,0 +1,7 @@
+package agent
+
+import (
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+)
+
+var logger = AddLogger("agent")
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/eris-ltd/eris-cli/config"
 	def "github.com/eris-ltd/eris-cli/definitions"
@@ -345,6 +349,243 @@ func TestChainsNewConfigOpts(t *testing.T) {
 	}
 }
 
+func TestChainsNewNodes(t *testing.T) {
+	chainID := "testChainsNewNodes"
+	do := def.NowDo()
+
+	do.Name = chainID
+	do.N = 3
+	do.Operations.ContainerNumber = 1
+	do.Operations.PublishAllPorts = true
+	logger.Infof("Creating chain (from tests) =>\t%s\n", do.Name)
+	tests.IfExit(NewChain(do))
+
+	var keys []string
+	for i := 1; i <= int(do.N); i++ {
+		defer removeChainContainer(t, chainID, i)
+
+		chain, err := loaders.LoadChainDefinition(chainID, false, i)
+		if err != nil {
+			tests.IfExit(err)
+		}
+		if !IsChainRunning(chain) {
+			tests.IfExit(fmt.Errorf("expected node %d of the chain to be running", i))
+		}
+
+		// every node has its own validator key
+		ops := loaders.LoadDataDefinition(do.Name, i)
+		ops.Args = []string{"cat", fmt.Sprintf("/home/eris/.eris/chains/%s/priv_validator.json", chainID)}
+		key := trimResult(string(runContainer(t, ops)))
+		for _, k := range keys {
+			if k == key {
+				tests.IfExit(fmt.Errorf("expected node %d to have its own validator key", i))
+			}
+		}
+		keys = append(keys, key)
+
+		// and every node is seeded with all the others
+		ops.Args = []string{"cat", fmt.Sprintf("/home/eris/.eris/chains/%s/config.toml", chainID)}
+		conf := string(runContainer(t, ops))
+		for j := 1; j <= int(do.N); j++ {
+			if seeded := strings.Contains(conf, util.ChainContainersName(chainID, j)+":46656"); seeded != (j != i) {
+				tests.IfExit(fmt.Errorf("expected node %d to be seeded with the other nodes only, got %s", i, conf))
+			}
+		}
+	}
+}
+
+func TestInstallChain(t *testing.T) {
+	chainID := "testInstallChain"
+	genesis, err := ioutil.ReadFile(path.Join(common.ChainsPath, "default", "genesis.json"))
+	if err != nil {
+		tests.IfExit(err)
+	}
+	etcb := stubEtcb(map[string]string{
+		chainID + "/genesis": string(genesis),
+		chainID + "/seeds":   "1.1.1.1:46656",
+	})
+	defer etcb.Close()
+
+	do := def.NowDo()
+	do.Name = chainID
+	do.Gateway = etcb.URL
+	do.Operations.ContainerNumber = 1
+	do.Operations.PublishAllPorts = true
+	logger.Infof("Installing chain (from tests) =>\t%s\n", do.Name)
+	tests.IfExit(InstallChain(do))
+	defer removeChainContainer(t, chainID, do.Operations.ContainerNumber)
+
+	if !util.IsKnownChain(chainID) {
+		tests.IfExit(fmt.Errorf("expected a chain definition file for %s", chainID))
+	}
+
+	ops := loaders.LoadDataDefinition(do.Name, do.Operations.ContainerNumber)
+	ops.Args = []string{"cat", fmt.Sprintf("/home/eris/.eris/chains/%s/config.toml", chainID)}
+	conf := string(runContainer(t, ops))
+	if !strings.Contains(conf, "1.1.1.1:46656") {
+		tests.IfExit(fmt.Errorf("expected the registered seeds in config.toml, got %s", conf))
+	}
+
+	do = def.NowDo()
+	do.Name = "testInstallChainMissing"
+	do.Gateway = etcb.URL
+	if err := InstallChain(do); err == nil {
+		tests.IfExit(fmt.Errorf("expected an error installing an unregistered chain"))
+	}
+}
+
+func TestEtcbEntry(t *testing.T) {
+	etcb := stubEtcb(map[string]string{
+		"etcbchain/genesis": "{}",
+		"etcbchain/image":   "quay.io/eris/erisdb:0.11",
+	})
+	defer etcb.Close()
+
+	entry, err := getEtcbEntry(etcb.URL, "etcbchain")
+	if err != nil {
+		tests.IfExit(err)
+	}
+	if entry.Genesis != "{}" || entry.Image != "quay.io/eris/erisdb:0.11" || entry.Seeds != "" {
+		tests.IfExit(fmt.Errorf("unexpected etcb entry %v", entry))
+	}
+
+	if _, err := getEtcbEntry(etcb.URL, "nochain"); err == nil {
+		tests.IfExit(fmt.Errorf("expected an error for an unregistered chain"))
+	}
+}
+
+func TestChainStatus(t *testing.T) {
+	blockTime := time.Now().Add(-time.Second).UTC().Truncate(time.Second)
+	rpc := stubRPC(map[string]string{
+		"status":     fmt.Sprintf(`[32, {"node_info": {"network": "statuschain"}, "latest_block_hash": "ab12", "latest_block_height": 42, "latest_block_time": %d}]`, blockTime.UnixNano()),
+		"net_info":   `[33, {"listening": true, "listeners": [], "peers": [{}, {}]}]`,
+		"validators": `[35, {"block_height": 42, "validators": [{"address": "37236df251ab70022b1da351f08a20fb52443e37", "voting_power": 10}]}]`,
+	})
+	defer rpc.Close()
+
+	status, err := getChainStatus(rpc.URL)
+	if err != nil {
+		tests.IfExit(err)
+	}
+	expected := &ChainStatus{
+		ChainID:    "statuschain",
+		RPC:        rpc.URL,
+		Height:     42,
+		Hash:       "AB12",
+		Time:       blockTime,
+		Peers:      2,
+		Validators: []*StatusValidator{{Address: "37236DF251AB70022B1DA351F08A20FB52443E37", VotingPower: 10}},
+		Sync:       "synced",
+	}
+	if !reflect.DeepEqual(status, expected) {
+		tests.IfExit(fmt.Errorf("unexpected status %v, expected %v", status, expected))
+	}
+
+	table := statusTable(status)
+	for _, s := range []string{"statuschain", "42", "AB12", "37236DF251AB70022B1DA351F08A20FB52443E37", "synced"} {
+		if !strings.Contains(table, s) {
+			tests.IfExit(fmt.Errorf("expected %s in the status table, got %s", s, table))
+		}
+	}
+
+	if state := syncState(status, nil, blockTime.Add(2*staleBlockAge)); state != "stalled" {
+		tests.IfExit(fmt.Errorf("expected a stalled chain, got %s", state))
+	}
+
+	down := stubRPC(map[string]string{})
+	defer down.Close()
+	if _, err := getChainStatus(down.URL); err == nil {
+		tests.IfExit(fmt.Errorf("expected an error from a failing node"))
+	}
+}
+
+func TestSnapshotRestoreChain(t *testing.T) {
+	aChain := "snapchain"
+	restored := "restoredchain"
+	testNewChain(aChain)
+	defer testKillChain(t, aChain)
+
+	do := def.NowDo()
+	do.Name = aChain
+	do.Destination = filepath.Join(erisDir, "snapchain.tar.gz")
+	do.Operations.ContainerNumber = 1
+	logger.Infof("Snapshotting chain (from tests) =>\t%s\n", do.Name)
+	if err := SnapshotChain(do); err != nil {
+		tests.IfExit(err)
+	}
+	defer os.Remove(do.Destination)
+	testExistAndRun(t, aChain, true, true)
+
+	tmp, err := ioutil.TempDir(erisDir, "snapshot")
+	if err != nil {
+		tests.IfExit(err)
+	}
+	defer os.RemoveAll(tmp)
+	if err := readArchive(do.Destination, tmp); err != nil {
+		tests.IfExit(err)
+	}
+	manifest, err := readSnapshotManifest(filepath.Join(tmp, snapshotManifestFile))
+	if err != nil {
+		tests.IfExit(err)
+	}
+	if manifest.ChainID != aChain || manifest.Image == "" {
+		tests.IfExit(fmt.Errorf("Unexpected snapshot manifest %v", manifest))
+	}
+	if _, err := os.Stat(filepath.Join(tmp, snapshotDir, "genesis.json")); err != nil {
+		tests.IfExit(fmt.Errorf("No genesis.json in the snapshot: %v", err))
+	}
+
+	do2 := def.NowDo()
+	do2.Name = restored
+	do2.Path = do.Destination
+	do2.Operations.ContainerNumber = 1
+	logger.Infof("Restoring chain (from tests) =>\t%s\n", do2.Name)
+	if err := RestoreChain(do2); err != nil {
+		tests.IfExit(err)
+	}
+	defer os.Remove(filepath.Join(common.ChainsPath, restored+".toml"))
+
+	if !util.IsDataContainer(restored, 1) {
+		tests.IfExit(fmt.Errorf("No data container for the restored chain %s", restored))
+	}
+	chain, err := loaders.LoadChainDefinition(restored, false, 1)
+	if err != nil {
+		tests.IfExit(err)
+	}
+	if chain.ChainID != aChain {
+		tests.IfExit(fmt.Errorf("Wrong chain_id of the restored chain. Got %s, expected %s", chain.ChainID, aChain))
+	}
+
+	ops := loaders.LoadDataDefinition(restored, 1)
+	ops.Args = []string{"test", "-f", path.Join(common.ErisContainerRoot, "chains", restored, "genesis.json")}
+	if _, err := perform.DockerRunData(ops, nil); err != nil {
+		tests.IfExit(fmt.Errorf("No genesis.json in the restored data container: %v", err))
+	}
+
+	// restoring again replaces the chain directory only
+	stray := path.Join(common.ErisContainerRoot, "chains", restored, "stray")
+	kept := path.Join(common.ErisContainerRoot, "kept")
+	ops.Args = []string{"touch", stray, kept}
+	if _, err := perform.DockerRunData(ops, nil); err != nil {
+		tests.IfExit(err)
+	}
+	if err := RestoreChain(do2); err != nil {
+		tests.IfExit(err)
+	}
+	ops.Args = []string{"test", "!", "-e", stray}
+	if _, err := perform.DockerRunData(ops, nil); err != nil {
+		tests.IfExit(fmt.Errorf("The restore left a stray file in the chain directory: %v", err))
+	}
+	ops.Args = []string{"test", "-f", kept}
+	if _, err := perform.DockerRunData(ops, nil); err != nil {
+		tests.IfExit(fmt.Errorf("The restore removed a file outside of the chain directory: %v", err))
+	}
+
+	if err := perform.DockerRemove(nil, ops, false, true); err != nil {
+		tests.IfExit(err)
+	}
+}
+
 func TestLogsChain(t *testing.T) {
 	testStartChain(t, chainName)
 	defer testKillChain(t, chainName)
@@ -880,6 +1121,33 @@ func removeChainContainer(t *testing.T, chainID string, cNum int) {
 	}
 }
 
+// stubEtcb answers get_name calls of the etcb RPC interface from names.
+func stubEtcb(names map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.Trim(r.URL.Query().Get("name"), `"`)
+		data, ok := names[name]
+		if !ok {
+			fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": "", "result": null, "error": "Name %s not found"}`, name)
+			return
+		}
+		entry, _ := json.Marshal(map[string]interface{}{"entry": etcbNameEntry{Name: name, Data: data}})
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": "", "result": [11, %s], "error": ""}`, entry)
+	}))
+}
+
+// stubRPC answers the calls of a tendermint RPC interface with the
+// results given by method.
+func stubRPC(results map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, ok := results[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": "", "result": null, "error": "Unknown method %s"}`, r.URL.Path)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": "", "result": %s, "error": ""}`, result)
+	}))
+}
+
 func runContainer(t *testing.T, ops *def.Operation) []byte {
 	oldWriter := config.GlobalConfig.Writer
 	newWriter := new(bytes.Buffer)
@@ -0,0 +1,167 @@
+package chains
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/config"
+	"github.com/eris-ltd/eris-cli/data"
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/util"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+)
+
+// tendermint's p2p port
+const p2pPort = "46656"
+
+// newChainCluster sets up a chain of do.N validator nodes on this host.
+// One genesis.json with do.N validators (and their priv_validator.json
+// files) is made with `mintgen random`, then each node i in 1..do.N gets
+// its own chain container (eris_chain_NAME_i) and data container, the
+// shared genesis and the i-th key. Every node uses all the other nodes
+// as its p2p seeds (see clusterPeers), so that the nodes find each other
+// whichever of them is started or restarted first.
+//
+//	do.Name - chain name
+//	do.N    - number of validator nodes
+//
+// All the other fields are used as with setupChain; do.GenesisFile and
+// do.Priv must be empty.
+func newChainCluster(do *definitions.Do) error {
+	if do.GenesisFile != "" || do.Priv != "" || do.CSV != "" {
+		return fmt.Errorf("The marmots make the genesis file and the validator keys of a chain with several nodes; please do not give --genesis, --priv or --csv with --nodes.")
+	}
+	if do.ChainID == "" {
+		do.ChainID = do.Name
+	}
+
+	keysDir, err := makeClusterKeys(do)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(keysDir))
+
+	for i := 1; i <= int(do.N); i++ {
+		links, seeds := clusterPeers(do.Name, i, int(do.N))
+		node := *do
+		node.Operations = &definitions.Operation{}
+		*node.Operations = *do.Operations
+		node.Operations.ContainerNumber = i
+		node.GenesisFile = filepath.Join(keysDir, "genesis.json")
+		node.Priv = filepath.Join(keysDir, fmt.Sprintf("priv_validator_%d.json", i-1))
+		node.Links = append(append([]string{}, do.Links...), links...)
+		node.ConfigOpts = append([]string{}, do.ConfigOpts...)
+		if len(seeds) != 0 {
+			node.ConfigOpts = append(node.ConfigOpts, "seeds="+strings.Join(seeds, ","))
+		}
+
+		logger.Infof("Setting up chain node =>\t%s:%d\n", do.Name, i)
+		if err := setupChain(&node, loaders.ErisChainNew); err != nil {
+			return fmt.Errorf("Error setting up node %d of the chain %s: %v", i, do.Name, err)
+		}
+	}
+
+	do.Result = "success"
+	return nil
+}
+
+// clusterPeers returns the links and the p2p seeds of node i of the
+// name chain of n nodes. The seeds are all the other nodes, known by
+// their container names on the eris network. Without networks, a
+// container only reaches the containers linked to it, which must exist
+// already, so node i is linked to nodes 1..i-1 (as node_j) and seeded
+// with those.
+func clusterPeers(name string, i, n int) (links, seeds []string) {
+	if util.Networks() == nil {
+		for j := 1; j < i; j++ {
+			peer := fmt.Sprintf("node_%d", j)
+			links = append(links, util.ChainContainersName(name, j)+":"+peer)
+			seeds = append(seeds, peer+":"+p2pPort)
+		}
+		return links, seeds
+	}
+
+	for j := 1; j <= n; j++ {
+		if j != i {
+			seeds = append(seeds, util.ChainContainersName(name, j)+":"+p2pPort)
+		}
+	}
+	return nil, seeds
+}
+
+// makeClusterKeys runs `mintgen random` in the chain image against the
+// data container of node 1 and exports the genesis.json and the
+// priv_validator_0..N-1.json files it makes to a temporary host
+// directory, which is returned. The keys are then removed from the
+// data container; each node only gets its own.
+func makeClusterKeys(do *definitions.Do) (string, error) {
+	ops := loaders.LoadDataDefinition(do.Name, 1)
+	if err := perform.DockerCreateData(ops); err != nil && err != perform.ErrContainerExists {
+		return "", fmt.Errorf("Error creating data container =>\t%v", err)
+	}
+
+	chain := loaders.MockChainDefinition(do.Name, do.ChainID, false, 1)
+	if do.Image != "" {
+		chain.Service.Image = do.Image
+	}
+	chain.Service.User = "root"
+	chain.Service.EntryPoint = "sh"
+
+	containerDir := path.Join(ErisContainerRoot, "chains", do.Name, "cluster")
+	logger.Infof("Making validator keys =>\t%s:%d\n", do.ChainID, do.N)
+	if err := runInChainData(ops, chain.Service, fmt.Sprintf("mkdir -p %s && mintgen random %d %s --dir=%s", containerDir, do.N, do.ChainID, containerDir)); err != nil {
+		return "", fmt.Errorf("Error making the validator keys: %v", err)
+	}
+	defer func() {
+		if err := runInChainData(ops, chain.Service, "rm -rf "+containerDir); err != nil {
+			logger.Infof("Could not remove the validator keys from the data container: %v\n", err)
+		}
+	}()
+
+	tmp, err := ioutil.TempDir(os.TempDir(), do.Name)
+	if err != nil {
+		return "", err
+	}
+	exportDo := definitions.NowDo()
+	exportDo.Name = do.Name
+	exportDo.Operations.ContainerNumber = 1
+	exportDo.Source = containerDir
+	exportDo.Destination = tmp
+	if err := data.ExportData(exportDo); err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+
+	// the export lands in tmp/cluster
+	keysDir := filepath.Join(tmp, "cluster")
+	for i := 0; i < int(do.N); i++ {
+		if _, err := os.Stat(filepath.Join(keysDir, fmt.Sprintf("priv_validator_%d.json", i))); err != nil {
+			os.RemoveAll(tmp)
+			return "", fmt.Errorf("The marmots could not find the key of validator %d: %v", i, err)
+		}
+	}
+	return keysDir, nil
+}
+
+// runInChainData runs the shell command in a throwaway container of the
+// chain image mounting the data container. Its output is only logged.
+func runInChainData(ops *definitions.Operation, srv *definitions.Service, command string) error {
+	ops.Args = []string{"-c", command}
+
+	saved := config.GlobalConfig.Writer
+	buf := new(bytes.Buffer)
+	config.GlobalConfig.Writer = buf
+	_, err := perform.DockerRunData(ops, srv)
+	config.GlobalConfig.Writer = saved
+
+	logger.Debugf("Output of =>\t\t\t%s:%s\n", command, buf.String())
+	return err
+}
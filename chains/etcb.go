@@ -0,0 +1,88 @@
+package chains
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Names under which a chain CHAIN_ID is registered in the etcb name
+// registry (see [eris chains register]).
+const (
+	etcbGenesis = "%s/genesis"
+	etcbSeeds   = "%s/seeds"
+	etcbImage   = "%s/image"
+)
+
+// etcbEntry is what [eris chains install] needs to know about a
+// registered chain.
+type etcbEntry struct {
+	Genesis string
+	Seeds   string
+	Image   string
+}
+
+// etcbNameEntry is a name registry entry of the etcb chain.
+type etcbNameEntry struct {
+	Name    string `json:"name"`
+	Owner   string `json:"owner"`
+	Data    string `json:"data"`
+	Expires int    `json:"expires"`
+}
+
+// getEtcbEntry looks up the chain chainID in the etcb chain at host.
+// The genesis is mandatory; the seeds and the image are not (a chain
+// registered without an image runs the default chain image).
+func getEtcbEntry(host, chainID string) (*etcbEntry, error) {
+	entry := &etcbEntry{}
+
+	var err error
+	if entry.Genesis, err = getEtcbName(host, fmt.Sprintf(etcbGenesis, chainID)); err != nil {
+		return nil, fmt.Errorf("The marmots could not find the genesis of %s in etcb (%s): %v", chainID, host, err)
+	}
+	if entry.Seeds, err = getEtcbName(host, fmt.Sprintf(etcbSeeds, chainID)); err != nil {
+		logger.Infof("No seeds registered for =>\t%s (%v)\n", chainID, err)
+	}
+	if entry.Image, err = getEtcbName(host, fmt.Sprintf(etcbImage, chainID)); err != nil {
+		logger.Debugf("No image registered for =>\t%s (%v)\n", chainID, err)
+	}
+	return entry, nil
+}
+
+// getEtcbName returns the data of the name registry entry name, read
+// with the get_name call of the etcb node's RPC interface.
+func getEtcbName(host, name string) (string, error) {
+	result, err := rpcGet(host, "get_name", url.Values{"name": {`"` + name + `"`}})
+	if err != nil {
+		return "", err
+	}
+
+	entry, err := decodeNameEntry(result)
+	if err != nil {
+		return "", err
+	}
+	if entry.Data == "" {
+		return "", fmt.Errorf("%s is not registered", name)
+	}
+	return entry.Data, nil
+}
+
+// decodeNameEntry reads the result of a get_name call, with or without
+// the "entry" wrapper.
+func decodeNameEntry(result json.RawMessage) (*etcbNameEntry, error) {
+	var withEntry struct {
+		Entry *etcbNameEntry `json:"entry"`
+	}
+	if err := json.Unmarshal(result, &withEntry); err != nil {
+		return nil, fmt.Errorf("unexpected result %s: %v", result, err)
+	}
+	if withEntry.Entry != nil {
+		return withEntry.Entry, nil
+	}
+
+	entry := &etcbNameEntry{}
+	if err := json.Unmarshal(result, entry); err != nil {
+		return nil, fmt.Errorf("unexpected result %s: %v", result, err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,102 @@
+// Package fake tests the chains package against util.FakeBackend, so
+// that they run without a Docker daemon (unlike those of chains).
+package fake
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/chains"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/initialize"
+	"github.com/eris-ltd/eris-cli/loaders"
+	tests "github.com/eris-ltd/eris-cli/testutils"
+	"github.com/eris-ltd/eris-cli/util"
+
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	var err error
+	erisDir, err = tests.FakeInit("eris_chains_fake")
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dirs.ChainsPath, "default.toml"), []byte(initialize.DefChainService()), 0644); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func writeDefinition(t *testing.T, dir, name, definition string) {
+	if err := ioutil.WriteFile(filepath.Join(dir, name+".toml"), []byte(definition), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func TestStartKillRm(t *testing.T) {
+	_, restore := tests.UseFake()
+	defer restore()
+
+	writeDefinition(t, dirs.ServicesPath, "keys", `
+name = "keys"
+
+[service]
+image = "quay.io/eris/keys:latest"
+`)
+	writeDefinition(t, dirs.ChainsPath, "marmotchain", `
+name = "marmotchain"
+chain_id = "marmotchain"
+
+[service]
+image = "quay.io/eris/erisdb:latest"
+`)
+
+	do := def.NowDo()
+	do.Name = "marmotchain"
+	do.Operations.ContainerNumber = 1
+	if err := chains.StartChain(do); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	chain, err := loaders.LoadChainDefinition("marmotchain", false, 1)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !chains.IsChainRunning(chain) {
+		t.Fatalf("expected the chain to be running")
+	}
+	if !util.IsServiceContainer("keys", 1, false) {
+		t.Fatalf("expected the keys dependency to be running")
+	}
+
+	do = def.NowDo()
+	do.Name = "marmotchain"
+	do.Operations.ContainerNumber = 1
+	if err := chains.KillChain(do); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	if chains.IsChainRunning(chain) || !chains.IsChainExisting(chain) {
+		t.Fatalf("expected the chain to be stopped")
+	}
+
+	do = def.NowDo()
+	do.Name = "marmotchain"
+	do.Operations.ContainerNumber = 1
+	do.RmD = true
+	if err := chains.RmChain(do); err != nil {
+		t.Fatalf("rm: %v", err)
+	}
+	if chains.IsChainExisting(chain) {
+		t.Fatalf("expected the chain to be removed")
+	}
+	if _, exists := util.ParseContainers(util.DataContainersName("marmotchain", 1), true); exists {
+		t.Fatalf("expected the chain data container to be removed")
+	}
+}
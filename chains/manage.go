@@ -90,8 +90,23 @@ func ImportChain(do *definitions.Do) error {
 		return nil
 	}
 
-	if strings.Contains(s[0], "github") {
-		logger.Println("https://twitter.com/ryaneshea/status/595957712040628224")
+	if util.IsGitLocation(do.Path) {
+		// the chain's genesis.json and config.toml go to ~/.eris/chains/NAME
+		related := []string{"genesis.json", "config.toml", "server_conf.toml"}
+
+		var err error
+		if logger.Level > 0 {
+			_, err = util.GetFromGit(do.Path, do.Name, ChainsPath, related, logger.Writer)
+		} else {
+			_, err = util.GetFromGit(do.Path, do.Name, ChainsPath, related, bytes.NewBuffer([]byte{}))
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := loaders.LoadChainDefinition(do.Name, false, 1); err != nil {
+			return fmt.Errorf("Your chain definition file looks improperly formatted and will not marshal: %v", err)
+		}
+		do.Result = "success"
 		return nil
 	}
 
@@ -38,16 +38,82 @@ func NewChain(do *definitions.Do) error {
 		}
 	}
 
+	if do.N > 1 {
+		logger.Debugf("Starting Setup for Nodes =>\t%s:%d\n", do.Name, do.N)
+		return newChainCluster(do)
+	}
+
 	// for now we just let setupChain force do.ChainID = do.Name
 	// and we overwrite using jq in the container
 	logger.Debugf("Starting Setup for ChnID =>\t%s\n", do.Name)
 	return setupChain(do, loaders.ErisChainNew)
 }
 
+// InstallChain fetches the chain do.ChainID (do.Name if empty) from the
+// etcb registry at do.Gateway and boots a node of it. The chain's
+// genesis, seeds and image are read from etcb and the chain definition
+// file ~/.eris/chains/NAME.toml is written before the node is set up
+// like [eris chains new] does. The node gets a fresh key which is not
+// in the genesis, so it does not validate; it fast syncs from the seeds.
 func InstallChain(do *definitions.Do) error {
-	return setupChain(do, loaders.ErisChainInstall)
+	if do.Name == "" {
+		return fmt.Errorf("InstallChain requires a chainame")
+	}
+	if do.ChainID == "" {
+		do.ChainID = do.Name
+	}
+
+	fileName := filepath.Join(ChainsPath, do.Name) + ".toml"
+	if _, err := os.Stat(fileName); err == nil {
+		return fmt.Errorf("A chain definition for %s already exists. Start it with [eris chains start %s]", do.Name, do.Name)
+	}
+
+	logger.Infof("Fetching chain from etcb =>\t%s:%s\n", do.Gateway, do.ChainID)
+	entry, err := getEtcbEntry(do.Gateway, do.ChainID)
+	if err != nil {
+		return err
+	}
+
+	genesis, err := ioutil.TempFile(os.TempDir(), "genesis")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(genesis.Name())
+	if _, err := genesis.WriteString(entry.Genesis); err != nil {
+		genesis.Close()
+		return err
+	}
+	genesis.Close()
+	do.GenesisFile = genesis.Name()
+
+	if entry.Seeds != "" {
+		do.ConfigOpts = append(do.ConfigOpts, "seeds="+entry.Seeds)
+	}
+	do.ConfigOpts = append(do.ConfigOpts, "fast-sync=true")
+
+	chain := loaders.MockChainDefinition(do.Name, do.ChainID, false, do.Operations.ContainerNumber)
+	if entry.Image != "" {
+		chain.Service.Image = entry.Image
+	}
+	chain.Maintainer.Name, chain.Maintainer.Email, err = config.GitConfigUser()
+	if err != nil {
+		logger.Debugf("%v\n", err)
+	}
+	logger.Debugf("Writing chain definition =>\t%s:%s\n", fileName, chain.Service.Image)
+	if err := WriteChainDefinitionFile(chain, fileName); err != nil {
+		return fmt.Errorf("error writing chain definition to file: %v", err)
+	}
+
+	if err := setupChain(do, loaders.ErisChainNew); err != nil {
+		os.Remove(fileName)
+		return err
+	}
+	return nil
 }
 
+// KillChain stops the do.Name chain (and removes it with do.Rm). With
+// do.Cascade, the running services and chains which depend on the chain
+// are stopped first.
 func KillChain(do *definitions.Do) error {
 	chain, err := loaders.LoadChainDefinition(do.Name, false, do.Operations.ContainerNumber)
 	if err != nil {
@@ -58,6 +124,12 @@ func KillChain(do *definitions.Do) error {
 		do.Timeout = 0 //overrides 10 sec default
 	}
 
+	if do.Cascade {
+		if err := services.KillDependents(definitions.TypeChain, do.Name, do); err != nil {
+			return err
+		}
+	}
+
 	if IsChainRunning(chain) {
 		if err := perform.DockerStop(chain.Service, chain.Operations, do.Timeout); err != nil {
 			return err
@@ -115,6 +187,10 @@ func startChain(do *definitions.Do, exec bool) error {
 		return nil
 	}
 
+	if err := loaders.CheckDefinition("chains", do.Name); err != nil {
+		return err
+	}
+
 	// boot the dependencies (eg. keys)
 	if err := bootDependencies(chain, do); err != nil {
 		return err
@@ -159,6 +235,9 @@ func startChain(do *definitions.Do, exec bool) error {
 		err = perform.DockerExecService(chain.Service, chain.Operations)
 	} else {
 		err = perform.DockerRunService(chain.Service, chain.Operations)
+		if err == nil {
+			err = perform.DockerWaitHealthy(chain.Service, chain.Operations, chain.HealthCheck)
+		}
 	}
 	if err != nil {
 		do.Result = "error"
@@ -168,30 +247,22 @@ func startChain(do *definitions.Do, exec bool) error {
 	return nil
 }
 
-// boot chain dependencies
-// TODO: this currently only supports simple services (with no further dependencies)
+// boot chain dependencies: the services the chain depends on are started
+// along with their own dependencies
 func bootDependencies(chain *definitions.Chain, do *definitions.Do) error {
 	if chain.Dependencies != nil {
-		name := do.Name
 		logger.Infoln("Booting chain dependencies", chain.Dependencies.Services, chain.Dependencies.Chains)
+		var group []*definitions.ServiceDefinition
 		for _, srvName := range chain.Dependencies.Services {
-			do.Name = srvName
-			srv, err := loaders.LoadServiceDefinition(do.Name, false, do.Operations.ContainerNumber)
+			g, err := services.BuildServicesGroup(srvName, do.Operations.ContainerNumber, group...)
 			if err != nil {
 				return err
 			}
-
-			// Start corresponding service.
-			if !services.IsServiceRunning(srv.Service, srv.Operations) {
-				name := strings.ToUpper(do.Name)
-				logger.Infof("%s is not running. Starting now. Waiting for %s to become available \n", name, name)
-				if err = perform.DockerRunService(srv.Service, srv.Operations); err != nil {
-					return err
-				}
-			}
-
+			group = append(group, g...)
+		}
+		if err := services.StartGroup(group); err != nil {
+			return err
 		}
-		do.Name = name // undo side effects
 
 		for _, chainName := range chain.Dependencies.Chains {
 			chn, err := loaders.LoadChainDefinition(chainName, false, do.Operations.ContainerNumber)
@@ -378,8 +449,6 @@ func setupChain(do *definitions.Do, cmd string) (err error) {
 	logger.Debugf("Set links from setupChain =>\t%v\n", do.Links)
 	chain.Service.Links = append(chain.Service.Links, do.Links...)
 
-	// TODO: if do.N > 1 ...
-
 	chain.Operations.DataContainerName = util.DataContainersName(do.Name, do.Operations.ContainerNumber)
 
 	if err := bootDependencies(chain, do); err != nil {
@@ -389,7 +458,9 @@ func setupChain(do *definitions.Do, cmd string) (err error) {
 	logger.Debugf("Starting chain via Docker =>\t%s\n", chain.Service.Name)
 	logger.Debugf("\twith Image =>\t\t%s\n", chain.Service.Image)
 
-	err = perform.DockerRunService(chain.Service, chain.Operations)
+	if err = perform.DockerRunService(chain.Service, chain.Operations); err == nil {
+		err = perform.DockerWaitHealthy(chain.Service, chain.Operations, chain.HealthCheck)
+	}
 	// this err is caught in the defer above
 
 	return
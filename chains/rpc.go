@@ -0,0 +1,55 @@
+package chains
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// rpcGet calls method of the RPC interface of the tendermint node at
+// host with the URI (GET) form and returns its result. Tendermint wraps
+// results as [type, {...}]; the wrapper is removed.
+func rpcGet(host, method string, params url.Values) (json.RawMessage, error) {
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	query := fmt.Sprintf("%s/%s", strings.TrimSuffix(host, "/"), method)
+	if len(params) > 0 {
+		query += "?" + params.Encode()
+	}
+
+	logger.Debugf("Querying RPC =>\t\t%s\n", query)
+	resp, err := http.Get(query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  string          `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unexpected response (%s): %v", resp.Status, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s", response.Error)
+	}
+
+	var wrapped []json.RawMessage
+	if err := json.Unmarshal(response.Result, &wrapped); err == nil {
+		if len(wrapped) != 2 {
+			return nil, fmt.Errorf("unexpected result %s", response.Result)
+		}
+		return wrapped[1], nil
+	}
+	return response.Result, nil
+}
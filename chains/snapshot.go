@@ -0,0 +1,319 @@
+package chains
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/eris-ltd/eris-cli/data"
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/util"
+	"github.com/eris-ltd/eris-cli/version"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/docker/docker/pkg/archive"
+)
+
+// A chain snapshot is a gzipped tarball of the chain's directory in its
+// data container or volume (/home/eris/.eris/chains/NAME), kept in
+// snapshotDir next to the snapshotManifest file.
+const (
+	snapshotManifestFile = "snapshot.json"
+	snapshotDir          = "chain"
+)
+
+// snapshotManifest describes the chain a snapshot was taken of.
+type snapshotManifest struct {
+	Name        string `json:"name"`
+	ChainID     string `json:"chain_id"`
+	Image       string `json:"image"`
+	BlockHeight int    `json:"block_height,omitempty"`
+	Created     string `json:"created"`
+	ErisVersion string `json:"eris_version"`
+}
+
+// SnapshotChain archives the state of the do.Name chain to the file
+// do.Destination (NAME.tar.gz in the current directory by default). A
+// running chain is stopped while its data is exported, and started
+// again afterwards. The block height of the manifest is the
+// latest height reported by the RPC interface of a running chain; it
+// is left out for a chain which is not running.
+//
+//	do.Name                       - chain name
+//	do.Destination                - archive file
+//	do.Operations.ContainerNumber - chain container number
+//	do.Timeout                    - timeout to stop the chain
+func SnapshotChain(do *definitions.Do) error {
+	chain, err := loaders.LoadChainDefinition(do.Name, false, do.Operations.ContainerNumber)
+	if err != nil {
+		return err
+	}
+	if !util.IsData(do.Name, do.Operations.ContainerNumber) {
+		return fmt.Errorf("The marmots cannot find the data of the chain %s.", do.Name)
+	}
+
+	out := do.Destination
+	if out == "" {
+		out = do.Name + ".tar.gz"
+	}
+	if out, err = filepath.Abs(out); err != nil {
+		return err
+	}
+
+	height := 0
+	if IsChainRunning(chain) {
+		height = runningHeight(chain)
+
+		logger.Infof("Stopping chain for snapshot =>\t%s\n", do.Name)
+		if err := perform.DockerStop(chain.Service, chain.Operations, do.Timeout); err != nil {
+			return err
+		}
+		defer func() {
+			logger.Infof("Restarting chain =>\t\t%s\n", do.Name)
+			if err := StartChain(restartDo(do)); err != nil {
+				logger.Errorf("Error restarting the chain %s: %v\n", do.Name, err)
+			}
+		}()
+	}
+
+	tmp, err := ioutil.TempDir(os.TempDir(), "eris_snapshot")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	exportDo := definitions.NowDo()
+	exportDo.Name = do.Name
+	exportDo.Operations.ContainerNumber = do.Operations.ContainerNumber
+	exportDo.Source = path.Join(ErisContainerRoot, "chains", do.Name)
+	exportDo.Destination = tmp
+	if err := data.ExportData(exportDo); err != nil {
+		return err
+	}
+
+	// the export lands in tmp/NAME
+	if err := os.Rename(filepath.Join(tmp, do.Name), filepath.Join(tmp, snapshotDir)); err != nil {
+		return fmt.Errorf("The marmots could not find the chain directory in the chain data: %v", err)
+	}
+
+	manifest := &snapshotManifest{
+		Name:        do.Name,
+		ChainID:     chain.ChainID,
+		Image:       chain.Service.Image,
+		BlockHeight: height,
+		Created:     time.Now().UTC().Format(time.RFC3339),
+		ErisVersion: version.VERSION,
+	}
+	if err := writeSnapshotManifest(manifest, filepath.Join(tmp, snapshotManifestFile)); err != nil {
+		return err
+	}
+
+	logger.Infof("Writing snapshot =>\t\t%s\n", out)
+	if err := writeArchive(tmp, out); err != nil {
+		return err
+	}
+
+	if manifest.BlockHeight > 0 {
+		logger.Printf("Snapshot of %s (%s) at height %d =>\t%s\n", do.Name, manifest.ChainID, manifest.BlockHeight, out)
+	} else {
+		logger.Printf("Snapshot of %s (%s) =>\t%s\n", do.Name, manifest.ChainID, out)
+	}
+	do.Result = out
+	return nil
+}
+
+// RestoreChain replaces the chain directory in the data container (or
+// volume) of the do.Name chain with the one of the snapshot do.Path. The rest of
+// the data is left alone. The chain container is removed, and
+// started again if it was running. A chain definition file is written from the snapshot's
+// manifest if do.Name is not a known chain.
+//
+//	do.Name                       - chain name
+//	do.Path                       - archive file
+//	do.Operations.ContainerNumber - chain container number
+//	do.Timeout                    - timeout to stop the chain
+func RestoreChain(do *definitions.Do) error {
+	tmp, err := ioutil.TempDir(os.TempDir(), "eris_restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	logger.Infof("Reading snapshot =>\t\t%s\n", do.Path)
+	if err := readArchive(do.Path, tmp); err != nil {
+		return err
+	}
+	manifest, err := readSnapshotManifest(filepath.Join(tmp, snapshotManifestFile))
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Snapshot manifest =>\t\t%v\n", manifest)
+
+	// the chain files are imported to chains/NAME of the chain data
+	src := filepath.Join(tmp, "data")
+	if err := os.MkdirAll(filepath.Join(src, "chains"), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(tmp, snapshotDir), filepath.Join(src, "chains", do.Name)); err != nil {
+		return fmt.Errorf("The snapshot %s has no chain directory: %v", do.Path, err)
+	}
+
+	if util.GetFileByNameAndType("chains", do.Name) == "" {
+		chain := loaders.MockChainDefinition(do.Name, manifest.ChainID, false, do.Operations.ContainerNumber)
+		if manifest.Image != "" {
+			chain.Service.Image = manifest.Image
+		}
+		logger.Infof("Writing chain definition =>\t%s:%s\n", do.Name, manifest.ChainID)
+		if err := WriteChainDefinitionFile(chain, filepath.Join(ChainsPath, do.Name+".toml")); err != nil {
+			return err
+		}
+	}
+
+	chain, err := loaders.LoadChainDefinition(do.Name, false, do.Operations.ContainerNumber)
+	if err != nil {
+		return err
+	}
+
+	running := IsChainRunning(chain)
+	if IsChainExisting(chain) {
+		if running {
+			logger.Infof("Stopping chain for restore =>\t%s\n", do.Name)
+			if err := perform.DockerStop(chain.Service, chain.Operations, do.Timeout); err != nil {
+				return err
+			}
+		}
+		if err := perform.DockerRemove(chain.Service, chain.Operations, false, false); err != nil {
+			return err
+		}
+	}
+
+	if util.IsData(do.Name, do.Operations.ContainerNumber) {
+		logger.Infof("Clearing chain directory =>\t%s\n", do.Name)
+		if err := clearChainDir(chain); err != nil {
+			return fmt.Errorf("The marmots could not clear the chain directory of %s: %v", do.Name, err)
+		}
+	}
+
+	importDo := definitions.NowDo()
+	importDo.Name = do.Name
+	importDo.Operations.ContainerNumber = do.Operations.ContainerNumber
+	importDo.Source = src
+	importDo.Destination = ErisContainerRoot
+	if err := data.ImportData(importDo); err != nil {
+		return err
+	}
+
+	if running {
+		logger.Infof("Restarting chain =>\t\t%s\n", do.Name)
+		if err := StartChain(restartDo(do)); err != nil {
+			return err
+		}
+	}
+
+	if manifest.BlockHeight > 0 {
+		logger.Printf("Restored %s (%s) at height %d\n", do.Name, manifest.ChainID, manifest.BlockHeight)
+	} else {
+		logger.Printf("Restored %s (%s)\n", do.Name, manifest.ChainID)
+	}
+	do.Result = "success"
+	return nil
+}
+
+func restartDo(do *definitions.Do) *definitions.Do {
+	d := definitions.NowDo()
+	d.Name = do.Name
+	d.Operations.ContainerNumber = do.Operations.ContainerNumber
+	return d
+}
+
+// clearChainDir removes the chain directory (chains/NAME) from the data
+// container or volume of chain.
+func clearChainDir(chain *definitions.Chain) error {
+	srv := loaders.MockChainDefinition(chain.Name, chain.ChainID, false, chain.Operations.ContainerNumber).Service
+	srv.Image = chain.Service.Image
+	srv.User = "root"
+	srv.EntryPoint = "sh"
+
+	ops := loaders.LoadDataDefinition(chain.Name, chain.Operations.ContainerNumber)
+	return runInChainData(ops, srv, "rm -rf "+path.Join(ErisContainerRoot, "chains", chain.Name))
+}
+
+// runningHeight returns the latest block height of the running chain,
+// as reported by its RPC interface, or 0 if it cannot be had.
+func runningHeight(chain *definitions.Chain) int {
+	addr, err := util.ContainerPortAddress(chain.Operations.SrvContainerID, chainRPCPort)
+	if err != nil {
+		logger.Debugf("Cannot find the chain RPC address: %v\n", err)
+		return 0
+	}
+	result, err := rpcGet(addr, "status", nil)
+	if err != nil {
+		logger.Debugf("Cannot get the chain status: %v\n", err)
+		return 0
+	}
+	var status struct {
+		LatestBlockHeight int `json:"latest_block_height"`
+	}
+	if err := json.Unmarshal(result, &status); err != nil {
+		logger.Debugf("Unexpected chain status %s: %v\n", result, err)
+		return 0
+	}
+	return status.LatestBlockHeight
+}
+
+func writeSnapshotManifest(manifest *snapshotManifest, file string) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(b, '\n'), 0644)
+}
+
+func readSnapshotManifest(file string) (*snapshotManifest, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("The archive is not a chain snapshot (no %s)", snapshotManifestFile)
+	}
+	manifest := &snapshotManifest{}
+	if err := json.Unmarshal(b, manifest); err != nil {
+		return nil, fmt.Errorf("Bad snapshot manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+// writeArchive writes the gzipped tarball of the contents of dir to file.
+func writeArchive(dir, file string) error {
+	reader, err := util.Tar(dir, archive.Gzip)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, reader)
+	return err
+}
+
+// readArchive unpacks the (gzipped) tarball file into dir.
+func readArchive(file, dir string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return util.Untar(f, "", dir)
+}
@@ -0,0 +1,230 @@
+package chains
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/olekukonko/tablewriter"
+)
+
+// The RPC port of the chain container.
+const chainRPCPort = "46657"
+
+// A chain which has not made a block for staleBlockAge is reported as
+// stalled.
+const staleBlockAge = time.Minute
+
+// ChainStatus is the consensus state of a running chain, as reported by
+// the RPC interface of its node.
+type ChainStatus struct {
+	Name       string             `json:"name"`
+	ChainID    string             `json:"chain_id"`
+	RPC        string             `json:"rpc"`
+	Height     int                `json:"latest_block_height"`
+	Hash       string             `json:"latest_block_hash"`
+	Time       time.Time          `json:"latest_block_time"`
+	Peers      int                `json:"peers"`
+	Validators []*StatusValidator `json:"validators"`
+	Sync       string             `json:"sync"`
+}
+
+// StatusValidator is a member of the validator set of the chain.
+type StatusValidator struct {
+	Address     string `json:"address"`
+	VotingPower int64  `json:"voting_power"`
+}
+
+// StatusChain displays the latest block, the number of peers, the
+// validator set and the sync state of the running do.Name chain as a
+// table (or JSON with do.JSON). The node is queried on the host port
+// its RPC port (46657) is published to. do.Result is set to the JSON.
+//
+//	do.Name                       - chain name
+//	do.Operations.ContainerNumber - chain container number
+//	do.JSON                       - display JSON instead of a table
+func StatusChain(do *definitions.Do) error {
+	chain, err := loaders.LoadChainDefinition(do.Name, false, do.Operations.ContainerNumber)
+	if err != nil {
+		return err
+	}
+	if !IsChainRunning(chain) {
+		return fmt.Errorf("The chain %s is not running. Start it with [eris chains start %s].", do.Name, do.Name)
+	}
+
+	addr, err := util.ContainerPortAddress(chain.Operations.SrvContainerID, chainRPCPort)
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Chain RPC address =>\t\t%s\n", addr)
+
+	status, err := getChainStatus(addr)
+	if err != nil {
+		return fmt.Errorf("The marmots could not get the status of %s from %s: %v", do.Name, addr, err)
+	}
+	status.Name = do.Name
+
+	out, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if do.JSON {
+		logger.Printf("%s\n", out)
+	} else {
+		logger.Printf("%s", statusTable(status))
+	}
+	do.Result = string(out)
+	return nil
+}
+
+// getChainStatus queries the status, net_info and validators calls of
+// the RPC interface of the node at host.
+func getChainStatus(host string) (*ChainStatus, error) {
+	status := &ChainStatus{RPC: host}
+
+	result, err := rpcGet(host, "status", nil)
+	if err != nil {
+		return nil, err
+	}
+	var st struct {
+		NodeInfo struct {
+			Network string `json:"network"`
+		} `json:"node_info"`
+		LatestBlockHash   string          `json:"latest_block_hash"`
+		LatestBlockHeight int             `json:"latest_block_height"`
+		LatestBlockTime   json.RawMessage `json:"latest_block_time"`
+		CatchingUp        *bool           `json:"catching_up"`
+	}
+	if err := json.Unmarshal(result, &st); err != nil {
+		return nil, fmt.Errorf("unexpected status %s: %v", result, err)
+	}
+	status.ChainID = st.NodeInfo.Network
+	status.Height = st.LatestBlockHeight
+	status.Hash = strings.ToUpper(st.LatestBlockHash)
+	if status.Time, err = parseBlockTime(st.LatestBlockTime); err != nil {
+		return nil, err
+	}
+
+	result, err = rpcGet(host, "net_info", nil)
+	if err != nil {
+		return nil, err
+	}
+	var netInfo struct {
+		Peers []json.RawMessage `json:"peers"`
+	}
+	if err := json.Unmarshal(result, &netInfo); err != nil {
+		return nil, fmt.Errorf("unexpected net_info %s: %v", result, err)
+	}
+	status.Peers = len(netInfo.Peers)
+
+	result, err = rpcGet(host, "validators", nil)
+	if err != nil {
+		return nil, err
+	}
+	var vals struct {
+		Validators       []*StatusValidator `json:"validators"`
+		BondedValidators []*StatusValidator `json:"bonded_validators"`
+	}
+	if err := json.Unmarshal(result, &vals); err != nil {
+		return nil, fmt.Errorf("unexpected validators %s: %v", result, err)
+	}
+	status.Validators = append(vals.Validators, vals.BondedValidators...)
+	if status.Validators == nil {
+		status.Validators = []*StatusValidator{}
+	}
+	for _, v := range status.Validators {
+		v.Address = strings.ToUpper(v.Address)
+	}
+
+	status.Sync = syncState(status, st.CatchingUp, time.Now())
+	return status, nil
+}
+
+// parseBlockTime reads latest_block_time, which is in nanoseconds since
+// the epoch, or an RFC 3339 string on newer nodes.
+func parseBlockTime(raw json.RawMessage) (time.Time, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return time.Time{}, nil
+	}
+
+	var nanos int64
+	if err := json.Unmarshal(raw, &nanos); err == nil {
+		if nanos == 0 {
+			return time.Time{}, nil
+		}
+		return time.Unix(0, nanos).UTC(), nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return time.Time{}, fmt.Errorf("unexpected latest_block_time %s", raw)
+	}
+	if nanos, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(0, nanos).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected latest_block_time %s", raw)
+	}
+	return t.UTC(), nil
+}
+
+// syncState is "catching up" if the node says so, "starting" before the
+// first block, "stalled" if the latest block is older than
+// staleBlockAge, and "synced" otherwise.
+func syncState(status *ChainStatus, catchingUp *bool, now time.Time) string {
+	switch {
+	case catchingUp != nil && *catchingUp:
+		return "catching up"
+	case status.Height == 0:
+		return "starting"
+	case !status.Time.IsZero() && now.Sub(status.Time) > staleBlockAge:
+		return "stalled"
+	default:
+		return "synced"
+	}
+}
+
+func statusTable(status *ChainStatus) string {
+	blockTime := ""
+	if !status.Time.IsZero() {
+		blockTime = status.Time.Format(time.RFC3339)
+	}
+
+	buf := new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetHeader([]string{"CHAIN NAME", "CHAIN ID", "HEIGHT", "BLOCK HASH", "BLOCK TIME", "PEERS", "VALIDATORS", "SYNC"})
+	table.Append([]string{status.Name, status.ChainID, strconv.Itoa(status.Height), status.Hash, blockTime,
+		strconv.Itoa(status.Peers), strconv.Itoa(len(status.Validators)), status.Sync})
+
+	table.SetBorder(false)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator("-")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	if len(status.Validators) > 0 {
+		buf.WriteString("\n")
+		vals := tablewriter.NewWriter(buf)
+		vals.SetHeader([]string{"VALIDATOR", "VOTING POWER"})
+		for _, v := range status.Validators {
+			vals.Append([]string{v.Address, strconv.FormatInt(v.VotingPower, 10)})
+		}
+		vals.SetBorder(false)
+		vals.SetCenterSeparator(" ")
+		vals.SetColumnSeparator(" ")
+		vals.SetRowSeparator("-")
+		vals.SetAlignment(tablewriter.ALIGN_LEFT)
+		vals.Render()
+	}
+
+	return buf.String()
+}
@@ -2,12 +2,13 @@ package chains
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/eris-ltd/eris-cli/config"
 	def "github.com/eris-ltd/eris-cli/definitions"
-	srv "github.com/eris-ltd/eris-cli/services"
+	"github.com/eris-ltd/eris-cli/genesis"
 
 	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
 
@@ -56,42 +57,44 @@ func WriteChainDefinitionFile(chainDef *def.Chain, fileName string) error {
 		enc.Encode(chainDef.Service)
 		writer.Write([]byte("\n[maintainer]\n"))
 		enc.Encode(chainDef.Maintainer)
+		if !chainDef.HealthCheck.IsEmpty() {
+			writer.Write([]byte("\n[healthcheck]\n"))
+			enc.Encode(chainDef.HealthCheck)
+		}
 	}
 	return nil
 }
 
+// MakeGenesisFile writes the genesis.json of the chain_id do.Chain.Name
+// to config.GlobalConfig.Writer. It has a validator and an account with
+// every permission for each of the comma separated public keys in
+// do.Pubkey, or it is made from the genesis.csv (or validators.csv and
+// accounts.csv, comma separated) files in do.CSV.
 func MakeGenesisFile(do *def.Do) error {
+	var genDoc *genesis.GenesisDoc
+	var err error
 
-	//otherwise it'll start its own keys server that won't have the key needed...
-	do.Name = "keys"
-	IfExit(srv.EnsureRunning(do))
-
-	doThr := def.NowDo()
-	doThr.Chain.ChainType = "throwaway" //for teardown
-	doThr.Name = "default"
-	doThr.Operations.ContainerNumber = 1
-	doThr.Operations.PublishAllPorts = true
-
-	logger.Infof("Starting chain from MakeGenesisFile =>\t%s\n", doThr.Name)
-	if er := StartChain(doThr); er != nil {
-		return fmt.Errorf("error starting chain %v\n", er)
+	if do.CSV != "" {
+		csvFiles := strings.Split(do.CSV, ",")
+		var accountsCSV string
+		if len(csvFiles) > 1 {
+			accountsCSV = csvFiles[1]
+		}
+		logger.Debugf("Making genesis from csv =>	%s:%v\n", do.Chain.Name, csvFiles)
+		genDoc, err = genesis.GenesisFromCSV(do.Chain.Name, csvFiles[0], accountsCSV)
+	} else {
+		logger.Debugf("Making genesis from keys =>	%s:%s\n", do.Chain.Name, do.Pubkey)
+		genDoc, err = genesis.GenesisFromPubKeys(do.Chain.Name, strings.Split(do.Pubkey, ",")...)
 	}
-
-	doThr.Operations.Args = []string{"mintgen", "known", do.Chain.Name, fmt.Sprintf("--pub=%s", do.Pubkey)}
-	doThr.Chain.Name = "default" //for teardown
-
-	// pipe this output to /chains/chainName/genesis.json
-	err := ExecChain(doThr)
 	if err != nil {
-		logger.Printf("exec chain err: %v\nCleaning up...\n", err)
-		doThr.Rm = true
-		doThr.RmD = true
-		if err := CleanUp(doThr); err != nil {
-			return err
-		}
+		return err
 	}
 
-	doThr.Rm = true
-	doThr.RmD = true
-	return CleanUp(doThr) // doesn't clean up keys but that's ~ ok b/c it's about to be used...
+	out, err := genDoc.JSON()
+	if err != nil {
+		return err
+	}
+	config.GlobalConfig.Writer.Write(out)
+	do.Result = "success"
+	return nil
 }
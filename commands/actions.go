@@ -55,12 +55,27 @@ func buildActionsCommand() {
 // Actions Sub-sub-Commands
 var actionsImport = &cobra.Command{
 	Use:   "import NAME LOCATION",
-	Short: "Import an action definition file from Github or IPFS.",
+	Short: "Import an action definition file from IPFS or git.",
 	Long: `Import an action definition for your platform.
 
-By default, Eris will import from ipfs.`,
-	Example: "$ eris actions import \"do not use\" QmNUhPtuD9VtntybNqLgTTevUmgqs13eMvo2fkCwLLx5MX",
-	Run:     ImportAction,
+LOCATION is one of
+
+	ipfs:HASH
+	git:URL[#REF[:PATH]]
+	git://HOST/REPO[#REF[:PATH]]
+	github:ORG/REPO[#REF[:PATH]]
+
+For git locations the repository is cloned with the local git binary
+(any URL git understands works, including file:// remotes). REF is
+a branch, tag, or commit, and PATH is the definition file or the
+directory holding it (NAME.toml, .json, or .yaml, directly or in an
+actions subdirectory).
+
+A github: PATH naming a definition file is downloaded from GitHub
+without cloning the repository.`,
+	Example: `$ eris actions import "do not use" ipfs:QmNUhPtuD9VtntybNqLgTTevUmgqs13eMvo2fkCwLLx5MX
+$ eris actions import "do not use" github:eris-ltd/eris-actions#master:actions`,
+	Run: ImportAction,
 }
 
 // flags to add: template
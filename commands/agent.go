@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/eris-ltd/eris-cli/agent"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
+)
+
+var (
+	agentHost    string
+	agentToken   string
+	agentTLSCert string
+	agentTLSKey  string
+)
+
+var Agent = &cobra.Command{
+	Use:   "agent",
+	Short: "Run an eris agent serving eris operations over HTTP.",
+	Long: `Run a long-lived HTTP server exposing the services, chains,
+data, keys and files operations as JSON endpoints.
+
+Operation endpoints (e.g. POST /services/start) take the same
+parameters the command line does, encoded as JSON, and return
+the command's result and output. Logs (POST /services/logs,
+/chains/logs) and exec (POST /services/exec) stream their output.
+
+Other eris command lines can drive the agent's host with
+--machine=http://HOST:PORT.
+
+The agent serves this machine only by default. To serve other
+hosts it needs a token, which clients give in the ERIS_AGENT_TOKEN
+environment variable, and a TLS certificate and key. Services run
+through the agent may not be privileged or bind host directories
+outside of the eris root.`,
+	Example: `$ eris agent
+$ eris agent --host 0.0.0.0:46651 --token $ERIS_AGENT_TOKEN --tls-cert agent.crt --tls-key agent.key`,
+	Run: StartAgent,
+}
+
+func buildAgentCommand() {
+	addAgentFlags()
+}
+
+func addAgentFlags() {
+	Agent.Flags().StringVarP(&agentHost, "host", "", agent.DefaultHost, "address for the agent to listen on")
+	Agent.Flags().StringVarP(&agentToken, "token", "", os.Getenv("ERIS_AGENT_TOKEN"), "token clients must send to the agent (required with a non-local --host)")
+	Agent.Flags().StringVarP(&agentTLSCert, "tls-cert", "", "", "TLS certificate file to serve the agent with (required with a non-local --host)")
+	Agent.Flags().StringVarP(&agentTLSKey, "tls-key", "", "", "TLS key file of the certificate")
+}
+
+func StartAgent(cmd *cobra.Command, args []string) {
+	IfExit(agent.Serve(agentHost, agentToken, agentTLSCert, agentTLSKey))
+}
@@ -51,10 +51,13 @@ func buildChainsCommand() {
 	Chains.AddCommand(chainsStart)
 	Chains.AddCommand(chainsLogs)
 	Chains.AddCommand(chainsInspect)
+	Chains.AddCommand(chainsStatus)
 	Chains.AddCommand(chainsStop)
 	Chains.AddCommand(chainsExec)
 	Chains.AddCommand(chainsCat)
 	Chains.AddCommand(chainsExport)
+	Chains.AddCommand(chainsSnapshot)
+	Chains.AddCommand(chainsRestore)
 	Chains.AddCommand(chainsRename)
 	Chains.AddCommand(chainsUpdate)
 	Chains.AddCommand(chainsRemove)
@@ -88,9 +91,18 @@ unless the --options flag is passed.
 Will use a default eris:db server config from ~/.eris/chains/default/server_conf.toml
 unless the --serverconf flag is passed.
 
+With the --nodes flag, a genesis.json with that many validators is made and
+a node is started for each validator. The nodes are numbered 1..N (address
+them with the --num flag of the other chains commands), each has its own
+data container, and each uses all the others as its p2p seeds. The --N flag
+is a deprecated alias of --nodes.
+
 For more complex blockchain creation, you will want to "hand craft" a genesis.json
 see our tutorial for chain creation here:
 https://docs.erisindustries.com/tutorials/chainmaking/`,
+	Example: `$ eris chains new simplechain
+$ eris chains new testnet --nodes 4
+$ eris chains stop testnet --num 3`,
 	Run: NewChain,
 }
 
@@ -121,7 +133,13 @@ var chainsInstall = &cobra.Command{
 
 Install an existing erisdb based blockchain for use locally.
 
-(Currently a work in progress.)`,
+The genesis.json, the p2p seeds and the docker image of the chain
+(as registered with [eris chains register]) are fetched from the
+etcb chain, a chain definition file is written to
+~/.eris/chains/NAME.toml, and a node of the chain is started. The
+node does not validate; it syncs the chain from the seeds.`,
+	Example: `$ eris chains install mychain
+$ eris chains install mine --id mychain --etcb-host localhost:46657`,
 	Run: InstallChain,
 }
 
@@ -145,14 +163,31 @@ Services are handled using the [eris services] command.`,
 
 var chainsImport = &cobra.Command{
 	Use:   "import NAME LOCATION",
-	Short: "Import a chain definition file from Github or IPFS.",
+	Short: "Import a chain definition file from IPFS or git.",
 	Long: `Import a chain definition for your platform.
 
-By default, Eris will import from IPFS.
+LOCATION is one of
+
+	ipfs:HASH
+	git:URL[#REF[:PATH]]
+	git://HOST/REPO[#REF[:PATH]]
+	github:ORG/REPO[#REF[:PATH]]
+
+For git locations the repository is cloned with the local git binary
+(any URL git understands works, including file:// remotes). REF is
+a branch, tag, or commit, and PATH is the definition file or the
+directory holding it (NAME.toml, .json, or .yaml, directly or in a
+chains subdirectory). The genesis.json, config.toml, and
+server_conf.toml found next to the definition file (or in a NAME
+directory next to it) are imported into ~/.eris/chains/NAME.
+A github: PATH naming a definition file is downloaded from GitHub
+without cloning the repository.
 
 To list known chains use: [eris chains ls --known].`,
-	Example: "$ eris chains import 2gather QmNUhPtuD9VtntybNqLgTTevUmgqs13eMvo2fkCwLLx5MX",
-	Run:     ImportChain,
+	Example: `$ eris chains import 2gather ipfs:QmNUhPtuD9VtntybNqLgTTevUmgqs13eMvo2fkCwLLx5MX
+$ eris chains import 2gather github:eris-ltd/eris-chains#v0.1:chains
+$ eris chains import 2gather git:file:///srv/definitions.git#master:chains/2gather.toml`,
+	Run: ImportChain,
 }
 
 var chainsCheckout = &cobra.Command{
@@ -252,8 +287,11 @@ with volumes-from the data container`,
 var chainsStop = &cobra.Command{
 	Use:   "stop NAME",
 	Short: "Stop a running blockchain.",
-	Long:  `Stop a running blockchain.`,
-	Run:   KillChain,
+	Long: `Stop a running blockchain.
+
+With --cascade, the running services and chains which depend on the
+chain are stopped before it.`,
+	Run: KillChain,
 }
 
 var chainsInspect = &cobra.Command{
@@ -270,6 +308,24 @@ $ eris chains inspect 2gather host_config.binds -- will display only that value`
 	Run: InspectChain,
 }
 
+var chainsStatus = &cobra.Command{
+	Use:   "status NAME",
+	Short: "Consensus status of a running blockchain.",
+	Long: `Consensus status of a running blockchain.
+
+The chain's node is queried on the host port its RPC port (46657)
+is published to. The latest block height, hash and time, the number
+of peers, the validator set and the sync state of the node are
+displayed as a table, or as JSON with the --json flag.
+
+The sync state is "catching up" if the node says so, "starting"
+before the first block, "stalled" if the latest block is more than
+a minute old, and "synced" otherwise.`,
+	Example: `$ eris chains status simplechain
+$ eris chains status simplechain --json`,
+	Run: StatusChain,
+}
+
 var chainsExport = &cobra.Command{
 	Use:   "export NAME",
 	Short: "Export a chain definition file to IPFS.",
@@ -279,6 +335,36 @@ Command will return a machine readable version of the IPFS hash.`,
 	Run: ExportChain,
 }
 
+var chainsSnapshot = &cobra.Command{
+	Use:   "snapshot NAME",
+	Short: "Archive the state of a blockchain.",
+	Long: `Archive the state of a blockchain.
+
+The chain's directory in its data container is written to a gzipped
+tarball (NAME.tar.gz by default) along with a snapshot.json manifest
+holding the chain_id and the image of the chain and, if the chain is
+running, its block height.
+A running chain is stopped during the snapshot and started again
+afterwards.
+
+Snapshots are restored with [eris chains restore].`,
+	Example: `$ eris chains snapshot simplechain --out simplechain-before.tar.gz`,
+	Run:     SnapshotChain,
+}
+
+var chainsRestore = &cobra.Command{
+	Use:   "restore NAME FILE",
+	Short: "Restore a blockchain from a snapshot.",
+	Long: `Restore a blockchain from a snapshot.
+
+The data container of the chain is recreated from the snapshot made
+with [eris chains snapshot]. A running chain is stopped, its container
+is removed, and it is started again once the data is restored. If NAME
+is not a known chain, its definition file is made from the snapshot.`,
+	Example: `$ eris chains restore simplechain simplechain-before.tar.gz`,
+	Run:     RestoreChain,
+}
+
 var chainsRename = &cobra.Command{
 	Use:   "rename OLD_NAME NEW_NAME",
 	Short: "Rename a blockchain.",
@@ -345,21 +431,33 @@ Command will cat local chains definition file.`,
 }
 
 var chainsMakeGenesis = &cobra.Command{
-	Use:   "make-genesis NAME KEY",
+	Use:   "make-genesis NAME [KEY,KEY,...]",
 	Short: "Generates a genesis file.",
-	Long: `Generates a genesis file with chainNAME and a single pubkey.
+	Long: `Generates a genesis file for the chain_id NAME and prints it.
+
+Each of the comma separated public keys gets a validator and an account
+with every permission. This is what [mintgen known NAME KEY] makes, but
+the genesis is made by eris itself, without Docker.
+
+With --csv the genesis is made from a genesis.csv file, whose entries
+are both validators and accounts, or from validators.csv,accounts.csv
+files. The entries are
 
-Command is equivalent to: [eris chains exec someChain "mintgen known NAME KEY"]
+	pubkey,amount,name,perms,setbit
 
-but does not require a pre-existing chain to execute.
+of which only the public key is needed.
 
 see https://github.com/eris-ltd/mint-client for more info`,
+	Example: `$ eris chains make-genesis mychain CB3688B7561D488A2A4834E1AEE9398BEF94844D8BDBBCA980C11E3654A45906 > genesis.json
+$ eris chains make-genesis mychain --csv validators.csv,accounts.csv`,
 	Run: MakeGenesisFile,
 }
 
 //----------------------------------------------------------------------
 
 func addChainsFlags() {
+	buildFlag(chainsMakeGenesis, do, "csv", "chain")
+
 	buildFlag(chainsNew, do, "config", "chain")
 	buildFlag(chainsNew, do, "csv", "chain")
 	buildFlag(chainsNew, do, "serverconf", "chain")
@@ -371,7 +469,9 @@ func addChainsFlags() {
 	chainsNew.PersistentFlags().StringVarP(&do.GenesisFile, "genesis", "g", "", "genesis.json file")
 	chainsNew.PersistentFlags().StringSliceVarP(&do.ConfigOpts, "options", "", nil, "space separated <key>=<value> pairs to set in config.toml")
 	chainsNew.PersistentFlags().StringVarP(&do.Priv, "priv", "", "", "pass in a priv_validator.json file (dev-only!)")
-	chainsNew.PersistentFlags().UintVarP(&do.N, "N", "", 1, "make a new genesis.json with this many validators and create data containers for each")
+	chainsNew.PersistentFlags().UintVarP(&do.N, "nodes", "", 1, "make a new genesis.json with this many validators and start a node (with its own data container) for each")
+	chainsNew.PersistentFlags().UintVarP(&do.N, "N", "", 1, "make a new genesis.json with this many validators and start a node (with its own data container) for each")
+	chainsNew.PersistentFlags().MarkDeprecated("N", "please use --nodes")
 	chainsNew.PersistentFlags().BoolVarP(&do.Force, "force", "f", false, "overwrite data in  ~/.eris/data/chainName")
 
 	buildFlag(chainsRegister, do, "links", "chain")
@@ -403,6 +503,12 @@ func addChainsFlags() {
 	buildFlag(chainsExec, do, "links", "chain")
 	chainsExec.Flags().StringVarP(&do.Image, "image", "", "", "Docker image")
 
+	chainsStatus.Flags().BoolVarP(&do.JSON, "json", "", false, "display the status as JSON")
+
+	chainsSnapshot.Flags().StringVarP(&do.Destination, "out", "", "", "archive file (default NAME.tar.gz)")
+	buildFlag(chainsSnapshot, do, "timeout", "chain")
+	buildFlag(chainsRestore, do, "timeout", "chain")
+
 	buildFlag(chainsRemove, do, "file", "chain")
 	buildFlag(chainsRemove, do, "data", "chain")
 	buildFlag(chainsRemove, do, "rm-volumes", "chain")
@@ -417,6 +523,7 @@ func addChainsFlags() {
 	buildFlag(chainsStop, do, "force", "chain")
 	buildFlag(chainsStop, do, "timeout", "chain")
 	buildFlag(chainsStop, do, "volumes", "chain")
+	buildFlag(chainsStop, do, "cascade", "chain")
 
 	buildFlag(chainsListAll, do, "known", "chain")
 	buildFlag(chainsListAll, do, "existing", "chain")
@@ -567,6 +674,25 @@ func ExportChain(cmd *cobra.Command, args []string) {
 	IfExit(chns.ExportChain(do))
 }
 
+func StatusChain(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Name = args[0]
+	IfExit(chns.StatusChain(do))
+}
+
+func SnapshotChain(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Name = args[0]
+	IfExit(chns.SnapshotChain(do))
+}
+
+func RestoreChain(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(2, "eq", cmd, args))
+	do.Name = args[0]
+	do.Path = args[1]
+	IfExit(chns.RestoreChain(do))
+}
+
 func ListAllChains(cmd *cobra.Command, args []string) {
 	//if no flags are set, list all the things
 	//otherwise, allow only a single flag
@@ -627,9 +753,13 @@ func CatChain(cmd *cobra.Command, args []string) {
 }
 
 func MakeGenesisFile(cmd *cobra.Command, args []string) {
-	IfExit(ArgCheck(2, "ge", cmd, args))       //eq doesn't fly...
+	if do.CSV == "" {
+		IfExit(ArgCheck(2, "ge", cmd, args)) //eq doesn't fly...
+		do.Pubkey = strings.TrimSpace(args[1])
+	} else {
+		IfExit(ArgCheck(1, "ge", cmd, args))
+	}
 	do.Chain.Name = strings.TrimSpace(args[0]) //trim for bash
-	do.Pubkey = strings.TrimSpace(args[1])
 	IfExit(chns.MakeGenesisFile(do))
 
 }
@@ -11,7 +11,8 @@ var Clean = &cobra.Command{
 	Use:   "clean",
 	Short: "Clean up your eris working environment.",
 	Long: `Stops and force removes all eris containers
-	(chains, services, datas, etc) by default. Useful
+	(chains, services, datas, etc) by default, as well
+	as the eris networks left without containers. Useful
 	for development.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		CleanItUp(cmd, args)
@@ -34,7 +34,12 @@ and sticks it back into ~/.eris/data/NAME on the host.
 
 At Eris, we use this functionality to formulate little JSONs
 and configs on the host and then "stick them back into the
-containers"`,
+containers"
+
+The data may be kept in a data container or, for definitions
+with data_container = "volume", in a named volume. The data
+commands work the same with both; [eris data migrate] moves
+the data of a data container into a named volume.`,
 	Run: func(cmd *cobra.Command, args []string) { cmd.Help() },
 }
 
@@ -47,6 +52,7 @@ func buildDataCommand() {
 	Data.AddCommand(dataExport)
 	Data.AddCommand(dataExec)
 	Data.AddCommand(dataRm)
+	Data.AddCommand(dataMigrate)
 	addDataFlags()
 }
 
@@ -117,6 +123,20 @@ var dataRm = &cobra.Command{
 	Run:   RmData,
 }
 
+var dataMigrate = &cobra.Command{
+	Use:   "migrate NAME [NAME...]",
+	Short: "Move the data of data containers into named volumes",
+	Long: `Move the data of data containers into named volumes.
+
+The contents of each data container are copied into a named
+volume of the same name, then the data container is removed
+along with the service container which used it. The service
+container is recreated with the volume on its next start.
+The service must be stopped first.`,
+	Example: `$ eris data migrate ipfs -- will move the ipfs data into a volume`,
+	Run:     MigrateData,
+}
+
 //----------------------------------------------------
 
 func addDataFlags() {
@@ -127,6 +147,7 @@ func addDataFlags() {
 	buildFlag(dataExec, do, "interactive", "data")
 
 	dataImport.Flags().StringVarP(&do.Destination, "dest", "", "", "destination for import into data container")
+	dataImport.Flags().BoolVarP(&do.DataVolume, "volume", "", false, "keep new data in a named volume rather than a data container")
 	//XXX not used ... but could be if we wanted to be less opiniated
 	//dataImport.Flags().StringVarP(&do.Source, "src", "", "", "source on host to import from")
 	//dataExport.Flags().StringVarP(&do.Destination, "dest", "", "", "destination for export on host")
@@ -173,6 +194,12 @@ func RmData(cmd *cobra.Command, args []string) {
 	IfExit(data.RmData(do))
 }
 
+func MigrateData(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "ge", cmd, args))
+	do.Operations.Args = args
+	IfExit(data.MigrateData(do))
+}
+
 func ImportData(cmd *cobra.Command, args []string) {
 	IfExit(ArgCheck(1, "ge", cmd, args))
 	do.Name = args[0]
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/eris-ltd/eris-cli/config"
 	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/remotes"
 	"github.com/eris-ltd/eris-cli/util"
 	"github.com/eris-ltd/eris-cli/version"
 
@@ -41,6 +45,31 @@ Complete documentation is available at https://docs.erisindustries.com
 		log.SetLoggers(logLevel, config.GlobalConfig.Writer, config.GlobalConfig.ErrorWriter)
 
 		ipfs.IpfsHost = config.GlobalConfig.Config.IpfsHost
+		IfExit(loaders.LoadProfile(do.Profile))
+
+		if strings.HasPrefix(do.MachineName, "http://") || strings.HasPrefix(do.MachineName, "https://") {
+			perform.Agent = perform.NewHTTPClient(do.MachineName)
+			util.Backend = perform.Agent
+			return
+		}
+
+		if do.Native {
+			util.Backend = perform.NewNativeBackend()
+			return
+		}
+
+		// Remotes commands manage their own connections.
+		if cmd.Parent() == Remotes {
+			return
+		}
+
+		if remotes.IsKnown(do.MachineName) {
+			remote, err := remotes.LoadRemoteDefinition(do.MachineName)
+			IfExit(err)
+			disconnect, err = remotes.Connect(remote)
+			IfExit(err)
+			return
+		}
 
 		util.DockerConnect(do.Verbose, do.MachineName)
 
@@ -51,6 +80,10 @@ Complete documentation is available at https://docs.erisindustries.com
 		}
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if disconnect != nil {
+			disconnect()
+		}
+
 		err := config.SaveGlobalConfig(config.GlobalConfig.Config)
 		if err != nil {
 			logger.Errorln(err)
@@ -79,21 +112,28 @@ func AddCommands() {
 	buildActionsCommand()
 	ErisCmd.AddCommand(Actions)
 
-	// TODO
-	// buildProjectsCommand()
-	// ErisCmd.AddCommand(Projects)
-	// buildRemotesCommand()
-	// ErisCmd.AddCommand(Remotes)
+	buildProjectsCommand()
+	ErisCmd.AddCommand(Projects)
+	buildStackCommand()
+	ErisCmd.AddCommand(Stack)
+	buildRemotesCommand()
+	ErisCmd.AddCommand(Remotes)
 
 	buildFilesCommand()
 	ErisCmd.AddCommand(Files)
 	buildDataCommand()
 	ErisCmd.AddCommand(Data)
+	buildImagesCommand()
+	ErisCmd.AddCommand(Images)
+	buildAgentCommand()
+	ErisCmd.AddCommand(Agent)
 	ErisCmd.AddCommand(ListEverything)
 	buildManCommand()
 	ErisCmd.AddCommand(ManPage)
 	buildCleanCommand()
 	ErisCmd.AddCommand(Clean)
+	buildValidateCommand()
+	ErisCmd.AddCommand(Validate)
 	buildInitCommand()
 	ErisCmd.AddCommand(Init)
 	buildUpdateCommand()
@@ -108,13 +148,18 @@ func AddCommands() {
 // Global Do struct
 var do *definitions.Do
 
+// Tears down the connection to a remote given with --machine.
+var disconnect func()
+
 // Flags that are to be used by commands are handled by the Do struct
 // Define the persistent commands (globals)
 func AddGlobalFlags() {
 	ErisCmd.PersistentFlags().BoolVarP(&do.Verbose, "verbose", "v", false, "verbose output")
 	ErisCmd.PersistentFlags().BoolVarP(&do.Debug, "debug", "d", false, "debug level output")
 	ErisCmd.PersistentFlags().IntVarP(&do.Operations.ContainerNumber, "num", "n", 1, "container number")
-	ErisCmd.PersistentFlags().StringVarP(&do.MachineName, "machine", "m", "eris", "machine name for docker-machine that is running VM")
+	ErisCmd.PersistentFlags().StringVarP(&do.MachineName, "machine", "m", "eris", "machine name for docker-machine that is running VM, name of a remote, or an http:// URL of an eris agent")
+	ErisCmd.PersistentFlags().BoolVarP(&do.Native, "native", "", os.Getenv("ERIS_NATIVE") == "true", "run services and chains as host processes instead of docker containers (or set ERIS_NATIVE=true)")
+	ErisCmd.PersistentFlags().StringVarP(&do.Profile, "profile", "", os.Getenv("ERIS_PROFILE"), "profile in ~/.eris/profiles with the values of the ${VAR} variables of service and chain definitions (or set ERIS_PROFILE)")
 }
 
 func InitializeConfig() {
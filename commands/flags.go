@@ -40,6 +40,8 @@ func buildFlag(cmd *cobra.Command, do *definitions.Do, flag, typ string) { //doe
 		cmd.Flags().BoolVarP(&do.Rm, "rm", "r", false, "remove containers after stopping")
 	case "data":
 		cmd.Flags().BoolVarP(&do.RmD, "data", "x", false, "remove data containers after stopping")
+	case "cascade":
+		cmd.Flags().BoolVarP(&do.Cascade, "cascade", "", false, fmt.Sprintf("stop the running services and chains which depend on the %s too", typ))
 		//exec (services, chains)
 	case "publish":
 		cmd.PersistentFlags().BoolVarP(&do.Operations.PublishAllPorts, "publish", "p", false, "publish random ports")
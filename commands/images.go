@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"github.com/eris-ltd/eris-cli/images"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
+)
+
+// Primary Images Sub-Command
+var Images = &cobra.Command{
+	Use:   "images",
+	Short: "Save and Load the Images of Services and Chains.",
+	Long: `Save the images services and chains need to a file and load
+them on machines which cannot reach the registries.`,
+	Run: func(cmd *cobra.Command, args []string) { cmd.Help() },
+}
+
+// Build the images subcommand
+func buildImagesCommand() {
+	Images.AddCommand(imagesSave)
+	Images.AddCommand(imagesLoad)
+	addImagesFlags()
+}
+
+var imagesSave = &cobra.Command{
+	Use:   "save FILE.tar",
+	Short: "Save the images of services and chains to a file.",
+	Long: `Save the images of the services and chains given to a file.
+
+The images of the services and chains they depend on are saved too,
+as are the images of the data containers (quay.io/eris/data) and of
+the containers data is handled with (quay.io/eris/base). Images not
+found locally are pulled first, and those of [build] sections are
+built.
+
+The file is in the format of [docker save], with an eris manifest
+(eris-manifest.json) listing what it was saved for.`,
+	Example: `$ eris images save --services ipfs,keys --chains simplechain lab.tar`,
+	Run:     SaveImages,
+}
+
+var imagesLoad = &cobra.Command{
+	Use:   "load FILE.tar",
+	Short: "Load the images saved to a file.",
+	Long: `Load the images saved to a file with [eris images save].
+
+The images the manifest of the file lists are checked to be loaded,
+so that the services and chains it was saved for start without
+pulling any image.`,
+	Example: `$ eris images load lab.tar`,
+	Run:     LoadImages,
+}
+
+func addImagesFlags() {
+	imagesSave.Flags().StringSliceVarP(&do.ServicesSlice, "services", "s", []string{}, "comma separated list of services whose images to save")
+	imagesSave.Flags().StringSliceVarP(&do.ChainsSlice, "chains", "c", []string{}, "comma separated list of chains whose images to save")
+}
+
+func SaveImages(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Path = args[0]
+	IfExit(images.Save(do))
+}
+
+func LoadImages(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Path = args[0]
+	IfExit(images.Load(do))
+}
@@ -1,8 +1,11 @@
 package commands
 
 import (
+	"strings"
+
 	prj "github.com/eris-ltd/eris-cli/projects"
 
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
 )
 
@@ -12,13 +15,13 @@ var Projects = &cobra.Command{
 	Short: "Start, Stop, and Manage Projects or Applications.",
 	Long: `Start, stop, and manage projects or applications.
 
-Within the Eris platform, projects are a bundle of services,
-and actions which are configured to run in a specific manner.
-Projects may be defined either by a package.json file in the
-root of an application's directory or via a docker-compose.yml
-file in the root of an application's directory. Projects are
-given a human readable name so that Eris can checkout and
-operate the application or project.`,
+Within the Eris platform, projects are a bundle of a chain,
+services, actions, and a contracts package which are configured
+to run in a specific manner. Projects are defined by project
+definition files in ~/.eris/projects (a project.toml file in the
+root of an application's directory can be added with
+[eris projects add]). Projects are given a human readable name
+so that Eris can checkout and operate the application or project.`,
 	Run: func(cmd *cobra.Command, args []string) { cmd.Help() },
 }
 
@@ -30,6 +33,7 @@ func buildProjectsCommand() {
 	Projects.AddCommand(projectsInstall)
 	Projects.AddCommand(projectsList)
 	Projects.AddCommand(projectsCheckout)
+	Projects.AddCommand(projectsCurrent)
 	Projects.AddCommand(projectsConfig)
 	Projects.AddCommand(projectsServices)
 	Projects.AddCommand(projectsActions)
@@ -39,6 +43,7 @@ func buildProjectsCommand() {
 	Projects.AddCommand(projectsRedefine)
 	Projects.AddCommand(projectsRm)
 	Projects.AddCommand(projectsClean)
+	addProjectsFlags()
 }
 
 // get a project definition file from a remote (currently limited to github.com and ipfs)
@@ -47,23 +52,26 @@ var projectsGet = &cobra.Command{
 	Use:   "get [name] [github.com/USER/REPO] || [name] [ipfs hash]",
 	Short: "Get a project from Github or IPFS.",
 	Long: `Retrieve a project from the internet (utilizes git clone or ipfs)
-and install the project's dependencies.
+and register it with Eris.
+
+A cloned project is placed in ~/.eris/apps/NAME. If the root of
+the clone has a project.toml (or .json, .yaml) file it defines the
+project; otherwise the clone becomes the project's contracts package.
 
-NOTE: This functionality is currently limited to github.com and IPFS.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Get(cmd, args)
-	},
+NOTE: This functionality is currently limited to git and IPFS.`,
+	Example: `$ eris projects get idi github.com/eris-ltd/idi
+$ eris projects get idi QmcJdniiSKMp5az3fJvkbJTANd7bFtDoUkov3a8pkByWkv`,
+	Run: GetProject,
 }
 
 // new builds a project definition file
 // flags to add: --template, --checkout, --format
 var projectsNew = &cobra.Command{
-	Use:   "new [name]",
-	Short: "Create a new project definition file.",
-	Long:  `Create a new project definition file optionally from a template.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.New(cmd, args)
-	},
+	Use:     "new [name]",
+	Short:   "Create a new project definition file.",
+	Long:    `Create a new project definition file.`,
+	Example: "$ eris projects new idi --chain simplechain --services ipfs,keys --actions deploy",
+	Run:     NewProject,
 }
 
 // add brings a project into the eris projects tree
@@ -71,23 +79,25 @@ var projectsNew = &cobra.Command{
 var projectsAdd = &cobra.Command{
 	Use:   "add [name] [project-definition-file]",
 	Short: "Add a project to Eris.",
-	Long: `Projects may be defined either by a package.json file in the root
-of an application's directory or via a docker-compose.yml file
-in the root of an application's directory.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Add(cmd, args)
-	},
+	Long: `Add a project to Eris from a project definition file (in JSON,
+TOML, or YAML format) such as the project.toml file in the root of
+an application's directory. Flags take precedence over the file.
+
+A project definition names the project's chain, its services,
+its actions, and the directory of its contracts package.`,
+	Example: "$ eris projects add idi ~/idi/project.toml",
+	Run:     AddProject,
 }
 
 // install dependencies
 // flags to add: --checkout
 var projectsInstall = &cobra.Command{
-	Use:   "install [name] [package-definition-file]",
+	Use:   "install [name] [project-definition-file]",
 	Short: "Install a project's dependencies.",
-	Long:  `Install a project's dependencies if those dependencies are defined services.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Install(cmd, args)
-	},
+	Long: `Install a project's dependencies by pulling the images of the
+project's chain and services (and of the services they depend on).
+If a project definition file is given, the project is added first.`,
+	Run: InstallProject,
 }
 
 // list known projects
@@ -96,29 +106,39 @@ var projectsList = &cobra.Command{
 	Short: "List projects registered with Eris.",
 	Long: `List all projects registered with Eris. To add a project use:
 [eris projects add project-definition-file]`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.ListProjects()
-	},
+	Run: ListProjects,
 }
 
 // checkout a known project
 var projectsCheckout = &cobra.Command{
 	Use:   "checkout [project-name]",
 	Short: "Checkout a project registered with Eris.",
-	Long:  `Checkout a project registered with Eris.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Checkout(cmd, args)
-	},
+	Long: `Checkout a project registered with Eris.
+
+The project commands which take an optional [name] will operate
+on the checked out project. If no [project-name] is given, the
+current checkout is cleared.`,
+	Run: CheckoutProject,
+}
+
+// show the checked out project
+var projectsCurrent = &cobra.Command{
+	Use:   "current",
+	Short: "The currently checked out project.",
+	Long:  `Display the currently checked out project.`,
+	Run:   CurrentProject,
 }
 
 // configure known projects
 var projectsConfig = &cobra.Command{
-	Use:   "config [service] [key]:[val]",
+	Use:   "config [name] [key]:[val]...",
 	Short: "Configure projects registered with Eris.",
-	Long:  `Configure projects registered with Eris.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Configure(cmd, args)
-	},
+	Long: `Configure projects registered with Eris. If no [name] is
+given, will configure the currently checked out project.
+
+Known keys are chain, services, actions (comma separated), and contracts.`,
+	Example: "$ eris projects config idi chain:simplechain services:ipfs,keys",
+	Run:     ConfigureProject,
 }
 
 // list the services associated with the currently checked out project
@@ -128,9 +148,7 @@ var projectsServices = &cobra.Command{
 	Short: "List services for a project.",
 	Long: `List services for a project. If no arguments are given, will
 display the services for the currently checked out project.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.ListServices(cmd, args)
-	},
+	Run: ListProjectServices,
 }
 
 // list the actions associated with the currently checked out project
@@ -140,9 +158,7 @@ var projectsActions = &cobra.Command{
 	Short: "List actions for a project.",
 	Long: `List actions for a project. If no arguments are given, will
 display the actions for the currently checked out project.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.ListActions(cmd, args)
-	},
+	Run: ListProjectActions,
 }
 
 // start a project
@@ -152,10 +168,11 @@ var projectsStart = &cobra.Command{
 	Short: "Start a project registered with Eris.",
 	Long: `Start a project registered with Eris. If no [name] is give Eris
 will simply start the currently checked out project. To stop a
-project use: [eris projects kill name].`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Start(cmd, args)
-	},
+project use: [eris projects kill name].
+
+The project's chain is started first and then its services, each
+after the services it depends on.`,
+	Run: StartProject,
 }
 
 // stop a running project
@@ -163,10 +180,11 @@ var projectsStop = &cobra.Command{
 	Use:   "kill [name]",
 	Short: "Stop a running project.",
 	Long: `Stop a running project. If no [name] is give Eris
-will simply stop the currently checked out project.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Kill(cmd, args)
-	},
+will simply stop the currently checked out project.
+
+The services are stopped in the reverse order they were started,
+followed by the chain.`,
+	Run: KillProject,
 }
 
 // rename known projects
@@ -175,42 +193,175 @@ var projectsRename = &cobra.Command{
 	Short: "Rename a project registered with Eris.",
 	Long: `Rename a project registered with Eris. To add a project use:
 eris project add [project-definition-file]`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Rename(cmd, args)
-	},
+	Run: RenameProject,
 }
 
 // change the package definition file for a known project
 var projectsRedefine = &cobra.Command{
-	Use:   "redefine [name] [package-definition-file]",
+	Use:   "redefine [name] [project-definition-file]",
 	Short: "Change a project's definition file.",
 	Long:  `Change a project's definition file.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Redefine(cmd, args)
-	},
+	Run:   RedefineProject,
 }
 
 // remove a known projects
 // flags to add: --clean
 var projectsRm = &cobra.Command{
-	Use:   "rm [name]",
+	Use:   "rm [name]...",
 	Short: "Remove a project registered with Eris.",
 	Long: `Remove a project registered with Eris. Will not delete the
 project's data (chains, etc.). To remove all of the project's
 data use: [eris project clean name]`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Rm(cmd, args)
-	},
+	Run: RmProject,
 }
 
 // clean a project's data from the machine
 // flags to add: --force (no confirm)
 var projectsClean = &cobra.Command{
-	Use:   "clean",
+	Use:   "clean [name]",
 	Short: "Clean a project's data from the machine.",
 	Long: `Clean a project's data from the machine and unregister the
-project with Eris.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		prj.Clean(cmd, args)
-	},
+project with Eris. The project's chain and service containers are
+removed along with their data containers.`,
+	Run: CleanProject,
+}
+
+//----------------------------------------------------------------------
+// cli flags
+
+func addProjectsFlags() {
+	for _, cmd := range []*cobra.Command{projectsNew, projectsAdd, projectsGet} {
+		cmd.Flags().StringVarP(&do.Project.Chain, "chain", "c", "", "chain the project runs against")
+		cmd.Flags().StringSliceVarP(&do.Project.Services, "services", "s", []string{}, "comma separated list of the project's services")
+		cmd.Flags().StringSliceVarP(&do.Project.Actions, "actions", "a", []string{}, "comma separated list of the project's actions")
+		cmd.Flags().StringVarP(&do.Project.Contracts, "contracts", "", "", "directory of the project's contracts package")
+	}
+
+	buildFlag(projectsServices, do, "quiet", "project")
+	buildFlag(projectsActions, do, "quiet", "project")
+	buildFlag(projectsList, do, "quiet", "project")
+
+	buildFlag(projectsStart, do, "env", "project")
+	buildFlag(projectsStart, do, "links", "project")
+
+	buildFlag(projectsStop, do, "force", "project")
+	buildFlag(projectsStop, do, "timeout", "project")
+	buildFlag(projectsStop, do, "rm", "project")
+	buildFlag(projectsStop, do, "data", "project")
+	buildFlag(projectsStop, do, "volumes", "project")
+}
+
+//----------------------------------------------------------------------
+// cli command wrappers
+
+func GetProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(2, "eq", cmd, args))
+	do.Name = args[0]
+	do.Path = args[1]
+	IfExit(prj.Get(do))
+}
+
+func NewProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Name = args[0]
+	IfExit(prj.New(do))
+}
+
+func AddProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "ge", cmd, args))
+	do.Name = args[0]
+	if len(args) > 1 {
+		do.Path = args[1]
+	}
+	IfExit(prj.Add(do))
+}
+
+func InstallProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "ge", cmd, args))
+	do.Name = args[0]
+	if len(args) > 1 {
+		do.Path = args[1]
+	}
+	IfExit(prj.Install(do))
+}
+
+func ListProjects(cmd *cobra.Command, args []string) {
+	IfExit(prj.ListProjects(do))
+}
+
+func CheckoutProject(cmd *cobra.Command, args []string) {
+	if len(args) >= 1 {
+		do.Name = args[0]
+	} else {
+		do.Name = ""
+	}
+	IfExit(prj.Checkout(do))
+}
+
+func CurrentProject(cmd *cobra.Command, args []string) {
+	IfExit(prj.Current(do))
+}
+
+func ConfigureProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "ge", cmd, args))
+	if !strings.Contains(args[0], ":") {
+		do.Name = args[0]
+		args = args[1:]
+	}
+	do.Operations.Args = args
+	IfExit(prj.Configure(do))
+}
+
+func ListProjectServices(cmd *cobra.Command, args []string) {
+	nameOrHead(args)
+	IfExit(prj.ListServices(do))
+}
+
+func ListProjectActions(cmd *cobra.Command, args []string) {
+	nameOrHead(args)
+	IfExit(prj.ListActions(do))
+}
+
+func StartProject(cmd *cobra.Command, args []string) {
+	nameOrHead(args)
+	IfExit(prj.Start(do))
+}
+
+func KillProject(cmd *cobra.Command, args []string) {
+	nameOrHead(args)
+	IfExit(prj.Kill(do))
+}
+
+func RenameProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(2, "eq", cmd, args))
+	do.Name = args[0]
+	do.NewName = args[1]
+	IfExit(prj.Rename(do))
+}
+
+func RedefineProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(2, "eq", cmd, args))
+	do.Name = args[0]
+	do.Path = args[1]
+	IfExit(prj.Redefine(do))
+}
+
+func RmProject(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "ge", cmd, args))
+	do.Operations.Args = args
+	IfExit(prj.Rm(do))
+}
+
+func CleanProject(cmd *cobra.Command, args []string) {
+	nameOrHead(args)
+	IfExit(prj.Clean(do))
+}
+
+// an empty do.Name selects the checked out project
+func nameOrHead(args []string) {
+	if len(args) >= 1 {
+		do.Name = args[0]
+	} else {
+		do.Name = ""
+	}
 }
@@ -3,6 +3,7 @@ package commands
 import (
 	rem "github.com/eris-ltd/eris-cli/remotes"
 
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
 )
 
@@ -14,7 +15,10 @@ various services reachable by the Eris platform.
 
 Actions, if configured as such, can utilize remote machines.
 To register and manage remote machines for sending of actions
-to those machines, use this command.`,
+to those machines, use this command.
+
+Remote definition files live in ~/.eris/remotes/NAME.toml.
+Any eris command can be pointed at a remote with --machine=NAME.`,
 	Run: func(cmd *cobra.Command, args []string) { cmd.Help() },
 }
 
@@ -26,16 +30,21 @@ func buildRemotesCommand() {
 	Remotes.AddCommand(remotesEdit)
 	Remotes.AddCommand(remotesRename)
 	Remotes.AddCommand(remotesRemove)
+	addRemotesFlags()
 }
 
 // add
 var remotesAdd = &cobra.Command{
-	Use:   "add [name] [remote-definition-file]",
+	Use:   "add NAME [REMOTE-DEFINITION-FILE]",
 	Short: "Adds a remote to Eris.",
-	Long:  `Adds a remote to Eris in JSON, TOML, or YAML format.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		rem.Add(args)
-	},
+	Long: `Adds a remote to Eris in JSON, TOML, or YAML format.
+
+The remote is described either by a definition file or by
+flags (which take precedence over the file).`,
+	Example: `$ eris remotes add node1 --host 10.0.0.5 --docker-host tcp://10.0.0.5:2376 --cert-path ~/.docker/node1
+$ eris remotes add node2 --host node2.example.com --ssh-user core
+$ eris remotes add node3 ~/node3.toml`,
+	Run: AddRemote,
 }
 
 // ls
@@ -44,47 +53,98 @@ var remotesList = &cobra.Command{
 	Use:   "ls",
 	Short: "List all registered remotes.",
 	Long:  `List all registered remotes`,
-	Run: func(cmd *cobra.Command, args []string) {
-		rem.List()
-	},
+	Run:   ListRemotes,
 }
 
 // do
 var remotesDo = &cobra.Command{
-	Use:   "do [name]",
+	Use:   "do NAME ACTION",
 	Short: "Perform an action on a remote.",
-	Long:  `Perform an action on a remote according to the action definition file.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		rem.Do(args)
-	},
+	Long: `Perform an action on a remote according to the action definition file.
+
+The services and chain the action depends on are started on the
+remote and the action's steps run with DOCKER_HOST pointing at
+the remote's docker daemon.`,
+	Example: "$ eris remotes do node1 chain info",
+	Run:     DoRemote,
 }
 
 // edit
 var remotesEdit = &cobra.Command{
-	Use:   "edit [name]",
+	Use:   "edit NAME",
 	Short: "Edit a remote definition file.",
 	Long:  `Edit a remote definition file`,
-	Run: func(cmd *cobra.Command, args []string) {
-		rem.Edit(args)
-	},
+	Run:   EditRemote,
 }
 
 // rename
 var remotesRename = &cobra.Command{
-	Use:   "rename [old] [new]",
+	Use:   "rename OLD NEW",
 	Short: "Rename a remote.",
 	Long:  `Rename a remote`,
-	Run: func(cmd *cobra.Command, args []string) {
-		rem.Rename(args)
-	},
+	Run:   RenameRemote,
 }
 
 // remove
 var remotesRemove = &cobra.Command{
-	Use:   "remove [name]",
+	Use:   "remove NAME",
 	Short: "Remove a remote definition file.",
 	Long:  `Remove a remote definition file`,
-	Run: func(cmd *cobra.Command, args []string) {
-		rem.Remove(args)
-	},
+	Run:   RmRemote,
+}
+
+// ----------------------------------------------------------------------
+// cli flags
+func addRemotesFlags() {
+	remotesAdd.Flags().StringVarP(&do.Remote.Host, "host", "", "", "host name or address of the remote machine")
+	remotesAdd.Flags().StringVarP(&do.Remote.DockerHost, "docker-host", "", "", "docker daemon endpoint of the remote (e.g. tcp://10.0.0.5:2376)")
+	remotesAdd.Flags().StringVarP(&do.Remote.DockerCertPath, "cert-path", "", "", "directory with cert.pem, key.pem and ca.pem for a TLS docker endpoint")
+	remotesAdd.Flags().StringVarP(&do.Remote.SSHUser, "ssh-user", "", "", "user to ssh into the remote as (tunnels the docker socket when no docker host is given)")
+	remotesAdd.Flags().StringVarP(&do.Remote.Agent, "agent", "", "", "url of an eris agent running on the remote")
+
+	buildFlag(remotesDo, do, "quiet", "action")
+	buildFlag(remotesDo, do, "chain", "action")
+	buildFlag(remotesDo, do, "services", "action")
+}
+
+//----------------------------------------------------------------------
+// cli command wrappers
+
+func AddRemote(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "ge", cmd, args))
+	do.Name = args[0]
+	if len(args) > 1 {
+		do.Path = args[1]
+	}
+	IfExit(rem.AddRemote(do))
+}
+
+func ListRemotes(cmd *cobra.Command, args []string) {
+	IfExit(rem.ListRemotes(do))
+}
+
+func DoRemote(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(2, "ge", cmd, args))
+	do.Name = args[0]
+	do.Operations.Args = args[1:]
+	IfExit(rem.DoRemote(do))
+}
+
+func EditRemote(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Name = args[0]
+	IfExit(rem.EditRemote(do))
+}
+
+func RenameRemote(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(2, "eq", cmd, args))
+	do.Name = args[0]
+	do.NewName = args[1]
+	IfExit(rem.RenameRemote(do))
+}
+
+func RmRemote(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "ge", cmd, args))
+	do.Operations.Args = args
+	IfExit(rem.RmRemote(do))
 }
@@ -65,11 +65,27 @@ Blockchains are handled using the [eris chains] command.`,
 }
 
 var servicesImport = &cobra.Command{
-	Use:     "import NAME HASH",
-	Short:   "Import a service definition file from IPFS.",
-	Long:    `Import a service for your platform.`,
-	Example: "$ eris services import eth QmQ1LZYPNG4wSb9dojRicWCmM4gFLTPKFUhFnMTR3GKuA2",
-	Run:     ImportService,
+	Use:   "import NAME LOCATION",
+	Short: "Import a service definition file from IPFS or git.",
+	Long: `Import a service for your platform.
+
+LOCATION is an IPFS hash or a git location
+
+	git:URL[#REF[:PATH]]
+	git://HOST/REPO[#REF[:PATH]]
+	github:ORG/REPO[#REF[:PATH]]
+
+For git locations the repository is cloned with the local git binary
+(any URL git understands works, including file:// remotes). REF is
+a branch, tag, or commit, and PATH is the definition file or the
+directory holding it (NAME.toml, .json, or .yaml, directly or in a
+services subdirectory).
+
+A github: PATH naming a definition file is downloaded from GitHub
+without cloning the repository.`,
+	Example: `$ eris services import eth QmQ1LZYPNG4wSb9dojRicWCmM4gFLTPKFUhFnMTR3GKuA2
+$ eris services import eth github:eris-ltd/eris-services#master:services`,
+	Run: ImportService,
 }
 
 var servicesNew = &cobra.Command{
@@ -166,8 +182,13 @@ var servicesExec = &cobra.Command{
 var servicesStop = &cobra.Command{
 	Use:   "stop NAME",
 	Short: "Stop a running service.",
-	Long:  `Stop a service which is currently running.`,
-	Run:   KillService,
+	Long: `Stop a service which is currently running.
+
+The services the service depends on are stopped after it, unless
+other running services or chains still need them. Chains are not
+stopped as dependencies. With --cascade, the running services and
+chains which depend on the service are stopped before it.`,
+	Run: KillService,
 }
 
 var servicesRename = &cobra.Command{
@@ -211,7 +232,11 @@ var servicesCat = &cobra.Command{
 	Short: "Display the service definition file.",
 	Long: `Display the service definition file.
 
-Command will cat local service definition file.`,
+Command will cat local service definition file. With --resolved,
+the definitions the service extends are merged in and the
+result is displayed as TOML.`,
+	Example: `$ eris services cat ipfs
+$ eris services cat ipfs --resolved`,
 	Run: CatService,
 }
 
@@ -247,9 +272,12 @@ func addServicesFlags() {
 	buildFlag(servicesStop, do, "data", "service")
 	buildFlag(servicesStop, do, "force", "service")
 	buildFlag(servicesStop, do, "timeout", "service")
+	buildFlag(servicesStop, do, "cascade", "service")
 	servicesStop.Flags().BoolVarP(&do.All, "all", "a", false, "stop the primary service and its dependent services")
 	servicesStop.Flags().StringVarP(&do.ChainName, "chain", "c", "", "specify a chain the service should also stop")
 
+	servicesCat.Flags().BoolVarP(&do.Resolved, "resolved", "", false, "display the definition with those it extends merged in")
+
 	buildFlag(servicesListAll, do, "known", "service")
 	buildFlag(servicesListAll, do, "existing", "service")
 	buildFlag(servicesListAll, do, "running", "service")
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"github.com/eris-ltd/eris-cli/stacks"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
+)
+
+// Primary Stack Sub-Command
+var Stack = &cobra.Command{
+	Use:   "stack",
+	Short: "Bring a Stack of Chains and Services Up and Down.",
+	Long: `Bring a stack of chains and services up and down.
+
+A stack is an environment described by a single manifest file
+(TOML, YAML or JSON): the chains to run (with their genesis files
+and config.toml options), the services to run (with overrides of
+fields of their service definitions), data to import into data
+containers, and actions to perform once everything is up.`,
+	Run: func(cmd *cobra.Command, args []string) { cmd.Help() },
+}
+
+// Build the stack subcommand
+func buildStackCommand() {
+	Stack.AddCommand(stackUp)
+	Stack.AddCommand(stackDown)
+	addStackFlags()
+}
+
+var stackUp = &cobra.Command{
+	Use:   "up FILE",
+	Short: "Bring a stack up or change it to match its manifest.",
+	Long: `Bring the stack of a manifest file up.
+
+[eris stack up] only changes what differs between the manifest and
+what is running, so it can be run again after editing the manifest.
+Chains and services not yet there are created, those whose entries
+(or service definition files) changed are recreated, stopped ones
+are started, and those dropped from the manifest are stopped and
+removed. A changed chain is made anew from its genesis. Data is
+imported and actions are performed when their entries are new or
+changed.
+
+The stack's state is kept in ~/.eris/stacks/NAME.json.`,
+	Example: `$ eris stack up stack.toml
+
+# stack.toml
+[[chains]]
+name = "simplechain"
+genesis = "genesis.json"
+options = ["log_level=info"]
+
+[[data]]
+name = "ipfs"
+source = "./files"
+destination = "/home/eris/.eris/files"
+
+[[services]]
+name = "ipfs"
+chain = "simplechain"
+  [services.service]
+  environment = ["IPFS_DEBUG=1"]
+
+[[actions]]
+name = "deploy"
+vars = ["env:test"]
+chain = "simplechain"`,
+	Run: StackUp,
+}
+
+var stackDown = &cobra.Command{
+	Use:   "down FILE",
+	Short: "Stop and remove the chains and services of a stack.",
+	Long: `Stop and remove the services and then the chains of a stack,
+dependents first. Data containers are kept unless --data is given.`,
+	Example: "$ eris stack down stack.toml",
+	Run:     StackDown,
+}
+
+func addStackFlags() {
+	buildFlag(stackUp, do, "timeout", "stack")
+	buildFlag(stackUp, do, "data", "stack")
+	buildFlag(stackUp, do, "quiet", "stack")
+
+	buildFlag(stackDown, do, "timeout", "stack")
+	buildFlag(stackDown, do, "data", "stack")
+}
+
+func StackUp(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Path = args[0]
+	IfExit(stacks.Up(do))
+}
+
+func StackDown(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(1, "eq", cmd, args))
+	do.Path = args[0]
+	IfExit(stacks.Down(do))
+}
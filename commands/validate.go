@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/eris-ltd/eris-cli/loaders"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
+)
+
+var Validate = &cobra.Command{
+	Use:   "validate [services|chains|actions] NAME",
+	Short: "Check a service, chain, or action definition file.",
+	Long: `Check a service, chain, or action definition file for mistakes.
+
+Fields unknown to eris (eg. typos) and values of the wrong type are
+reported, as are malformed port and volume specs, links and
+dependencies to unknown services or chains, and images without a
+tag (as a warning). Each problem is given as file:line: message.
+
+Service and chain definition files are also checked this way before
+they are started.`,
+	Example: `$ eris validate services ipfs
+$ eris validate chains simplechain`,
+	Run: ValidateDefinition,
+}
+
+func buildValidateCommand() {
+	addValidateFlags()
+}
+
+func addValidateFlags() {
+	buildFlag(Validate, do, "quiet", "validate")
+}
+
+func ValidateDefinition(cmd *cobra.Command, args []string) {
+	IfExit(ArgCheck(2, "eq", cmd, args))
+	do.Type = args[0]
+	do.Name = args[1]
+	IfExit(loaders.ValidateDefinition(do))
+}
@@ -29,9 +29,19 @@ type ErisConfig struct {
 	DockerHost     string `json:"DockerHost,omitempty" yaml:"DockerHost,omitempty" toml:"DockerHost,omitempty"`
 	DockerCertPath string `json:"DockerCertPath,omitempty" yaml:"DockerCertPath,omitempty" toml:"DockerCertPath,omitempty"`
 
+	// credentials for private registries by hostname (eg. quay.io or localhost:5000)
+	Registries map[string]*Registry `mapstructure:"registries" json:"registries,omitempty" yaml:"registries,omitempty" toml:"registries,omitempty"`
+
 	Verbose bool
 }
 
+// Registry is an entry of the [registries] table of eris.toml.
+type Registry struct {
+	Username string `json:"username,omitempty" yaml:"username,omitempty" toml:"username,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty" toml:"password,omitempty"`
+	Email    string `json:"email,omitempty" yaml:"email,omitempty" toml:"email,omitempty"`
+}
+
 func SetGlobalObject(writer, errorWriter io.Writer) (*ErisCli, error) {
 	e := ErisCli{
 		Writer:      writer,
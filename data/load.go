@@ -39,6 +39,12 @@ func giveMeAllTheNames(name string, srv *def.ServiceDefinition) {
 	logger.Debugf("My data container name is =>\t%s\n", srv.Operations.DataContainerName)
 }
 
+// dataExists returns true if there is a data container or a data volume
+// of name and number.
+func dataExists(name string, number int) bool {
+	return util.IsData(name, number)
+}
+
 func checkServiceGiven(args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("No Data Container Given. Please rerun command with a known data container.")
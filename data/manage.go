@@ -25,6 +25,12 @@ func RenameData(do *definitions.Do) error {
 		if err != nil {
 			return err
 		}
+	} else if util.IsDataVolume(do.Name, do.Operations.ContainerNumber) {
+		ops := loaders.LoadDataDefinition(do.Name, do.Operations.ContainerNumber)
+
+		if err := perform.DockerRenameDataVolume(ops, do.NewName); err != nil {
+			return err
+		}
 	} else {
 		return fmt.Errorf("I cannot find that data container. Please check the data container name you sent me.")
 	}
@@ -43,6 +49,13 @@ func InspectData(do *definitions.Do) error {
 		if err != nil {
 			return err
 		}
+	} else if util.IsDataVolume(do.Name, do.Operations.ContainerNumber) {
+		logger.Infoln("Inspecting data volume " + do.Name)
+
+		ops := loaders.LoadDataDefinition(do.Name, do.Operations.ContainerNumber)
+		if err := perform.DockerInspectDataVolume(ops, do.Operations.Args[0]); err != nil {
+			return err
+		}
 	} else {
 		return fmt.Errorf("I cannot find that data container. Please check the data container name you sent me.")
 	}
@@ -68,6 +81,15 @@ func RmData(do *definitions.Do) (err error) {
 				return err
 			}
 
+		} else if util.IsDataVolume(do.Name, do.Operations.ContainerNumber) {
+			logger.Infoln("Removing data volume " + do.Name)
+
+			ops := loaders.LoadDataDefinition(do.Name, do.Operations.ContainerNumber)
+			if err = perform.DockerRemoveDataVolume(ops); err != nil {
+				logger.Errorf("Error removing %s: %v", do.Name, err)
+				return err
+			}
+
 		} else {
 			err = fmt.Errorf("I cannot find that data container for %s. Please check the data container name you sent me.", do.Name)
 			logger.Errorln(err)
@@ -86,6 +108,32 @@ func RmData(do *definitions.Do) (err error) {
 	return err
 }
 
+// MigrateData moves the data containers do.Operations.Args (or do.Name)
+// into named volumes. The service containers which used them are
+// removed, to be recreated with the volumes on their next start.
+func MigrateData(do *definitions.Do) error {
+	if len(do.Operations.Args) == 0 {
+		do.Operations.Args = []string{do.Name}
+	}
+	for _, name := range do.Operations.Args {
+		if !util.IsDataContainer(name, do.Operations.ContainerNumber) {
+			if util.IsDataVolume(name, do.Operations.ContainerNumber) {
+				logger.Printf("The data of %s is in a volume already.\n", name)
+				continue
+			}
+			return fmt.Errorf("I cannot find that data container for %s. Please check the data container name you sent me.", name)
+		}
+
+		ops := loaders.LoadDataDefinition(name, do.Operations.ContainerNumber)
+		if err := perform.DockerMigrateData(ops); err != nil {
+			return err
+		}
+		logger.Printf("The data of %s is now in the %s volume.\n", name, ops.DataContainerName)
+	}
+	do.Result = "success"
+	return nil
+}
+
 func IsKnown(name string) bool {
 	return _parseKnown(name)
 }
@@ -18,18 +18,17 @@ import (
 )
 
 func ImportData(do *definitions.Do) error {
-	if util.IsDataContainer(do.Name, do.Operations.ContainerNumber) {
+	if dataExists(do.Name, do.Operations.ContainerNumber) {
 
 		srv := PretendToBeAService(do.Name, do.Operations.ContainerNumber)
-		service, exists := perform.ContainerExists(srv.Operations)
-
-		if !exists {
-			return fmt.Errorf("There is no data container for that service.")
+		id, unmount, err := perform.DockerMountData(srv.Operations)
+		if err != nil {
+			return err
 		}
+		defer unmount()
 
 		containerName := util.DataContainersName(do.Name, do.Operations.ContainerNumber)
 		logger.Debugf("Importing FROM =>\t\t%s\n", do.Source)
-		os.Chdir(do.Source)
 
 		logger.Debugf("Importing TO =>\t\t\t%s\n", do.Destination)
 		reader, err := util.Tar(do.Source, 0)
@@ -44,9 +43,9 @@ func ImportData(do *definitions.Do) error {
 			NoOverwriteDirNonDir: true,
 		}
 
-		logger.Infof("Copying into Cont. ID =>\t%s\n", service.ID)
+		logger.Infof("Copying into Cont. ID =>\t%s\n", id)
 		logger.Debugf("\tPath =>\t\t\t%s\n", do.Source)
-		if err := util.DockerClient.UploadToContainer(service.ID, opts); err != nil {
+		if err := util.Backend.UploadToContainer(id, opts); err != nil {
 			return err
 		}
 
@@ -61,7 +60,11 @@ func ImportData(do *definitions.Do) error {
 		}
 	} else {
 		ops := loaders.LoadDataDefinition(do.Name, do.Operations.ContainerNumber)
-		if err := perform.DockerCreateData(ops); err != nil {
+		if do.DataVolume {
+			if err := perform.DockerCreateDataVolume(ops); err != nil {
+				return fmt.Errorf("Error creating data volume %v.", err)
+			}
+		} else if err := perform.DockerCreateData(ops); err != nil {
 			return fmt.Errorf("Error creating data container %v.", err)
 		}
 		return ImportData(do)
@@ -71,7 +74,7 @@ func ImportData(do *definitions.Do) error {
 }
 
 func ExecData(do *definitions.Do) error {
-	if util.IsDataContainer(do.Name, do.Operations.ContainerNumber) {
+	if dataExists(do.Name, do.Operations.ContainerNumber) {
 		logger.Infoln("Running exec on container with volumes from data container " + do.Operations.DataContainerName)
 
 		ops := loaders.LoadDataDefinition(do.Name, do.Operations.ContainerNumber)
@@ -87,7 +90,7 @@ func ExecData(do *definitions.Do) error {
 }
 
 func ExportData(do *definitions.Do) error {
-	if util.IsDataContainer(do.Name, do.Operations.ContainerNumber) {
+	if dataExists(do.Name, do.Operations.ContainerNumber) {
 
 		logger.Infoln("Exporting data container", do.Name)
 
@@ -99,11 +102,11 @@ func ExportData(do *definitions.Do) error {
 		}
 
 		srv := PretendToBeAService(do.Name, do.Operations.ContainerNumber)
-		service, exists := perform.ContainerExists(srv.Operations)
-
-		if !exists {
-			return fmt.Errorf("There is no data container for that service.")
+		id, unmount, err := perform.DockerMountData(srv.Operations)
+		if err != nil {
+			return err
 		}
+		defer unmount()
 
 		reader, writer := io.Pipe()
 		defer reader.Close()
@@ -114,10 +117,9 @@ func ExportData(do *definitions.Do) error {
 		}
 
 		go func() {
-			logger.Infof("Copying out of Cont. ID =>\t%s\n", service.ID)
+			logger.Infof("Copying out of Cont. ID =>\t%s\n", id)
 			logger.Debugf("\tPath =>\t\t\t%s\n", do.Source)
-			IfExit(util.DockerClient.DownloadFromContainer(service.ID, opts)) // TODO: be smarter about catching this error
-			writer.Close()
+			writer.CloseWithError(util.Backend.DownloadFromContainer(id, opts))
 		}()
 
 		logger.Debugf("Untarring Package from Cont =>\t%s\n", exportPath)
@@ -0,0 +1,20 @@
+package definitions
+
+// Build is the [build] section of service definitions. When it is given
+// eris builds the image of the service from the context directory before
+// starting it, rather than pulling the image.
+type Build struct {
+	// directory sent to the daemon as the build context (relative to ~/.eris/services)
+	Context string `json:"context,omitempty" yaml:"context,omitempty" toml:"context,omitempty"`
+	// path of the Dockerfile within the context (default Dockerfile)
+	Dockerfile string `json:"dockerfile,omitempty" yaml:"dockerfile,omitempty" toml:"dockerfile,omitempty"`
+	// maps directly to docker build-arg
+	Args map[string]string `mapstructure:"args" json:"args,omitempty" yaml:"args,omitempty" toml:"args,omitempty"`
+	// stage of a multi-stage Dockerfile to build
+	Target string `json:"target,omitempty" yaml:"target,omitempty" toml:"target,omitempty"`
+}
+
+// IsEmpty returns true if there is no context to build an image from.
+func (b *Build) IsEmpty() bool {
+	return b == nil || b.Context == ""
+}
@@ -7,6 +7,10 @@ type Chain struct {
 	ChainID string `mapstructure:"chain_id" json:"chain_id" yaml:"chain_id" toml:"chain_id"`
 	// type of the chain
 	ChainType string `mapstructure:"chain_type" json:"chain_type" yaml:"chain_type" toml:"chain_type"`
+	// name of a chain definition whose fields this one inherits (and overrides)
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty" toml:"extends,omitempty"`
+	// lists (eg. service.ports) which replace those inherited rather than add to them
+	Replace []string `json:"replace,omitempty" yaml:"replace,omitempty" toml:"replace,omitempty"`
 
 	// same fields as in the Service Struct/Service Specification
 	Service      *Service      `json:"service,omitempty" yaml:"service,omitempty" toml:"service,omitempty"`
@@ -14,6 +18,7 @@ type Chain struct {
 	Maintainer   *Maintainer   `json:"maintainer,omitempty" yaml:"maintainer,omitempty" toml:"maintainer,omitempty"`
 	Location     *Location     `json:"location,omitempty" yaml:"location,omitempty" toml:"location,omitempty"`
 	Machine      *Machine      `json:"machine,omitempty" yaml:"machine,omitempty" toml:"machine,omitempty"`
+	HealthCheck  *HealthCheck  `json:"healthcheck,omitempty" yaml:"healthcheck,omitempty" toml:"healthcheck,omitempty"`
 	Operations   *Operation
 }
 
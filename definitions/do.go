@@ -4,6 +4,7 @@ type Do struct {
 	AddDir        bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	Actions       bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	Force         bool     `mapstructure:"," json:"," yaml:"," toml:","`
+	Cascade       bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	File          bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	Pull          bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	Quiet         bool     `mapstructure:"," json:"," yaml:"," toml:","`
@@ -20,6 +21,9 @@ type Do struct {
 	Yes           bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	OutputTable   bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	Dump          bool     `mapstructure:"," json:"," yaml:"," toml:","`
+	Native        bool     `mapstructure:"," json:"," yaml:"," toml:","`
+	Resolved      bool     `mapstructure:"," json:"," yaml:"," toml:","`
+	JSON          bool     `mapstructure:"," json:"," yaml:"," toml:","`
 	Lines         int      `mapstructure:"," json:"," yaml:"," toml:","` // XXX: for tail and logs
 	Timeout       uint     `mapstructure:"," json:"," yaml:"," toml:","`
 	N             uint     `mapstructure:"," json:"," yaml:"," toml:","`
@@ -37,6 +41,7 @@ type Do struct {
 	Hash          string   `mapstructure:"," json:"," yaml:"," toml:","`
 	Gateway       string   `mapstructure:"," json:"," yaml:"," toml:","`
 	MachineName   string   `mapstructure:"," json:"," yaml:"," toml:","`
+	Profile       string   `mapstructure:"," json:"," yaml:"," toml:","`
 	Name          string   `mapstructure:"," json:"," yaml:"," toml:","`
 	Image         string   `mapstructure:"," json:"," yaml:"," toml:","`
 	Path          string   `mapstructure:"," json:"," yaml:"," toml:","`
@@ -54,6 +59,7 @@ type Do struct {
 	DefaultFee    string   `mapstructure:"," json:"," yaml:"," toml:","`
 	DefaultAmount string   `mapstructure:"," json:"," yaml:"," toml:","`
 	ServicesSlice []string `mapstructure:"," json:"," yaml:"," toml:","`
+	ChainsSlice   []string `mapstructure:"," json:"," yaml:"," toml:","`
 	ConfigOpts    []string `mapstructure:"," json:"," yaml:"," toml:","`
 	//clean
 	Images    bool `mapstructure:"," json:"," yaml:"," toml:","`
@@ -62,6 +68,7 @@ type Do struct {
 	//data import/export
 	Source      string `mapstructure:"," json:"," yaml:"," toml:","`
 	Destination string `mapstructure:"," json:"," yaml:"," toml:","`
+	DataVolume  bool   `mapstructure:"," json:"," yaml:"," toml:","`
 
 	//listing functions
 	Known    bool `mapstructure:"," json:"," yaml:"," toml:","`
@@ -78,6 +85,8 @@ type Do struct {
 	Action            *Action
 	Chain             *Chain
 	Operations        *Operation
+	Project           *Project
+	Remote            *Remote
 	Service           *Service
 	ServiceDefinition *ServiceDefinition
 
@@ -90,6 +99,8 @@ func NowDo() *Do {
 		Action:            BlankAction(),
 		Chain:             BlankChain(),
 		Operations:        BlankOperation(),
+		Project:           BlankProject(),
+		Remote:            BlankRemote(),
 		Service:           BlankService(),
 		ServiceDefinition: BlankServiceDefinition(),
 	}
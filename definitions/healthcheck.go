@@ -0,0 +1,24 @@
+package definitions
+
+// HealthCheck is the [healthcheck] section of service and chain
+// definitions. After starting the container eris waits until every probe
+// given passes, trying at most Retries times.
+type HealthCheck struct {
+	// container port which must accept tcp connections (e.g. "46657")
+	TCPPort string `mapstructure:"tcp_port" json:"tcp_port,omitempty" yaml:"tcp_port,omitempty" toml:"tcp_port,omitempty"`
+	// container port and path which must answer a GET with a 2xx or 3xx status (e.g. "46657/status")
+	HTTPGet string `mapstructure:"http_get" json:"http_get,omitempty" yaml:"http_get,omitempty" toml:"http_get,omitempty"`
+	// command run in the container (with sh -c) which must exit with 0
+	ExecCommand string `mapstructure:"exec_command" json:"exec_command,omitempty" yaml:"exec_command,omitempty" toml:"exec_command,omitempty"`
+	// time between tries (e.g. "500ms"; default 1s)
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty" toml:"interval,omitempty"`
+	// time a probe may take (default 5s)
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+	// number of tries before giving up (default 30)
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty" toml:"retries,omitempty"`
+}
+
+// IsEmpty returns true if no probe is given.
+func (h *HealthCheck) IsEmpty() bool {
+	return h == nil || (h.TCPPort == "" && h.HTTPGet == "" && h.ExecCommand == "")
+}
@@ -0,0 +1,22 @@
+package definitions
+
+type Project struct {
+	// name of the project
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// the chain the project runs against; it is started before the services
+	Chain string `json:"chain,omitempty" yaml:"chain,omitempty" toml:"chain,omitempty"`
+	// an array of strings listing the services which make up the project
+	Services []string `json:"services,omitempty" yaml:"services,omitempty" toml:"services,omitempty"`
+	// an array of strings listing the actions the project provides
+	Actions []string `json:"actions,omitempty" yaml:"actions,omitempty" toml:"actions,omitempty"`
+	// path to the project's contracts package (a directory with a package.json)
+	Contracts string `json:"contracts,omitempty" yaml:"contracts,omitempty" toml:"contracts,omitempty"`
+
+	Maintainer *Maintainer `json:"maintainer,omitempty" yaml:"maintainer,omitempty" toml:"maintainer,omitempty"`
+}
+
+func BlankProject() *Project {
+	return &Project{
+		Maintainer: BlankMaintainer(),
+	}
+}
@@ -0,0 +1,21 @@
+package definitions
+
+type Remote struct {
+	// name of the remote
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// host name or address of the remote machine
+	Host string `json:"host,omitempty" yaml:"host,omitempty" toml:"host,omitempty"`
+	// docker daemon endpoint (e.g., tcp://10.0.0.5:2376); if empty and
+	// ssh_user is set, the remote's docker socket is tunneled over ssh
+	DockerHost string `mapstructure:"docker_host" json:"docker_host,omitempty" yaml:"docker_host,omitempty" toml:"docker_host,omitempty"`
+	// directory holding cert.pem, key.pem and ca.pem for a TLS docker endpoint
+	DockerCertPath string `mapstructure:"docker_cert_path" json:"docker_cert_path,omitempty" yaml:"docker_cert_path,omitempty" toml:"docker_cert_path,omitempty"`
+	// user to ssh into the remote as
+	SSHUser string `mapstructure:"ssh_user" json:"ssh_user,omitempty" yaml:"ssh_user,omitempty" toml:"ssh_user,omitempty"`
+	// url of an eris agent running on the remote (see `eris agent`)
+	Agent string `json:"agent,omitempty" yaml:"agent,omitempty" toml:"agent,omitempty"`
+}
+
+func BlankRemote() *Remote {
+	return &Remote{}
+}
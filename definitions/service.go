@@ -7,6 +7,9 @@ type Service struct {
 	Image string `json:"image,omitempty" yaml:"image,omitempty" toml:"image,omitempty"`
 	// whether eris should automagically handle a data container for this service
 	AutoData bool `json:"data_container" yaml:"data_container" toml:"data_container"`
+	// whether the data is kept in a named volume rather than a data container
+	// (set by data_container = "volume")
+	DataVolume bool `mapstructure:"-" json:"data_volume,omitempty" yaml:"-" toml:"-"`
 	// maps directly to docker cmd
 	Command string `json:"command,omitempty" yaml:"command,omitempty" toml:"command,omitempty"`
 	// maps directly to docker links
@@ -23,8 +26,11 @@ type Service struct {
 	Environment []string `json:"environment,omitempty" yaml:"environment,omitempty" toml:"environment,omitempty"`
 	// maps directly to docker env-file
 	EnvFile []string `mapstructure:"env_file" json:"env_file,omitempty" yaml:"env_file,omitempty" toml:"env_file,omitempty"`
-	// maps directly to docker net
+	// maps directly to docker net (host, none, bridge, container:NAME, or the
+	// network the container joins first; defaults to the eris network)
 	Net string `json:"net,omitempty" yaml:"net,omitempty" toml:"net,omitempty"`
+	// further user-defined networks the container joins
+	Networks []string `mapstructure:"networks" json:"networks,omitempty" yaml:"networks,omitempty" toml:"networks,omitempty"`
 	// maps directly to docker PID
 	PID string `json:"pid,omitempty" yaml:"pid,omitempty" toml:"pid,omitempty"`
 	// maps directly to docker DNS
@@ -44,10 +50,38 @@ type Service struct {
 	// maps directly to docker cpu_shares
 	CPUShares int64 `mapstructure:"cpu_shares" json:"cpu_shares,omitempty,omitzero" yaml:"cpu_shares,omitempty" toml:"cpu_shares,omitempty,omitzero"`
 	// maps directly to docker mem_limit
-	MemLimit int64 `mapstructure:"mem_limit" json:"memory,omitempty,omitzero" yaml:"memory,omitempty" toml:"memory,omitempty,omitzero"`
+	MemLimit int64 `mapstructure:"mem_limit" json:"mem_limit,omitempty,omitzero" yaml:"mem_limit,omitempty" toml:"mem_limit,omitempty,omitzero"`
+	// maps directly to docker memory-swap (memory plus swap; -1 for unlimited swap)
+	MemSwapLimit int64 `mapstructure:"memswap_limit" json:"memswap_limit,omitempty,omitzero" yaml:"memswap_limit,omitempty" toml:"memswap_limit,omitempty,omitzero"`
+	// maps directly to docker cpuset-cpus (eg. "0-2" or "0,1")
+	CPUSet string `mapstructure:"cpuset" json:"cpuset,omitempty" yaml:"cpuset,omitempty" toml:"cpuset,omitempty"`
+	// maps directly to docker cpu-quota (microseconds per cpu_period)
+	CPUQuota int64 `mapstructure:"cpu_quota" json:"cpu_quota,omitempty,omitzero" yaml:"cpu_quota,omitempty" toml:"cpu_quota,omitempty,omitzero"`
+	// maps directly to docker cpu-period (microseconds)
+	CPUPeriod int64 `mapstructure:"cpu_period" json:"cpu_period,omitempty,omitzero" yaml:"cpu_period,omitempty" toml:"cpu_period,omitempty,omitzero"`
+	// maps directly to docker pids-limit
+	PidsLimit int64 `mapstructure:"pids_limit" json:"pids_limit,omitempty,omitzero" yaml:"pids_limit,omitempty" toml:"pids_limit,omitempty,omitzero"`
+	// maps directly to docker ulimit (NAME=SOFT[:HARD], eg. "nofile=1024:4096")
+	Ulimits []string `mapstructure:"ulimits" json:"ulimits,omitempty" yaml:"ulimits,omitempty" toml:"ulimits,omitempty"`
+	// maps directly to docker blkio-weight (10 to 1000)
+	BlkioWeight int64 `mapstructure:"blkio_weight" json:"blkio_weight,omitempty,omitzero" yaml:"blkio_weight,omitempty" toml:"blkio_weight,omitempty,omitzero"`
+	// maps directly to docker tmpfs (PATH[:OPTIONS], eg. "/run:rw,size=64m")
+	Tmpfs []string `mapstructure:"tmpfs" json:"tmpfs,omitempty" yaml:"tmpfs,omitempty" toml:"tmpfs,omitempty"`
+	// maps directly to docker read-only (mount the root filesystem read only)
+	ReadOnly bool `mapstructure:"read_only" json:"read_only,omitempty" yaml:"read_only,omitempty" toml:"read_only,omitempty"`
+	// maps directly to docker security-opt
+	SecurityOpt []string `mapstructure:"security_opt" json:"security_opt,omitempty" yaml:"security_opt,omitempty" toml:"security_opt,omitempty"`
+	// maps directly to docker add-host (HOST:IP)
+	ExtraHosts []string `mapstructure:"extra_hosts" json:"extra_hosts,omitempty" yaml:"extra_hosts,omitempty" toml:"extra_hosts,omitempty"`
+	// maps directly to docker log-driver
+	LogDriver string `mapstructure:"log_driver" json:"log_driver,omitempty" yaml:"log_driver,omitempty" toml:"log_driver,omitempty"`
+	// maps directly to docker log-opt
+	LogOpt map[string]string `mapstructure:"log_opt" json:"log_opt,omitempty" yaml:"log_opt,omitempty" toml:"log_opt,omitempty"`
+	// maps directly to docker stop-signal
+	StopSignal string `mapstructure:"stop_signal" json:"stop_signal,omitempty" yaml:"stop_signal,omitempty" toml:"stop_signal,omitempty"`
 
 	// an env variable to set for when we are running `eris exec` so we can find the main container
-	ExecHost string `mapstructure:"exec_host" json:"memory,omitempty,omitzero" yaml:"memory,omitempty" toml:"memory,omitempty,omitzero"`
+	ExecHost string `mapstructure:"exec_host" json:"exec_host,omitempty" yaml:"exec_host,omitempty" toml:"exec_host,omitempty"`
 }
 
 func BlankService() *Service {
@@ -8,12 +8,18 @@ type ServiceDefinition struct {
 	// a chain which must be started prior to this service starting. can take a `$chain` string
 	// which would then be passed in via a command line flag
 	Chain string `json:"chain,omitempty" yaml:"chain,omitempty" toml:"chain,omitempty"`
+	// name of a service definition whose fields this one inherits (and overrides)
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty" toml:"extends,omitempty"`
+	// lists (eg. service.ports) which replace those inherited rather than add to them
+	Replace []string `json:"replace,omitempty" yaml:"replace,omitempty" toml:"replace,omitempty"`
 
 	Service      *Service      `json:"service" yaml:"service" toml:"service"`
-	Dependencies *Dependencies `json:"dependencies,omitempty", yaml:"dependencies,omitempty" toml:"dependencies,omitempty"`
+	Dependencies *Dependencies `json:"dependencies,omitempty" yaml:"dependencies,omitempty" toml:"dependencies,omitempty"`
 	Maintainer   *Maintainer   `json:"maintainer,omitempty" yaml:"maintainer,omitempty" toml:"maintainer,omitempty"`
 	Location     *Location     `json:"location,omitempty" yaml:"location,omitempty" toml:"location,omitempty"`
 	Machine      *Machine      `json:"machine,omitempty" yaml:"machine,omitempty" toml:"machine,omitempty"`
+	HealthCheck  *HealthCheck  `json:"healthcheck,omitempty" yaml:"healthcheck,omitempty" toml:"healthcheck,omitempty"`
+	Build        *Build        `json:"build,omitempty" yaml:"build,omitempty" toml:"build,omitempty"`
 	Srvs         []*Service
 	Operations   *Operation
 }
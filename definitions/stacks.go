@@ -0,0 +1,58 @@
+package definitions
+
+// Stack is a stack manifest: an environment of chains, services, data
+// and actions brought up with [eris stack up] and down with [eris stack
+// down].
+type Stack struct {
+	// name of the stack (defaults to the manifest's file name)
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// chains started first
+	Chains []*StackChain `json:"chains,omitempty" yaml:"chains,omitempty" toml:"chains,omitempty"`
+	// data imported into data containers once the chains are up
+	Data []*StackData `json:"data,omitempty" yaml:"data,omitempty" toml:"data,omitempty"`
+	// services started after the chains and the data
+	Services []*StackService `json:"services,omitempty" yaml:"services,omitempty" toml:"services,omitempty"`
+	// actions performed once everything is up
+	Actions []*StackAction `json:"actions,omitempty" yaml:"actions,omitempty" toml:"actions,omitempty"`
+}
+
+type StackChain struct {
+	// name of the chain
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// genesis.json file of a new chain (relative to the manifest)
+	Genesis string `json:"genesis,omitempty" yaml:"genesis,omitempty" toml:"genesis,omitempty"`
+	// <key>=<value> pairs to set in config.toml of a new chain
+	Options []string `json:"options,omitempty" yaml:"options,omitempty" toml:"options,omitempty"`
+}
+
+type StackData struct {
+	// name of the data container (a service's or a chain's name)
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// host directory to import (relative to the manifest)
+	Source string `json:"source" yaml:"source" toml:"source"`
+	// path in the data container
+	Destination string `json:"destination" yaml:"destination" toml:"destination"`
+}
+
+type StackService struct {
+	// name of the service
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// chain the service connects to (overrides `$chain`)
+	Chain string `json:"chain,omitempty" yaml:"chain,omitempty" toml:"chain,omitempty"`
+	// fields overriding those of the service definition; lists are
+	// added to those of the definition
+	Service *Service `json:"service,omitempty" yaml:"service,omitempty" toml:"service,omitempty"`
+}
+
+type StackAction struct {
+	// name of the action
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// <key>:<value> variables of the action
+	Vars []string `json:"vars,omitempty" yaml:"vars,omitempty" toml:"vars,omitempty"`
+	// chain the action runs against (for `$chain`)
+	Chain string `json:"chain,omitempty" yaml:"chain,omitempty" toml:"chain,omitempty"`
+}
+
+func BlankStack() *Stack {
+	return &Stack{}
+}
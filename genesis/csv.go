@@ -0,0 +1,132 @@
+package genesis
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// csvEntry is a row of the genesis csv files:
+//
+//	pubkey,amount,name,perms,setbit
+//
+// Only the public key is mandatory. The amount defaults to DefaultAmount
+// and the permissions to every permission.
+type csvEntry struct {
+	pubKey string
+	amount int64
+	name   string
+	perms  uint64
+	setBit uint64
+}
+
+// GenesisFromCSV returns the genesis of chainID from the csv files
+// [eris chains new --csv] takes. With only validatorsCSV (genesis.csv)
+// each row is both a validator and an account; with accountsCSV as well
+// (validators.csv and accounts.csv) the rows of validatorsCSV are the
+// validators and the rows of accountsCSV the accounts.
+func GenesisFromCSV(chainID, validatorsCSV, accountsCSV string) (*GenesisDoc, error) {
+	vals, err := readCSV(validatorsCSV)
+	if err != nil {
+		return nil, err
+	}
+
+	accs := vals
+	if accountsCSV != "" {
+		if accs, err = readCSV(accountsCSV); err != nil {
+			return nil, err
+		}
+	}
+
+	var accounts []*GenesisAccount
+	for _, e := range accs {
+		accounts = append(accounts, &GenesisAccount{
+			PubKey:      e.pubKey,
+			Amount:      e.amount,
+			Name:        e.name,
+			Permissions: Permissions(e.perms, e.setBit),
+		})
+	}
+
+	var validators []*GenesisValidator
+	for _, e := range vals {
+		validators = append(validators, &GenesisValidator{
+			PubKey: PubKey(e.pubKey),
+			Amount: e.amount,
+			Name:   e.name,
+		})
+	}
+
+	return MakeGenesis(chainID, accounts, validators)
+}
+
+func readCSV(file string) ([]*csvEntry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+
+	var entries []*csvEntry
+	for i, record := range records {
+		if len(record) == 0 || strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		entry, err := parseCSVEntry(record)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", file, i+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: no entries", file)
+	}
+	return entries, nil
+}
+
+func parseCSVEntry(record []string) (*csvEntry, error) {
+	field := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	entry := &csvEntry{
+		pubKey: field(0),
+		amount: DefaultAmount,
+		name:   field(2),
+		perms:  AllPermFlags,
+		setBit: AllPermFlags,
+	}
+
+	var err error
+	if s := field(1); s != "" {
+		if entry.amount, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("bad amount %q", s)
+		}
+	}
+	if s := field(3); s != "" {
+		if entry.perms, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("bad perms %q", s)
+		}
+		// only the given permissions are set unless setbit says otherwise
+		entry.setBit = entry.perms
+	}
+	if s := field(4); s != "" {
+		if entry.setBit, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("bad setbit %q", s)
+		}
+	}
+	return entry, nil
+}
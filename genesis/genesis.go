@@ -0,0 +1,264 @@
+// Package genesis makes the genesis.json of eris chains (tendermint and
+// erisdb) without running mintgen in a container. A genesis is made from
+// typed accounts and validators (MakeGenesis), from validator public keys
+// (GenesisFromPubKeys, like `mintgen known`), or from the genesis.csv or
+// validators.csv and accounts.csv files [eris chains new --csv] takes
+// (GenesisFromCSV).
+package genesis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/decred/dcrd/crypto/ripemd160"
+)
+
+// Permission flags of erisdb accounts.
+const (
+	PermRoot uint64 = 1 << iota
+	PermSend
+	PermCall
+	PermCreateContract
+	PermCreateAccount
+	PermBond
+	PermName
+	PermHasBase
+	PermSetBase
+	PermUnsetBase
+	PermSetGlobal
+	PermHasRole
+	PermAddRole
+	PermRmRole
+
+	// every permission
+	AllPermFlags = PermRmRole<<1 - 1
+
+	// the permissions of a regular account
+	DefaultPermFlags = PermSend | PermCall | PermCreateContract | PermCreateAccount | PermBond | PermName | PermHasBase | PermHasRole
+)
+
+// DefaultAmount is given to the accounts and validators of a genesis
+// which have no amount.
+const DefaultAmount int64 = 9999999999
+
+// pubKeyTypeEd25519 is the type byte of ed25519 public keys.
+const pubKeyTypeEd25519 = 1
+
+// GenesisDoc is the genesis.json of a chain.
+type GenesisDoc struct {
+	ChainID    string              `json:"chain_id"`
+	Params     *GenesisParams      `json:"params,omitempty"`
+	Accounts   []*GenesisAccount   `json:"accounts"`
+	Validators []*GenesisValidator `json:"validators"`
+}
+
+// GenesisParams holds the chain wide settings of a genesis.
+type GenesisParams struct {
+	GlobalPermissions *AccountPermissions `json:"global_permissions,omitempty"`
+}
+
+// GenesisAccount is an account of the genesis. Its address is derived
+// from PubKey if Address is empty.
+type GenesisAccount struct {
+	Address     string              `json:"address"`
+	PubKey      string              `json:"-"`
+	Amount      int64               `json:"amount"`
+	Name        string              `json:"name,omitempty"`
+	Permissions *AccountPermissions `json:"permissions,omitempty"`
+}
+
+// AccountPermissions are the permissions of an account: Perms are the
+// granted ones among those which are set in SetBit.
+type AccountPermissions struct {
+	Base  BasePermissions `json:"base"`
+	Roles []string        `json:"roles"`
+}
+
+type BasePermissions struct {
+	Perms  uint64 `json:"perms"`
+	SetBit uint64 `json:"set"`
+}
+
+// GenesisValidator is a validator of the genesis. If UnbondTo is empty,
+// the bond goes back to the validator's own address.
+type GenesisValidator struct {
+	PubKey   PubKey          `json:"pub_key"`
+	Amount   int64           `json:"amount"`
+	Name     string          `json:"name,omitempty"`
+	UnbondTo []*BasicAccount `json:"unbond_to"`
+}
+
+type BasicAccount struct {
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+}
+
+// PubKey is the hex of an ed25519 public key. It is written as
+// [1, "HEX"] in the genesis.
+type PubKey string
+
+func (p PubKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{pubKeyTypeEd25519, string(p)})
+}
+
+func (p *PubKey) UnmarshalJSON(data []byte) error {
+	var typed []json.RawMessage
+	if err := json.Unmarshal(data, &typed); err == nil {
+		if len(typed) != 2 {
+			return fmt.Errorf("unexpected public key %s", data)
+		}
+		data = typed[1]
+	}
+	var key string
+	if err := json.Unmarshal(data, &key); err != nil {
+		return fmt.Errorf("unexpected public key %s: %v", data, err)
+	}
+	*p = PubKey(key)
+	return nil
+}
+
+// Permissions returns the account permissions with perms granted out of
+// setBit.
+func Permissions(perms, setBit uint64) *AccountPermissions {
+	return &AccountPermissions{
+		Base:  BasePermissions{Perms: perms, SetBit: setBit},
+		Roles: []string{},
+	}
+}
+
+// MakeGenesis checks the accounts and the validators and returns their
+// genesis. Hex keys and addresses are upper cased, account addresses
+// are derived from their public keys where needed, and validators
+// without UnbondTo unbond to their own address.
+func MakeGenesis(chainID string, accounts []*GenesisAccount, validators []*GenesisValidator) (*GenesisDoc, error) {
+	if chainID == "" {
+		return nil, fmt.Errorf("A genesis needs a chain_id")
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("The genesis of %s needs at least one validator", chainID)
+	}
+
+	genDoc := &GenesisDoc{
+		ChainID:    chainID,
+		Accounts:   []*GenesisAccount{},
+		Validators: []*GenesisValidator{},
+	}
+
+	for i, acc := range accounts {
+		a := *acc
+		if a.Address == "" {
+			addr, err := AddressFromPubKey(a.PubKey)
+			if err != nil {
+				return nil, fmt.Errorf("account %d: %v", i, err)
+			}
+			a.Address = addr
+		}
+		addr, err := checkHex(a.Address, 20)
+		if err != nil {
+			return nil, fmt.Errorf("account %d: bad address: %v", i, err)
+		}
+		a.Address = addr
+		if a.Amount < 0 {
+			return nil, fmt.Errorf("account %d: negative amount %d", i, a.Amount)
+		}
+		genDoc.Accounts = append(genDoc.Accounts, &a)
+	}
+
+	for i, val := range validators {
+		v := *val
+		key, err := checkHex(string(v.PubKey), 32)
+		if err != nil {
+			return nil, fmt.Errorf("validator %d: bad pub_key: %v", i, err)
+		}
+		v.PubKey = PubKey(key)
+		if v.Amount <= 0 {
+			return nil, fmt.Errorf("validator %d: the amount must be positive (got %d)", i, v.Amount)
+		}
+
+		if len(v.UnbondTo) == 0 {
+			addr, _ := AddressFromPubKey(key)
+			v.UnbondTo = []*BasicAccount{{Address: addr, Amount: v.Amount}}
+		} else {
+			unbondTo := make([]*BasicAccount, len(v.UnbondTo))
+			for j, u := range v.UnbondTo {
+				addr, err := checkHex(u.Address, 20)
+				if err != nil {
+					return nil, fmt.Errorf("validator %d: bad unbond_to address: %v", i, err)
+				}
+				unbondTo[j] = &BasicAccount{Address: addr, Amount: u.Amount}
+			}
+			v.UnbondTo = unbondTo
+		}
+		genDoc.Validators = append(genDoc.Validators, &v)
+	}
+
+	return genDoc, nil
+}
+
+// GenesisFromPubKeys returns the genesis of chainID with one validator
+// and one account with every permission for each of the public keys,
+// which is what `mintgen known` makes.
+func GenesisFromPubKeys(chainID string, pubKeys ...string) (*GenesisDoc, error) {
+	var accounts []*GenesisAccount
+	var validators []*GenesisValidator
+	for _, key := range pubKeys {
+		accounts = append(accounts, &GenesisAccount{
+			PubKey:      key,
+			Amount:      DefaultAmount,
+			Permissions: Permissions(AllPermFlags, AllPermFlags),
+		})
+		validators = append(validators, &GenesisValidator{
+			PubKey: PubKey(key),
+			Amount: DefaultAmount,
+		})
+	}
+	return MakeGenesis(chainID, accounts, validators)
+}
+
+// JSON returns the indented genesis.json.
+func (genDoc *GenesisDoc) JSON() ([]byte, error) {
+	out, err := json.MarshalIndent(genDoc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// WriteGenesisFile writes the genesis to file.
+func WriteGenesisFile(genDoc *GenesisDoc, file string) error {
+	out, err := genDoc.JSON()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, out, 0644)
+}
+
+// AddressFromPubKey returns the address of the hex ed25519 public key:
+// the RIPEMD-160 of the key's binary encoding (type byte, length, key).
+func AddressFromPubKey(pubKey string) (string, error) {
+	key, err := checkHex(pubKey, 32)
+	if err != nil {
+		return "", fmt.Errorf("bad public key: %v", err)
+	}
+	raw, _ := hex.DecodeString(key)
+
+	hasher := ripemd160.New()
+	hasher.Write(append([]byte{pubKeyTypeEd25519, 0x01, byte(len(raw))}, raw...))
+	return strings.ToUpper(hex.EncodeToString(hasher.Sum(nil))), nil
+}
+
+// checkHex returns the upper cased s if it is the hex of size bytes.
+func checkHex(s string, size int) (string, error) {
+	s = strings.TrimSpace(s)
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("%q is not hex", s)
+	}
+	if len(raw) != size {
+		return "", fmt.Errorf("%q is %d bytes long, expected %d", s, len(raw), size)
+	}
+	return strings.ToUpper(s), nil
+}
@@ -0,0 +1,183 @@
+package genesis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// the key of the default chain (see initialize)
+const (
+	testPubKey  = "CB3688B7561D488A2A4834E1AEE9398BEF94844D8BDBBCA980C11E3654A45906"
+	testAddress = "37236DF251AB70022B1DA351F08A20FB52443E37"
+	testPubKey2 = "1C3D1A8A5D4A1EE4D6B8A3B6E2C7A1F9E0D3C2B1A0F9E8D7C6B5A4938271605F"
+)
+
+func TestAddressFromPubKey(t *testing.T) {
+	addr, err := AddressFromPubKey(testPubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr != testAddress {
+		t.Fatalf("wrong address. Got %s, expected %s", addr, testAddress)
+	}
+
+	for _, bad := range []string{"", "CB36", "not hex"} {
+		if _, err := AddressFromPubKey(bad); err == nil {
+			t.Fatalf("expected an error for the public key %q", bad)
+		}
+	}
+}
+
+func TestGenesisFromPubKeys(t *testing.T) {
+	genDoc, err := GenesisFromPubKeys("mychain", testPubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &GenesisDoc{
+		ChainID: "mychain",
+		Accounts: []*GenesisAccount{{
+			Address:     testAddress,
+			PubKey:      testPubKey,
+			Amount:      DefaultAmount,
+			Permissions: Permissions(AllPermFlags, AllPermFlags),
+		}},
+		Validators: []*GenesisValidator{{
+			PubKey:   testPubKey,
+			Amount:   DefaultAmount,
+			UnbondTo: []*BasicAccount{{Address: testAddress, Amount: DefaultAmount}},
+		}},
+	}
+	if !reflect.DeepEqual(genDoc, expected) {
+		t.Fatalf("wrong genesis. Got %s, expected %s", mustJSON(t, genDoc), mustJSON(t, expected))
+	}
+
+	// pub_key is written as [type, hex]
+	var raw struct {
+		Validators []struct {
+			PubKey []interface{} `json:"pub_key"`
+		} `json:"validators"`
+	}
+	if err := json.Unmarshal(mustJSON(t, genDoc), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(raw.Validators[0].PubKey, []interface{}{float64(1), testPubKey}) {
+		t.Fatalf("wrong pub_key encoding %v", raw.Validators[0].PubKey)
+	}
+
+	// the output is deterministic and reads back
+	again, _ := GenesisFromPubKeys("mychain", testPubKey)
+	if string(mustJSON(t, genDoc)) != string(mustJSON(t, again)) {
+		t.Fatalf("genesis not deterministic")
+	}
+	read := &GenesisDoc{}
+	if err := json.Unmarshal(mustJSON(t, genDoc), read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Validators[0].PubKey != testPubKey {
+		t.Fatalf("wrong pub_key read back %s", read.Validators[0].PubKey)
+	}
+}
+
+func TestMakeGenesis(t *testing.T) {
+	genDoc, err := MakeGenesis("mychain",
+		[]*GenesisAccount{{Address: "0000000000000000000000000000000000000001", Amount: 10}},
+		[]*GenesisValidator{{
+			PubKey:   PubKey("cb3688b7561d488a2a4834e1aee9398bef94844d8bdbbca980c11e3654a45906"),
+			Amount:   5,
+			UnbondTo: []*BasicAccount{{Address: "0000000000000000000000000000000000000001", Amount: 5}},
+		}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genDoc.Validators[0].PubKey != testPubKey {
+		t.Fatalf("pub_key not upper cased: %s", genDoc.Validators[0].PubKey)
+	}
+	if genDoc.Validators[0].UnbondTo[0].Address != "0000000000000000000000000000000000000001" {
+		t.Fatalf("wrong unbond_to %v", genDoc.Validators[0].UnbondTo[0])
+	}
+
+	bad := []struct {
+		chainID    string
+		accounts   []*GenesisAccount
+		validators []*GenesisValidator
+	}{
+		{"", nil, []*GenesisValidator{{PubKey: testPubKey, Amount: 1}}},
+		{"mychain", nil, nil},
+		{"mychain", nil, []*GenesisValidator{{PubKey: testPubKey}}},
+		{"mychain", nil, []*GenesisValidator{{PubKey: "CB36", Amount: 1}}},
+		{"mychain", []*GenesisAccount{{Address: "01", Amount: 1}}, []*GenesisValidator{{PubKey: testPubKey, Amount: 1}}},
+		{"mychain", []*GenesisAccount{{Address: testAddress, Amount: -1}}, []*GenesisValidator{{PubKey: testPubKey, Amount: 1}}},
+	}
+	for i, b := range bad {
+		if _, err := MakeGenesis(b.chainID, b.accounts, b.validators); err == nil {
+			t.Fatalf("expected an error for case %d", i)
+		}
+	}
+}
+
+func TestGenesisFromCSV(t *testing.T) {
+	tmp, err := ioutil.TempDir(os.TempDir(), "eris_genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	// the genesis.csv of the default chain
+	genesisCSV := writeCSV(t, tmp, "genesis.csv", testPubKey+",\n")
+	genDoc, err := GenesisFromCSV("mychain", genesisCSV, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromKeys, _ := GenesisFromPubKeys("mychain", testPubKey)
+	if string(mustJSON(t, genDoc)) != string(mustJSON(t, fromKeys)) {
+		t.Fatalf("wrong genesis from genesis.csv. Got %s, expected %s", mustJSON(t, genDoc), mustJSON(t, fromKeys))
+	}
+
+	validatorsCSV := writeCSV(t, tmp, "validators.csv", testPubKey+",5000,val0\n")
+	accountsCSV := writeCSV(t, tmp, "accounts.csv", testPubKey+",100,acc0,2302\n"+testPubKey2+",200,acc1,2,130\n\n")
+	genDoc, err = GenesisFromCSV("mychain", validatorsCSV, accountsCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(genDoc.Validators) != 1 || genDoc.Validators[0].Amount != 5000 || genDoc.Validators[0].Name != "val0" {
+		t.Fatalf("wrong validators %s", mustJSON(t, genDoc.Validators))
+	}
+	if len(genDoc.Accounts) != 2 {
+		t.Fatalf("wrong number of accounts %d", len(genDoc.Accounts))
+	}
+	if acc := genDoc.Accounts[0]; acc.Address != testAddress || acc.Amount != 100 || acc.Permissions.Base != (BasePermissions{DefaultPermFlags, DefaultPermFlags}) {
+		t.Fatalf("wrong account %s", mustJSON(t, acc))
+	}
+	if acc := genDoc.Accounts[1]; acc.Name != "acc1" || acc.Permissions.Base != (BasePermissions{PermSend, PermSend | PermHasBase}) {
+		t.Fatalf("wrong account %s", mustJSON(t, acc))
+	}
+
+	badCSV := writeCSV(t, tmp, "bad.csv", testPubKey+",lots\n")
+	if _, err := GenesisFromCSV("mychain", badCSV, ""); err == nil {
+		t.Fatalf("expected an error for a bad amount")
+	}
+	if _, err := GenesisFromCSV("mychain", filepath.Join(tmp, "missing.csv"), ""); err == nil {
+		t.Fatalf("expected an error for a missing csv")
+	}
+}
+
+func writeCSV(t *testing.T, dir, name, contents string) string {
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,298 @@
+package images
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/services"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// ManifestFile is the file of an image bundle listing what it was saved
+// for. The rest of the bundle is as docker save writes it, so docker load
+// can read it too.
+const ManifestFile = "eris-manifest.json"
+
+// BaseImages are the images of the containers eris runs next to the
+// services and chains: data containers, the containers volumes are
+// mounted into and those data is handled with.
+var BaseImages = []string{"quay.io/eris/data:latest", "quay.io/eris/base:latest"}
+
+// Manifest is the content of the ManifestFile of a bundle.
+type Manifest struct {
+	Services []string `json:"services,omitempty"`
+	Chains   []string `json:"chains,omitempty"`
+	Images   []string `json:"images"`
+}
+
+// Save writes the images the services do.ServicesSlice and the chains
+// do.ChainsSlice need, with those of the services and chains they depend
+// on and the BaseImages, to the bundle do.Path. Images not found locally
+// are pulled (and those of [build] sections built) first.
+func Save(do *definitions.Do) error {
+	if util.Images() == nil {
+		return fmt.Errorf("The marmots cannot save images with this backend.")
+	}
+
+	images, err := resolveImages(do.ServicesSlice, do.ChainsSlice)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		if err := perform.DockerPullImage(image); err != nil {
+			return err
+		}
+	}
+
+	manifest := &Manifest{
+		Services: do.ServicesSlice,
+		Chains:   do.ChainsSlice,
+		Images:   images,
+	}
+
+	// The bundle is written aside and moved in place once complete.
+	file, err := ioutil.TempFile(filepath.Dir(do.Path), filepath.Base(do.Path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	logger.Infof("Saving images =>\t\t%s\n", strings.Join(images, " "))
+	if err := writeBundle(file, manifest); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), do.Path); err != nil {
+		return err
+	}
+
+	logger.Printf("Saved %d images to %s.\n", len(images), do.Path)
+	do.Result = "success"
+	return nil
+}
+
+// Load imports the images of the bundle do.Path and checks those its
+// manifest lists are all there, so that none are pulled when the
+// services and chains it was saved for are started.
+func Load(do *definitions.Do) error {
+	if util.Images() == nil {
+		return fmt.Errorf("The marmots cannot load images with this backend.")
+	}
+
+	manifest, err := readManifest(do.Path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(do.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	logger.Infof("Loading images from =>\t\t%s\n", do.Path)
+	if err := util.Images().LoadImage(docker.LoadImageOptions{InputStream: file}); err != nil {
+		return err
+	}
+
+	// Bundles written by docker save have no manifest to check.
+	if manifest == nil {
+		logger.Printf("Loaded the images of %s.\n", do.Path)
+		do.Result = "success"
+		return nil
+	}
+
+	var missing []string
+	for _, image := range manifest.Images {
+		if _, err := util.Images().InspectImage(image); err != nil {
+			missing = append(missing, image)
+			continue
+		}
+		logger.Infof("Image loaded =>\t\t\t%s\n", image)
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("The images %s of the manifest are missing from %s.", strings.Join(missing, ", "), do.Path)
+	}
+
+	if len(manifest.Services) != 0 {
+		logger.Printf("The images of the services %s are loaded.\n", strings.Join(manifest.Services, ", "))
+	}
+	if len(manifest.Chains) != 0 {
+		logger.Printf("The images of the chains %s are loaded.\n", strings.Join(manifest.Chains, ", "))
+	}
+	do.Result = "success"
+	return nil
+}
+
+// resolveImages returns the sorted images of the services and chains
+// given, those they depend on (or are linked to with the chain field of
+// service definitions) and the BaseImages.
+func resolveImages(srvs, chains []string) ([]string, error) {
+	group, err := services.BuildGroup(srvs, chains, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, name := range chains {
+		known[name] = true
+	}
+	var linked []string
+	for _, srv := range group {
+		if name := linkedChain(srv.Chain); name != "" && !known[name] {
+			known[name] = true
+			linked = append(linked, name)
+		}
+	}
+	if len(linked) != 0 {
+		group, err = services.BuildGroup(srvs, append(append([]string{}, chains...), linked...), 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	var images []string
+	add := func(image string) {
+		image = imageWithTag(image)
+		if image != "" && !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	for _, srv := range group {
+		if err := perform.DockerBuild(srv.Service, srv.Build); err != nil {
+			return nil, err
+		}
+		logger.Debugf("Found image =>\t\t\t%s (%s)\n", srv.Service.Image, srv.Name)
+		add(srv.Service.Image)
+	}
+	for _, image := range BaseImages {
+		add(image)
+	}
+
+	sort.Strings(images)
+	return images, nil
+}
+
+// linkedChain returns the name of the chain of the chain field of a
+// service definition (the checked out one for $chain) or "" if there is
+// none.
+func linkedChain(chain string) string {
+	if chain == "" {
+		return ""
+	}
+	if strings.HasPrefix(chain, "$chain") {
+		head, err := util.GetHead()
+		if err != nil || head == "" {
+			logger.Infof("No chain checked out =>\t\t%s skipped\n", chain)
+			return ""
+		}
+		return head
+	}
+	name, _, _, _ := util.ParseDependency(chain)
+	return name
+}
+
+// imageWithTag gives the image the latest tag if it has neither a tag
+// nor a digest, as Docker does.
+func imageWithTag(image string) string {
+	if image == "" || strings.Contains(image, "@") {
+		return image
+	}
+	if strings.LastIndex(image, ":") > strings.LastIndex(image, "/") {
+		return image
+	}
+	return image + ":latest"
+}
+
+// writeBundle writes the images of the manifest as the daemon exports
+// them to w, with the manifest added as ManifestFile.
+func writeBundle(w io.Writer, manifest *Manifest) error {
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	go func() {
+		writer.CloseWithError(util.Images().ExportImages(docker.ExportImagesOptions{
+			Names:        manifest.Images,
+			OutputStream: writer,
+		}))
+	}()
+
+	in := tar.NewReader(reader)
+	out := tar.NewWriter(w)
+	for {
+		header, err := in.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := out.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			return err
+		}
+	}
+	// Errors of the export past the end of the tar.
+	if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+		return err
+	}
+
+	body, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := out.WriteHeader(&tar.Header{Name: ManifestFile, Mode: 0644, Size: int64(len(body))}); err != nil {
+		return err
+	}
+	if _, err := out.Write(body); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// readManifest returns the manifest of the bundle file or nil if it has
+// none.
+func readManifest(file string) (*Manifest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	files := tar.NewReader(f)
+	for {
+		header, err := files.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("The marmots could not read the images in %s: %v", file, err)
+		}
+		if header.Name != ManifestFile {
+			continue
+		}
+
+		manifest := &Manifest{}
+		if err := json.NewDecoder(files).Decode(manifest); err != nil {
+			return nil, fmt.Errorf("The marmots could not read the manifest of %s: %v", file, err)
+		}
+		return manifest, nil
+	}
+}
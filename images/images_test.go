@@ -0,0 +1,143 @@
+package images
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+	"github.com/eris-ltd/eris-cli/version"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	log.SetLoggers(0, os.Stdout, os.Stderr)
+
+	var err error
+	erisDir, err = ioutil.TempDir("", "eris_images")
+	if err != nil {
+		panic(err)
+	}
+	config.ChangeErisDir(erisDir)
+	config.GlobalConfig, err = config.SetGlobalObject(os.Stdout, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+	for _, dir := range []string{ServicesPath, ChainsPath} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			panic(err)
+		}
+	}
+	for file, body := range map[string]string{
+		filepath.Join(ServicesPath, "keys.toml"):      "name = \"keys\"\n\n[service]\nimage = \"quay.io/eris/keys:0.12\"\n",
+		filepath.Join(ServicesPath, "ipfs.toml"):      "name = \"ipfs\"\nchain = \"simplechain\"\n\n[service]\nimage = \"quay.io/eris/ipfs\"\ndata_container = true\n\n[dependencies]\nservices = [\"keys\"]\n",
+		filepath.Join(ChainsPath, "default.toml"):     "[service]\nimage = \"quay.io/eris/erisdb\"\n",
+		filepath.Join(ChainsPath, "simplechain.toml"): "name = \"simplechain\"\n",
+	} {
+		if err := ioutil.WriteFile(file, []byte(body), 0644); err != nil {
+			panic(err)
+		}
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	bundle := filepath.Join(erisDir, "lab.tar")
+	do := definitions.NowDo()
+	do.ServicesSlice = []string{"ipfs"}
+	do.Path = bundle
+	if err := Save(do); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"quay.io/eris/base:latest",
+		"quay.io/eris/data:latest",
+		"quay.io/eris/erisdb:" + version.VERSION,
+		"quay.io/eris/ipfs:latest",
+		"quay.io/eris/keys:0.12",
+	}
+	manifest, err := readManifest(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest == nil || !reflect.DeepEqual(manifest.Images, want) || !reflect.DeepEqual(manifest.Services, []string{"ipfs"}) {
+		t.Fatalf("expected the images of ipfs, its dependencies and chain, got %+v", manifest)
+	}
+	if files := bundleFiles(t, bundle); !files["manifest.json"] || !files[ManifestFile] {
+		t.Fatalf("expected the docker and eris manifests in the bundle, got %v", files)
+	}
+
+	// On a machine with none of the images.
+	fake = util.NewFakeBackend()
+	util.Backend = fake
+	do = definitions.NowDo()
+	do.Path = bundle
+	if err := Load(do); err != nil {
+		t.Fatal(err)
+	}
+	for _, image := range want {
+		if _, err := fake.InspectImage(image); err != nil {
+			t.Fatalf("expected the image %s loaded, got %v", image, err)
+		}
+	}
+
+	if err := ioutil.WriteFile(bundle, []byte("not a tar"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Load(do); err == nil {
+		t.Fatalf("expected an error loading a file which is no bundle")
+	}
+}
+
+func TestImageWithTag(t *testing.T) {
+	for image, want := range map[string]string{
+		"ubuntu":                          "ubuntu:latest",
+		"quay.io/eris/ipfs:0.12":          "quay.io/eris/ipfs:0.12",
+		"localhost:5000/eris/ipfs":        "localhost:5000/eris/ipfs:latest",
+		"quay.io/eris/ipfs@sha256:abcdef": "quay.io/eris/ipfs@sha256:abcdef",
+	} {
+		if got := imageWithTag(image); got != want {
+			t.Fatalf("imageWithTag(%q): expected %q, got %q", image, want, got)
+		}
+	}
+}
+
+func bundleFiles(t *testing.T, file string) map[string]bool {
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	files := make(map[string]bool)
+	r := tar.NewReader(f)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = true
+	}
+}
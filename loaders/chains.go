@@ -49,6 +49,9 @@ func LoadChainDefinition(chainName string, newCont bool, cNum ...int) (*definiti
 	if err != nil {
 		return nil, err
 	}
+	if err = resolveExtends(chainConf, ChainsPath, chainName, "chain"); err != nil {
+		return nil, err
+	}
 
 	// marshal chain and always reset the operational requirements
 	// this will make sure to sync with docker so that if changes
@@ -56,6 +59,7 @@ func LoadChainDefinition(chainName string, newCont bool, cNum ...int) (*definiti
 	if err = MarshalChainDefinition(chainConf, chain); err != nil {
 		return nil, err
 	}
+	interpolateDefinition(chain)
 
 	// Docker 1.6 (which eris doesn't support) had different linking mechanism.
 	if ver, _ := util.DockerClientVersion(); ver >= version.DVER_MIN {
@@ -96,6 +100,7 @@ func ServiceDefFromChain(chain *definitions.Chain, cmd string) *definitions.Serv
 	chain.Service.Image = "quay.io/eris/erisdb:" + vers
 	chain.Service.AutoData = true // default. they can turn it off. it's like BarBri
 	chain.Service.Command = cmd
+	joinNetwork(chain.Service, util.ChainNetwork(chain.Name))
 
 	srv := &definitions.ServiceDefinition{
 		Name:         chain.Name,
@@ -106,6 +111,7 @@ func ServiceDefFromChain(chain *definitions.Chain, cmd string) *definitions.Serv
 		Maintainer:   chain.Maintainer,
 		Location:     chain.Location,
 		Machine:      chain.Machine,
+		HealthCheck:  chain.HealthCheck,
 	}
 	ServiceFinalizeLoad(srv) // these are mostly operational considerations that we want to ensure are met
 
@@ -139,6 +145,8 @@ func MockChainDefinition(chainName, chainID string, newCont bool, cNum ...int) *
 
 // marshal from viper to definitions struct
 func MarshalChainDefinition(chainConf *viper.Viper, chain *definitions.Chain) error {
+	volume := readDataVolume(chainConf)
+
 	chnTemp := definitions.BlankChain()
 	err := chainConf.Marshal(chnTemp)
 	if err != nil {
@@ -147,6 +155,9 @@ func MarshalChainDefinition(chainConf *viper.Viper, chain *definitions.Chain) er
 
 	util.Merge(chain.Service, chnTemp.Service)
 	chain.ChainID = chnTemp.ChainID
+	if chnTemp.HealthCheck != nil {
+		chain.HealthCheck = chnTemp.HealthCheck
+	}
 
 	// toml bools don't really marshal well
 	// data_container can be in the chain or
@@ -158,6 +169,7 @@ func MarshalChainDefinition(chainConf *viper.Viper, chain *definitions.Chain) er
 			chain.Service.AutoData = true
 		}
 	}
+	chain.Service.DataVolume = volume
 
 	return nil
 }
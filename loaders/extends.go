@@ -0,0 +1,169 @@
+package loaders
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/config"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/viper"
+)
+
+// notInherited are the fields of a definition which are never taken
+// from the definition it extends.
+var notInherited = map[string]bool{
+	"name":         true,
+	"chain_id":     true,
+	"extends":      true,
+	"replace":      true,
+	"service.name": true,
+}
+
+// resolveExtends merges the definitions which the definition conf (of
+// the service or chain name, typ) extends into conf. The definitions
+// extended are read from dir.
+func resolveExtends(conf *viper.Viper, dir, name, typ string) error {
+	settings, err := extendSettings(normalizeSettings(conf), dir, typ, []string{name})
+	if err != nil {
+		return err
+	}
+	for key, value := range settings {
+		conf.Set(key, value)
+	}
+	return nil
+}
+
+// ResolvedSettings returns the settings of the definition of the
+// service or chain name (typ) once the definitions it extends are
+// merged in, without the extends and replace fields.
+func ResolvedSettings(dir, name, typ string) (map[string]interface{}, error) {
+	conf, err := config.LoadViperConfig(dir, name, typ)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := extendSettings(normalizeSettings(conf), dir, typ, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	delete(settings, "extends")
+	delete(settings, "replace")
+	return settings, nil
+}
+
+// extendSettings merges the definitions settings extends (through any
+// number of levels) into settings. seen are the names of the
+// definitions on the way, to catch cycles.
+func extendSettings(settings map[string]interface{}, dir, typ string, seen []string) (map[string]interface{}, error) {
+	parent, _ := settings["extends"].(string)
+	if parent == "" {
+		return settings, nil
+	}
+	for _, name := range seen {
+		if name == parent {
+			return nil, fmt.Errorf("The marmots found a cycle of %ss extending each other: %s -> %s.", typ, strings.Join(seen, " -> "), parent)
+		}
+	}
+
+	parentConf, err := config.LoadViperConfig(dir, parent, typ)
+	if err != nil {
+		return nil, fmt.Errorf("The %s %s extends the unknown %s %s.", typ, seen[len(seen)-1], typ, parent)
+	}
+	logger.Debugf("Extending %s =>\t\t%s with %s\n", typ, seen[len(seen)-1], parent)
+	base, err := extendSettings(normalizeSettings(parentConf), dir, typ, append(seen, parent))
+	if err != nil {
+		return nil, err
+	}
+
+	replace := make(map[string]bool)
+	for _, field := range stringList(settings["replace"]) {
+		replace[strings.ToLower(field)] = true
+	}
+	return mergeSettings("", base, settings, replace), nil
+}
+
+// mergeSettings deep merges the settings of a definition over those of
+// the definition it extends (base). Tables are merged field by field and
+// other values replace those of base, except for lists: the elements of
+// a list are added to those of base (leaving out duplicates), unless the
+// list is named in replace. The environment is merged by variable name.
+func mergeSettings(path string, base, over map[string]interface{}, replace map[string]bool) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for key, value := range base {
+		if !notInherited[join(path, key)] {
+			merged[key] = value
+		}
+	}
+
+	for key, value := range over {
+		field := join(path, key)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if b, ok := merged[key].(map[string]interface{}); ok {
+				merged[key] = mergeSettings(field, b, v, replace)
+				continue
+			}
+		case []interface{}:
+			b, ok := merged[key].([]interface{})
+			switch {
+			case !ok || replace[field]:
+			case field == "service.environment":
+				merged[key] = mergeEnvironment(b, v)
+				continue
+			default:
+				merged[key] = appendMissing(b, v)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+func appendMissing(base, over []interface{}) []interface{} {
+	merged := append([]interface{}{}, base...)
+	for _, elem := range over {
+		found := false
+		for _, b := range base {
+			if fmt.Sprint(b) == fmt.Sprint(elem) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, elem)
+		}
+	}
+	return merged
+}
+
+// mergeEnvironment merges KEY=value variables: those of over replace
+// those of base with the same KEY.
+func mergeEnvironment(base, over []interface{}) []interface{} {
+	merged := append([]interface{}{}, base...)
+	for _, elem := range over {
+		key := strings.SplitN(fmt.Sprint(elem), "=", 2)[0]
+		found := false
+		for i, b := range merged {
+			if strings.SplitN(fmt.Sprint(b), "=", 2)[0] == key {
+				merged[i], found = elem, true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, elem)
+		}
+	}
+	return merged
+}
+
+func normalizeSettings(conf *viper.Viper) map[string]interface{} {
+	settings, _ := normalize(conf.AllSettings()).(map[string]interface{})
+	return settings
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,131 @@
+package loaders
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+	"github.com/eris-ltd/eris-cli/definitions"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+)
+
+func TestExtends(t *testing.T) {
+	for name, body := range map[string]string{
+		"base.toml": `
+name = "base"
+
+[service]
+name = "base"
+image = "quay.io/eris/base:0.12"
+data_container = true
+ports = ["4001:4001"]
+volumes = ["/data"]
+environment = ["LEVEL=info", "MODE=base"]
+`,
+		"middle.yaml": `
+extends: base
+maintainer:
+  name: marmot
+service:
+  environment:
+    - MODE=middle
+  volumes:
+    - /data
+    - /logs
+`,
+		"leaf.toml": `
+name = "leaf"
+extends = "middle"
+replace = ["service.ports"]
+
+[service]
+ports = ["4002:4001"]
+environment = ["EXTRA=1"]
+`,
+	} {
+		file := writeDefinition(ServicesPath, name, body)
+		defer os.Remove(file)
+	}
+
+	settings, err := ResolvedSettings(ServicesPath, "leaf", "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := settings["extends"]; ok {
+		t.Fatalf("expected the resolved settings to leave out extends")
+	}
+	service := settings["service"].(map[string]interface{})
+	for field, expected := range map[string]string{
+		"image":       "quay.io/eris/base:0.12",
+		"ports":       "[4002:4001]",
+		"volumes":     "[/data /logs]",
+		"environment": "[LEVEL=info MODE=middle EXTRA=1]",
+	} {
+		if got := strings.TrimSpace(toString(service[field])); got != expected {
+			t.Fatalf("expected service.%s %s, got %s", field, expected, got)
+		}
+	}
+	if _, ok := service["name"]; ok {
+		t.Fatalf("expected the service name not to be inherited, got %v", service["name"])
+	}
+
+	// As the loaders marshal it.
+	conf, err := config.LoadViperConfig(ServicesPath, "leaf", "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resolveExtends(conf, ServicesPath, "leaf", "service"); err != nil {
+		t.Fatal(err)
+	}
+	srv := definitions.BlankServiceDefinition()
+	if err := MarshalServiceDefinition(conf, srv); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Name != "leaf" || srv.Extends != "middle" || !srv.Service.AutoData || srv.Maintainer.Name != "marmot" {
+		t.Fatalf("expected the leaf's fields merged with those it extends, got %+v", srv)
+	}
+	if !reflect.DeepEqual(srv.Service.Volumes, []string{"/data", "/logs"}) {
+		t.Fatalf("expected the volumes to add up, got %v", srv.Service.Volumes)
+	}
+}
+
+func TestExtendsErrors(t *testing.T) {
+	for name, body := range map[string]string{
+		"loopa.toml":  "extends = \"loopb\"\n[service]\nimage = \"a:1\"\n",
+		"loopb.toml":  "extends = \"loopc\"\n",
+		"loopc.toml":  "extends = \"loopa\"\n",
+		"orphan.toml": "extends = \"nobody\"\n",
+	} {
+		file := writeDefinition(ServicesPath, name, body)
+		defer os.Remove(file)
+	}
+
+	_, err := ResolvedSettings(ServicesPath, "loopa", "service")
+	if err == nil || !strings.Contains(err.Error(), "loopa -> loopb -> loopc -> loopa") {
+		t.Fatalf("expected a cycle error, got %v", err)
+	}
+	_, err = ResolvedSettings(ServicesPath, "orphan", "service")
+	if err == nil || !strings.Contains(err.Error(), "unknown service nobody") {
+		t.Fatalf("expected an unknown service error, got %v", err)
+	}
+
+	expectProblems(t, problems(t, "services", "child.toml", "extends = \"keys\"\n\n[service]\nports = [\"1:1\"]\n"))
+	expectProblems(t, problems(t, "services", "lost.toml", "extends = \"nobody\"\n"),
+		`1: extends: unknown service "nobody"`)
+}
+
+func toString(value interface{}) string {
+	l, ok := value.([]interface{})
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	var strs []string
+	for _, elem := range l {
+		strs = append(strs, elem.(string))
+	}
+	return "[" + strings.Join(strs, " ") + "]"
+}
@@ -0,0 +1,169 @@
+package loaders
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/config"
+	"github.com/eris-ltd/eris-cli/util"
+)
+
+// profile holds the values of the profile selected with [--profile],
+// by their lower case names.
+var profile = make(map[string]string)
+
+// LoadProfile reads the values of the profile name from its file in
+// ~/.eris/profiles (eg. staging.toml) for the interpolation of service
+// and chain definitions. An empty name clears the profile.
+func LoadProfile(name string) error {
+	profile = make(map[string]string)
+	if name == "" {
+		return nil
+	}
+
+	conf, err := config.LoadViperConfig(util.ProfilesPath(), name, "profile")
+	if err != nil {
+		return fmt.Errorf("I cannot find the %s profile. Please add a %s.toml file to %s.", name, name, util.ProfilesPath())
+	}
+	addProfileValues("", conf.AllSettings())
+	logger.Debugf("Using profile =>\t\t%s (%d values)\n", name, len(profile))
+	return nil
+}
+
+// addProfileValues adds the values of a profile's table; those of its
+// tables are named table.key.
+func addProfileValues(prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		key = strings.ToLower(prefix + key)
+		switch table := value.(type) {
+		case map[string]interface{}:
+			addProfileValues(key+".", table)
+		case map[interface{}]interface{}:
+			addProfileValues(key+".", normalize(table).(map[string]interface{}))
+		default:
+			profile[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// lookupVariable returns the value of the variable name from the host
+// environment or, failing that, from the profile.
+func lookupVariable(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	value, ok := profile[strings.ToLower(name)]
+	return value, ok
+}
+
+// interpolate replaces the ${VAR} and ${VAR:-default} variables of s
+// with their values. The default is used when the variable is unset or
+// empty; unset calls back with the names of the variables which are
+// unset and have no default (they are replaced with nothing). $${ is
+// kept as a literal ${ and anything else with a $ (eg. $chain) is left
+// as it is.
+func interpolate(s string, unset func(name string)) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' {
+			out = append(out, s[i])
+			continue
+		}
+		if strings.HasPrefix(s[i:], "$${") {
+			out = append(out, "${"...)
+			i += 2
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			out = append(out, s[i])
+			continue
+		}
+		end := strings.Index(s[i:], "}")
+		if end < 0 {
+			out = append(out, s[i:]...)
+			break
+		}
+		expr := s[i+2 : i+end]
+		name, def, hasDefault := expr, "", false
+		if n := strings.Index(expr, ":-"); n >= 0 {
+			name, def, hasDefault = expr[:n], expr[n+2:], true
+		}
+		if !isVariableName(name) {
+			out = append(out, s[i:i+end+1]...)
+			i += end
+			continue
+		}
+
+		value, ok := lookupVariable(name)
+		switch {
+		case hasDefault && value == "":
+			value = def
+		case !ok && unset != nil:
+			unset(name)
+		}
+		out = append(out, value...)
+		i += end
+	}
+	return string(out)
+}
+
+func isVariableName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// interpolateDefinition interpolates the string fields of a service or
+// chain definition (those read from the definition file, so not the
+// Operations).
+func interpolateDefinition(def interface{}) {
+	interpolateValue(reflect.ValueOf(def), func(name string) {
+		logger.Infof("Variable not set =>\t\t%s (left empty)\n", name)
+	})
+}
+
+func interpolateValue(v reflect.Value, unset func(string)) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			interpolateValue(v.Elem(), unset)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.PkgPath == "" && f.Tag.Get("toml") != "" {
+				interpolateValue(v.Field(i), unset)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			interpolateValue(v.Index(i), unset)
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			value := v.MapIndex(key).String()
+			v.SetMapIndex(key, reflect.ValueOf(interpolate(value, unset)).Convert(v.Type().Elem()))
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(interpolate(v.String(), unset))
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package loaders
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+)
+
+func TestInterpolate(t *testing.T) {
+	os.Setenv("ERIS_TEST_HOST", "host.example")
+	os.Setenv("ERIS_TEST_EMPTY", "")
+	defer os.Unsetenv("ERIS_TEST_HOST")
+	defer os.Unsetenv("ERIS_TEST_EMPTY")
+
+	for _, c := range []struct{ in, out, unset string }{
+		{"plain", "plain", ""},
+		{"${ERIS_TEST_HOST}:4001", "host.example:4001", ""},
+		{"${ERIS_TEST_HOST:-other}", "host.example", ""},
+		{"${ERIS_TEST_EMPTY:-fallback}", "fallback", ""},
+		{"${ERIS_TEST_NOPE:-}", "", ""},
+		{"a${ERIS_TEST_NOPE}b", "ab", "ERIS_TEST_NOPE"},
+		{"$chain:/$eris/${ERIS_TEST_HOST}", "$chain:/$eris/host.example", ""},
+		{"$${ERIS_TEST_HOST}", "${ERIS_TEST_HOST}", ""},
+		{"${1bad} ${} ${ERIS_TEST_HOST", "${1bad} ${} ${ERIS_TEST_HOST", ""},
+	} {
+		var unset []string
+		out := interpolate(c.in, func(name string) { unset = append(unset, name) })
+		if out != c.out {
+			t.Fatalf("interpolate %q: expected %q, got %q", c.in, c.out, out)
+		}
+		if strings.Join(unset, ",") != c.unset {
+			t.Fatalf("interpolate %q: expected unset %q, got %v", c.in, c.unset, unset)
+		}
+	}
+}
+
+func TestProfile(t *testing.T) {
+	if err := os.MkdirAll(util.ProfilesPath(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeDefinition(util.ProfilesPath(), "staging.toml", "ipfs_port = 5002\nIMAGE_TAG = \"0.12\"\n\n[chain]\nname = \"stagechain\"\n")
+	defer os.RemoveAll(util.ProfilesPath())
+	defer LoadProfile("")
+
+	if err := LoadProfile("nope"); err == nil {
+		t.Fatalf("expected an error for a missing profile")
+	}
+	if err := LoadProfile("staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("IPFS_PORT", "6002")
+	defer os.Unsetenv("IPFS_PORT")
+
+	srv := definitions.BlankServiceDefinition()
+	srv.Chain = "${chain.name}"
+	srv.Service.Image = "quay.io/eris/ipfs:${IMAGE_TAG}"
+	srv.Service.Ports = []string{"${IPFS_PORT}:5001", "${OTHER_PORT:-8080}:8080"}
+	srv.Dependencies = &definitions.Dependencies{Services: []string{"${KEYS:-keys}"}}
+	srv.Operations.SrvContainerName = "${IMAGE_TAG}"
+	interpolateDefinition(srv)
+
+	if srv.Chain != "stagechain" || srv.Service.Image != "quay.io/eris/ipfs:0.12" {
+		t.Fatalf("expected the profile's values, got %q and %q", srv.Chain, srv.Service.Image)
+	}
+	if srv.Service.Ports[0] != "6002:5001" || srv.Service.Ports[1] != "8080:8080" {
+		t.Fatalf("expected the environment to override the profile, got %v", srv.Service.Ports)
+	}
+	if srv.Dependencies.Services[0] != "keys" {
+		t.Fatalf("expected the default dependency, got %v", srv.Dependencies.Services)
+	}
+	if srv.Operations.SrvContainerName != "${IMAGE_TAG}" {
+		t.Fatalf("expected the operations to be left alone, got %q", srv.Operations.SrvContainerName)
+	}
+}
+
+func TestValidateVariables(t *testing.T) {
+	os.Setenv("ERIS_TEST_PORT", "4001")
+	defer os.Unsetenv("ERIS_TEST_PORT")
+
+	expectProblems(t, problems(t, "services", "vars.toml", `
+[service]
+image = "quay.io/eris/keys:${ERIS_TEST_TAG:-0.12}"
+ports = ["${ERIS_TEST_PORT}:4001", "${ERIS_TEST_MISSING}:1"]
+`),
+		`4: warning: service.ports: variable ERIS_TEST_MISSING is not set and has no default`,
+		`4: service.ports: bad port ":1": "" is not a port`,
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	// "os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/eris-ltd/eris-cli/config"
@@ -16,6 +17,10 @@ import (
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/viper"
 )
 
+// dataVolume is the data_container value of the definitions which keep
+// their data in a named volume.
+const dataVolume = "volume"
+
 func LoadServiceDefinition(servName string, newCont bool, cNum ...int) (*definitions.ServiceDefinition, error) {
 	if len(cNum) == 0 {
 		cNum = append(cNum, 0)
@@ -36,18 +41,23 @@ func LoadServiceDefinition(servName string, newCont bool, cNum ...int) (*definit
 	if err != nil {
 		return nil, err
 	}
+	if err = resolveExtends(serviceConf, ServicesPath, servName, "service"); err != nil {
+		return nil, err
+	}
 
 	if err = MarshalServiceDefinition(serviceConf, srv); err != nil {
 		return nil, err
 	}
+	interpolateDefinition(srv)
 
 	if srv.Service == nil {
 		return nil, fmt.Errorf("No service given.")
 	}
 
-	if err = checkImage(srv.Service); err != nil {
+	if err = checkImage(srv.Service, srv.Build); err != nil {
 		return nil, err
 	}
+	buildContext(srv.Build)
 
 	// Docker 1.6 (which eris doesn't support) had different linking mechanism.
 	if ver, _ := util.DockerClientVersion(); ver >= version.DVER_MIN {
@@ -78,6 +88,8 @@ func MockServiceDefinition(servName string, newCont bool, cNum ...int) *definiti
 }
 
 func MarshalServiceDefinition(serviceConf *viper.Viper, srv *definitions.ServiceDefinition) error {
+	volume := readDataVolume(serviceConf)
+
 	err := serviceConf.Marshal(srv)
 	if err != nil {
 		// Vipers error messages are atrocious.
@@ -88,6 +100,7 @@ func MarshalServiceDefinition(serviceConf *viper.Viper, srv *definitions.Service
 	if serviceConf.GetBool("service.data_container") {
 		srv.Service.AutoData = true
 	}
+	srv.Service.DataVolume = volume
 
 	return nil
 }
@@ -157,6 +170,38 @@ func connectToAService(srv *definitions.Service, ops *definitions.Operation, typ
 		newVol := containerName + ":rw"
 		srv.VolumesFrom = append(srv.VolumesFrom, newVol)
 	}
+
+	// The chain is known as "chain" on its own network.
+	if typ == definitions.TypeChain {
+		joinNetwork(srv, util.ChainNetwork(name))
+	}
+}
+
+// readDataVolume returns true if data_container is "volume" (in the
+// [service] table or, as chains have it, at the top level of conf),
+// which it turns into true for the marshalling.
+func readDataVolume(conf *viper.Viper) bool {
+	volume := false
+	if conf.GetString("data_container") == dataVolume {
+		conf.Set("data_container", true)
+		volume = true
+	}
+	if service, ok := normalize(conf.Get("service")).(map[string]interface{}); ok && service["data_container"] == dataVolume {
+		service["data_container"] = true
+		conf.Set("service", service)
+		volume = true
+	}
+	return volume
+}
+
+// joinNetwork adds the network to those of the service, once.
+func joinNetwork(srv *definitions.Service, network string) {
+	for _, n := range srv.Networks {
+		if n == network {
+			return
+		}
+	}
+	srv.Networks = append(srv.Networks, network)
 }
 
 func loadServiceDefinition(servName string) (*viper.Viper, error) {
@@ -164,14 +209,23 @@ func loadServiceDefinition(servName string) (*viper.Viper, error) {
 }
 
 // Services must be given an image. Flame out if they do not.
-func checkImage(srv *definitions.Service) error {
-	if srv.Image == "" {
-		return fmt.Errorf("An \"image\" field is required in the service definition file.")
+func checkImage(srv *definitions.Service, build *definitions.Build) error {
+	if srv.Image == "" && build.IsEmpty() {
+		return fmt.Errorf("An \"image\" field or a [build] section is required in the service definition file.")
 	}
 
 	return nil
 }
 
+// buildContext makes a relative build context relative to the directory
+// of the service definition files.
+func buildContext(build *definitions.Build) {
+	if build.IsEmpty() || filepath.IsAbs(build.Context) {
+		return
+	}
+	build.Context = filepath.Join(ServicesPath, build.Context)
+}
+
 func addDependencyVolumesAndLinks(deps *definitions.Dependencies, srv *definitions.Service, ops *definitions.Operation) {
 	if deps != nil {
 		for i, dep := range deps.Services {
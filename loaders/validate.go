@@ -0,0 +1,741 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/BurntSushi/toml"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/gopkg.in/yaml.v2"
+)
+
+// A Problem is a mistake found in a definition file. Warnings are
+// reported but do not keep the definition from being used.
+type Problem struct {
+	File    string
+	Line    int
+	Field   string
+	Message string
+	Warning bool
+}
+
+func (p *Problem) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(p.File)
+	if p.Line > 0 {
+		fmt.Fprintf(&buf, ":%d", p.Line)
+	}
+	buf.WriteString(": ")
+	if p.Warning {
+		buf.WriteString("warning: ")
+	}
+	if p.Field != "" {
+		buf.WriteString(p.Field + ": ")
+	}
+	buf.WriteString(p.Message)
+	return buf.String()
+}
+
+// ValidationError lists the problems which keep a definition file from
+// being used.
+type ValidationError struct {
+	Problems []*Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := []string{"The marmots found problems in the definition file:"}
+	for _, p := range e.Problems {
+		lines = append(lines, "\t"+p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// The definitions marshalled from service, chain and action definition
+// files by kind (as GetFileByNameAndType takes it), with the top level
+// keys the loaders read besides the fields of the definition.
+var definitionKinds = map[string]struct {
+	typ    reflect.Type
+	extras []string
+}{
+	"services": {reflect.TypeOf(definitions.ServiceDefinition{}), nil},
+	"chains":   {reflect.TypeOf(definitions.Chain{}), []string{"data_container"}},
+	"actions":  {reflect.TypeOf(definitions.Action{}), nil},
+}
+
+// ValidateDefinition checks the definition file of the service, chain
+// or action (do.Type being services, chains or actions) do.Name and
+// displays the problems found as file:line: message. An error is
+// returned if any of them is not a warning.
+//
+//	do.Type - services, chains or actions
+//	do.Name - name of the definition
+func ValidateDefinition(do *definitions.Do) error {
+	if _, ok := definitionKinds[do.Type]; !ok {
+		return fmt.Errorf("I cannot validate %q definitions. Please give services, chains or actions.", do.Type)
+	}
+	file := util.GetFileByNameAndType(do.Type, do.Name)
+	if file == "" {
+		return fmt.Errorf("I cannot find the %s definition file for %s.", strings.TrimSuffix(do.Type, "s"), do.Name)
+	}
+
+	problems, err := ValidateFile(file, do.Type)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		logger.Println(p.String())
+	}
+	if errs := problemErrors(problems); len(errs) > 0 {
+		return fmt.Errorf("The marmots found %d problem(s) in %s.", len(errs), file)
+	}
+	do.Result = "valid"
+	if !do.Quiet {
+		logger.Printf("%s is valid.\n", file)
+	}
+	return nil
+}
+
+// CheckDefinition is the check done before starting a service or a
+// chain (kind being services or chains): it returns a ValidationError
+// if the definition file of name has problems other than warnings.
+// Definitions without a file (mocked ones) pass.
+func CheckDefinition(kind, name string) error {
+	file := util.GetFileByNameAndType(kind, name)
+	if file == "" {
+		return nil
+	}
+
+	logger.Debugf("Validating definition =>\t%s\n", file)
+	problems, err := ValidateFile(file, kind)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		if p.Warning {
+			logger.Debugln(p.String())
+		}
+	}
+	if errs := problemErrors(problems); len(errs) > 0 {
+		return &ValidationError{errs}
+	}
+	return nil
+}
+
+// ValidateFile checks the definition file (TOML, YAML or JSON) of kind
+// (services, chains or actions). Fields unknown to the definition and
+// values of the wrong type are reported, as are malformed port and
+// volume specs, links and dependencies to unknown services or chains,
+// and (as warnings) images without a tag. The error is only set if the
+// file cannot be read.
+func ValidateFile(file, kind string) ([]*Problem, error) {
+	k, ok := definitionKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("I cannot validate %q definitions. Please give services, chains or actions.", kind)
+	}
+	body, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{file: file, ext: filepath.Ext(file), lines: strings.Split(string(body), "\n")}
+	conf, bad := v.decode(body)
+	if bad != nil {
+		return []*Problem{bad}, nil
+	}
+	v.keys = keyLines(body, v.ext)
+	if kind != "actions" {
+		v.interpolate("", conf)
+	}
+
+	extras := make(map[string]bool)
+	for _, key := range k.extras {
+		extras[key] = true
+	}
+	v.checkFields("", conf, k.typ, extras)
+
+	// The image of a definition which extends another may be inherited.
+	parent, _ := conf["extends"].(string)
+	if parent != "" && util.GetFileByNameAndType(kind, parent) == "" {
+		v.add("extends", "", false, "unknown %s %q", strings.TrimSuffix(kind, "s"), parent)
+	}
+
+	// Neither is it needed if the image is built.
+	_, build := conf["build"].(map[string]interface{})
+	service, _ := conf["service"].(map[string]interface{})
+	v.checkService(service, kind == "services" && parent == "" && !build)
+	if deps, ok := conf["dependencies"].(map[string]interface{}); ok {
+		v.checkDependencies(deps)
+	}
+
+	sort.Stable(byLine(v.problems))
+	return v.problems, nil
+}
+
+func problemErrors(problems []*Problem) []*Problem {
+	var errs []*Problem
+	for _, p := range problems {
+		if !p.Warning {
+			errs = append(errs, p)
+		}
+	}
+	return errs
+}
+
+type byLine []*Problem
+
+func (p byLine) Len() int           { return len(p) }
+func (p byLine) Less(i, j int) bool { return p[i].Line < p[j].Line }
+func (p byLine) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+type validator struct {
+	file     string
+	ext      string
+	lines    []string
+	keys     map[string]int
+	problems []*Problem
+}
+
+func (v *validator) add(field, value string, warning bool, format string, args ...interface{}) {
+	v.problems = append(v.problems, &Problem{
+		File:    v.file,
+		Line:    v.line(field, value),
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+		Warning: warning,
+	})
+}
+
+// decode reads the file into maps keyed by lower case keys, as viper
+// does. A syntax error is returned as a problem.
+func (v *validator) decode(body []byte) (map[string]interface{}, *Problem) {
+	var conf interface{}
+	var err error
+	line := 0
+
+	switch v.ext {
+	case ".json":
+		var m map[string]interface{}
+		err = json.Unmarshal(body, &m)
+		if serr, ok := err.(*json.SyntaxError); ok {
+			line = offsetLine(body, serr.Offset)
+		}
+		conf = m
+	case ".yaml", ".yml":
+		var m map[interface{}]interface{}
+		err = yaml.Unmarshal(body, &m)
+		if err != nil {
+			line = errorLine(err, `line (\d+)`)
+		}
+		conf = m
+	default:
+		var m map[string]interface{}
+		_, err = toml.Decode(string(body), &m)
+		if err != nil {
+			line = errorLine(err, `line (\d+)`)
+		}
+		conf = m
+	}
+	if err != nil {
+		return nil, &Problem{File: v.file, Line: line, Message: fmt.Sprintf("cannot be read: %v", err)}
+	}
+
+	m, _ := normalize(conf).(map[string]interface{})
+	if m == nil {
+		m = make(map[string]interface{})
+	}
+	return m, nil
+}
+
+// interpolate replaces the variables of the string values of conf as
+// the loaders do, so that the values are checked as they will be used.
+// Variables which are unset and have no default are warned of.
+func (v *validator) interpolate(path string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return interpolate(value, func(name string) {
+			v.add(path, "${"+name, true, "variable %s is not set and has no default", name)
+		})
+	case map[string]interface{}:
+		for _, key := range sortedKeys(value) {
+			value[key] = v.interpolate(strings.TrimPrefix(path+"."+key, "."), value[key])
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = v.interpolate(path, value[i])
+		}
+	}
+	return value
+}
+
+// normalize turns the maps of the YAML and TOML decoders into
+// map[string]interface{} with lower case keys, and lists into
+// []interface{}.
+func normalize(value interface{}) interface{} {
+	switch val := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, v := range val {
+			m[strings.ToLower(fmt.Sprint(k))] = normalize(v)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for k, v := range val {
+			m[strings.ToLower(k)] = normalize(v)
+		}
+		return m
+	case []map[string]interface{}:
+		l := make([]interface{}, len(val))
+		for i, v := range val {
+			l[i] = normalize(v)
+		}
+		return l
+	case []interface{}:
+		l := make([]interface{}, len(val))
+		for i, v := range val {
+			l[i] = normalize(v)
+		}
+		return l
+	}
+	return value
+}
+
+// checkFields reports the keys of conf which are not fields of the
+// struct typ (or extras), and the values of the wrong type.
+func (v *validator) checkFields(path string, conf map[string]interface{}, typ reflect.Type, extras map[string]bool) {
+	fields, internal := schemaFields(typ)
+	for _, key := range sortedKeys(conf) {
+		field := key
+		if path != "" {
+			field = path + "." + key
+		}
+		f, ok := fields[key]
+		if !ok {
+			if extras[key] || internal[key] {
+				continue
+			}
+			if guess := closestKey(key, fields); guess != "" {
+				v.add(field, "", false, "unknown field (did you mean %q?)", guess)
+			} else {
+				v.add(field, "", false, "unknown field")
+			}
+			continue
+		}
+		v.checkType(field, conf[key], f.Type)
+	}
+}
+
+func (v *validator) checkType(field string, value interface{}, typ reflect.Type) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if value == nil {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			v.add(field, "", false, "should be a table, not %s", describe(value))
+			return
+		}
+		v.checkFields(field, m, typ, nil)
+	case reflect.Slice:
+		l, ok := value.([]interface{})
+		if !ok {
+			v.add(field, "", false, "should be a list of %ss, not %s", kindName(typ.Elem()), describe(value))
+			return
+		}
+		for _, elem := range l {
+			v.checkType(field, elem, typ.Elem())
+		}
+	case reflect.Map:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			v.add(field, "", false, "should be a table, not %s", describe(value))
+			return
+		}
+		for _, key := range sortedKeys(m) {
+			v.checkType(field+"."+key, m[key], typ.Elem())
+		}
+	case reflect.String:
+		// numbers and booleans are read as strings
+		if !isScalar(value) {
+			v.add(field, "", false, "should be a string, not %s", describe(value))
+		}
+	case reflect.Bool:
+		switch val := value.(type) {
+		case bool:
+		case string:
+			if val == dataVolume && strings.HasSuffix(field, "data_container") {
+				break
+			}
+			if _, err := strconv.ParseBool(val); err != nil {
+				v.add(field, val, false, "should be true or false, not %q", val)
+			}
+		default:
+			if !isNumber(value) {
+				v.add(field, "", false, "should be true or false, not %s", describe(value))
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch val := value.(type) {
+		case string:
+			if _, err := strconv.ParseInt(val, 0, 64); err != nil {
+				v.add(field, val, false, "should be a number, not %q", val)
+			}
+		case bool:
+		default:
+			if !isNumber(value) {
+				v.add(field, "", false, "should be a number, not %s", describe(value))
+			}
+		}
+	}
+}
+
+// checkService checks the specs of the [service] section.
+func (v *validator) checkService(service map[string]interface{}, needsImage bool) {
+	image, _ := service["image"].(string)
+	switch {
+	case image == "" && needsImage:
+		v.add("service.image", "", false, "an image is required")
+	case image != "" && !hasImageTag(image):
+		v.add("service.image", image, true, "image %q has no tag; whatever image is latest will be used", image)
+	}
+
+	for _, port := range stringList(service["ports"]) {
+		if err := checkPortSpec(port, true); err != nil {
+			v.add("service.ports", port, false, "bad port %q: %v", port, err)
+		}
+	}
+	for _, port := range stringList(service["expose"]) {
+		if err := checkPortSpec(port, false); err != nil {
+			v.add("service.expose", port, false, "bad port %q: %v", port, err)
+		}
+	}
+	for _, vol := range stringList(service["volumes"]) {
+		if err := checkVolumeSpec(vol); err != nil {
+			v.add("service.volumes", vol, false, "bad volume %q: %v", vol, err)
+		}
+	}
+	for _, spec := range stringList(service["ulimits"]) {
+		if _, err := util.ParseUlimit(spec); err != nil {
+			v.add("service.ulimits", spec, false, "bad ulimit %q: %v", spec, err)
+		}
+	}
+	for _, spec := range stringList(service["tmpfs"]) {
+		if _, _, err := util.ParseTmpfs(spec); err != nil {
+			v.add("service.tmpfs", spec, false, "bad tmpfs %q: %v", spec, err)
+		}
+	}
+	for _, spec := range stringList(service["extra_hosts"]) {
+		if err := util.CheckExtraHost(spec); err != nil {
+			v.add("service.extra_hosts", spec, false, "bad extra host %q: %v", spec, err)
+		}
+	}
+	if service["memswap_limit"] != nil && service["mem_limit"] == nil {
+		v.add("service.memswap_limit", "", false, "memswap_limit needs a mem_limit")
+	}
+	for _, link := range stringList(service["links"]) {
+		name := strings.Split(link, ":")[0]
+		if !isKnownContainer(name) {
+			v.add("service.links", link, false, "link %q is to an unknown service or chain", link)
+		}
+	}
+}
+
+func (v *validator) checkDependencies(deps map[string]interface{}) {
+	for _, dep := range stringList(deps["services"]) {
+		name, _, _, _ := util.ParseDependency(dep)
+		if util.GetFileByNameAndType("services", name) == "" {
+			v.add("dependencies.services", dep, false, "unknown service %q", name)
+		}
+	}
+	for _, dep := range stringList(deps["chains"]) {
+		name, _, _, _ := util.ParseDependency(dep)
+		if !strings.HasPrefix(name, "$") && util.GetFileByNameAndType("chains", name) == "" {
+			v.add("dependencies.chains", dep, false, "unknown chain %q", name)
+		}
+	}
+}
+
+// line returns the line of the field (a dotted path) in the file, or of
+// its table if it is missing; the line of the list element value if
+// given and found.
+func (v *validator) line(field, value string) int {
+	line, ok := v.keys[field]
+	for !ok && strings.Contains(field, ".") {
+		field = field[:strings.LastIndex(field, ".")]
+		line, ok = v.keys[field]
+	}
+	if !ok {
+		return 0
+	}
+	if value == "" {
+		return line
+	}
+	for i := line - 1; i < len(v.lines) && i < line+100; i++ {
+		if strings.Contains(v.lines[i], value) {
+			return i + 1
+		}
+	}
+	return line
+}
+
+// schemaFields returns the fields of the struct typ which are read from
+// definition files, by their lower case keys. A field is found under
+// the key viper marshals it from and under its toml name. The internal
+// fields (without a toml name, such as Operations) are returned apart:
+// the JSON and YAML writers write them, but they are not checked.
+func schemaFields(typ reflect.Type) (fields map[string]reflect.StructField, internal map[string]bool) {
+	fields = make(map[string]reflect.StructField)
+	internal = make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tomlName := strings.Split(f.Tag.Get("toml"), ",")[0]
+		if tomlName == "" || tomlName == "-" {
+			internal[strings.ToLower(f.Name)] = true
+			continue
+		}
+		key := strings.Split(f.Tag.Get("mapstructure"), ",")[0]
+		if key == "" {
+			key = f.Name
+		}
+		fields[strings.ToLower(key)] = f
+		fields[strings.ToLower(tomlName)] = f
+	}
+	return fields, internal
+}
+
+// closestKey returns the key of fields closest to key, if it is close
+// enough to be a typo.
+func closestKey(key string, fields map[string]reflect.StructField) string {
+	best, bestDist := "", 3
+	for name := range fields {
+		if d := editDistance(key, name); d < bestDist || (d == bestDist && best != "" && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	if bestDist > len(key)/2 {
+		return ""
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j] + 1
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if prev[j-1]+cost < cur[j] {
+				cur[j] = prev[j-1] + cost
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// checkPortSpec checks a docker port spec: [[ip:]host_port:]port[/proto]
+// with ports or port ranges (a-b). Only a container port is allowed
+// unless published.
+func checkPortSpec(spec string, published bool) error {
+	ports := spec
+	if i := strings.LastIndex(spec, "/"); i >= 0 {
+		ports = spec[:i]
+		if proto := spec[i+1:]; proto != "tcp" && proto != "udp" {
+			return fmt.Errorf("unknown protocol %q", proto)
+		}
+	}
+
+	parts := strings.Split(ports, ":")
+	if len(parts) > 3 || (!published && len(parts) > 1) {
+		return fmt.Errorf("too many parts")
+	}
+	if err := checkPortRange(parts[len(parts)-1]); err != nil {
+		return err
+	}
+	if len(parts) >= 2 {
+		host := parts[len(parts)-2]
+		// the host port may be left to docker when an ip is given
+		if host != "" || len(parts) == 2 {
+			if err := checkPortRange(host); err != nil {
+				return err
+			}
+		}
+	}
+	if len(parts) == 3 && parts[0] != "" && net.ParseIP(parts[0]) == nil {
+		return fmt.Errorf("%q is not an ip address", parts[0])
+	}
+	return nil
+}
+
+func checkPortRange(ports string) error {
+	bounds := strings.SplitN(ports, "-", 2)
+	var nums []int
+	for _, b := range bounds {
+		n, err := strconv.Atoi(b)
+		if err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("%q is not a port", b)
+		}
+		nums = append(nums, n)
+	}
+	if len(nums) == 2 && nums[0] > nums[1] {
+		return fmt.Errorf("%q is not a port range", ports)
+	}
+	return nil
+}
+
+var (
+	volumeNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	volumeModes  = map[string]bool{"ro": true, "rw": true, "z": true, "Z": true, "nocopy": true}
+)
+
+// checkVolumeSpec checks a docker volume spec: [source:]path[:mode]. The
+// source is a host path (which may start with $eris, $pwd or ~) or the
+// name of a docker volume; path is absolute.
+func checkVolumeSpec(spec string) error {
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 {
+		return fmt.Errorf("too many parts")
+	}
+	if len(parts) == 3 {
+		for _, mode := range strings.Split(parts[2], ",") {
+			if !volumeModes[mode] {
+				return fmt.Errorf("unknown mode %q", mode)
+			}
+		}
+	}
+
+	dest := parts[0]
+	if len(parts) > 1 {
+		dest = parts[1]
+		src := parts[0]
+		switch {
+		case src == "":
+			return fmt.Errorf("the source is empty")
+		case strings.HasPrefix(src, "/"), strings.HasPrefix(src, "~"),
+			strings.HasPrefix(src, "$eris"), strings.HasPrefix(src, "$pwd"):
+		case !volumeNameRe.MatchString(src):
+			return fmt.Errorf("the source %q is neither an absolute path nor a volume name", src)
+		}
+	}
+	if !strings.HasPrefix(dest, "/") {
+		return fmt.Errorf("the path in the container %q is not absolute", dest)
+	}
+	return nil
+}
+
+// hasImageTag returns true if the image is given a tag or a digest.
+func hasImageTag(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	return strings.Contains(image[strings.LastIndex(image, "/")+1:], ":")
+}
+
+// isKnownContainer returns true if name is a service or a chain with a
+// definition file, or the container name of one.
+func isKnownContainer(name string) bool {
+	if cont := util.ContainerDisassemble(name); cont.ShortName != "" {
+		switch cont.Type {
+		case definitions.TypeService:
+			return util.GetFileByNameAndType("services", cont.ShortName) != ""
+		case definitions.TypeChain:
+			return util.GetFileByNameAndType("chains", cont.ShortName) != ""
+		case definitions.TypeData:
+			return true
+		}
+	}
+	return util.GetFileByNameAndType("services", name) != "" || util.GetFileByNameAndType("chains", name) != ""
+}
+
+func stringList(value interface{}) []string {
+	l, _ := value.([]interface{})
+	var strs []string
+	for _, elem := range l {
+		if isScalar(elem) {
+			strs = append(strs, fmt.Sprint(elem))
+		}
+	}
+	return strs
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int64, float64:
+		return true
+	}
+	return false
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "a table"
+	case []interface{}:
+		return "a list"
+	case string:
+		return fmt.Sprintf("the string %q", value)
+	case bool:
+		return fmt.Sprintf("%v", value)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func kindName(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "table"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	}
+	return "number"
+}
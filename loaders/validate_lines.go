@@ -0,0 +1,183 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// keyLines maps the dotted paths of the keys of a definition file (in
+// lower case) to the line they are first set on. Lists are not indexed:
+// the keys of the tables of a list are under the list's path.
+func keyLines(body []byte, ext string) map[string]int {
+	switch ext {
+	case ".json":
+		return jsonKeyLines(body)
+	case ".yaml", ".yml":
+		return yamlKeyLines(body)
+	}
+	return tomlKeyLines(body)
+}
+
+var (
+	tomlTableRe = regexp.MustCompile(`^\[\[?\s*([^\[\]]+?)\s*\]\]?`)
+	tomlKeyRe   = regexp.MustCompile(`^("[^"]+"|[A-Za-z0-9_-]+)\s*=`)
+)
+
+func tomlKeyLines(body []byte) map[string]int {
+	keys := make(map[string]int)
+	table := ""
+	depth := 0 // of the brackets of multi line arrays
+	for i, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		if depth > 0 {
+			depth += bracketDepth(line)
+			continue
+		}
+		if m := tomlTableRe.FindStringSubmatch(line); m != nil {
+			table = strings.ToLower(strings.Replace(m[1], `"`, "", -1))
+			setLine(keys, table, i+1)
+			continue
+		}
+		if m := tomlKeyRe.FindStringSubmatch(line); m != nil {
+			key := strings.ToLower(strings.Trim(m[1], `"`))
+			if table != "" {
+				key = table + "." + key
+			}
+			setLine(keys, key, i+1)
+			depth = bracketDepth(line[len(m[0]):])
+		}
+	}
+	return keys
+}
+
+// bracketDepth returns the number of [ less the number of ] of line,
+// outside of strings and comments.
+func bracketDepth(line string) int {
+	depth := 0
+	var quote rune
+	for _, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return depth
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+var yamlKeyRe = regexp.MustCompile(`^(\s*)(-\s+)?("[^"]+"|'[^']+'|[^\s#'"][^:#]*?)\s*:(\s|$)`)
+
+func yamlKeyLines(body []byte) map[string]int {
+	keys := make(map[string]int)
+	type level struct {
+		indent int
+		key    string
+	}
+	var stack []level
+	for i, line := range strings.Split(string(body), "\n") {
+		m := yamlKeyRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		indent := len(m[1]) + len(m[2])
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		key := strings.ToLower(strings.Trim(m[3], `"'`))
+		path := key
+		if len(stack) > 0 {
+			path = stack[len(stack)-1].key + "." + key
+		}
+		setLine(keys, path, i+1)
+		stack = append(stack, level{indent, path})
+	}
+	return keys
+}
+
+func jsonKeyLines(body []byte) map[string]int {
+	keys := make(map[string]int)
+	type frame struct {
+		object   bool
+		path     string
+		expected bool // whether a key is expected next
+		key      string
+	}
+	stack := []*frame{{path: ""}}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return keys
+		}
+		top := stack[len(stack)-1]
+		if top.object && top.expected {
+			if key, ok := tok.(string); ok {
+				top.key = strings.ToLower(key)
+				top.expected = false
+				path := top.key
+				if top.path != "" {
+					path = top.path + "." + top.key
+				}
+				setLine(keys, path, offsetLine(body, dec.InputOffset()))
+				continue
+			}
+		}
+
+		// a value (or the end of the object)
+		path := top.path
+		if top.object && top.key != "" {
+			path = strings.TrimPrefix(top.path+"."+top.key, ".")
+		}
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, &frame{object: true, path: path, expected: true})
+			continue
+		case json.Delim('['):
+			stack = append(stack, &frame{path: path})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		}
+		if parent := stack[len(stack)-1]; parent.object {
+			parent.expected = true
+		}
+	}
+}
+
+func setLine(keys map[string]int, key string, line int) {
+	if _, ok := keys[key]; !ok {
+		keys[key] = line
+	}
+}
+
+// offsetLine returns the line of the byte offset of body.
+func offsetLine(body []byte, offset int64) int {
+	if offset > int64(len(body)) {
+		offset = int64(len(body))
+	}
+	return bytes.Count(body[:offset], []byte("\n")) + 1
+}
+
+// errorLine returns the line number an error message gives with the
+// pattern, or 0.
+func errorLine(err error, pattern string) int {
+	m := regexp.MustCompile(pattern).FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
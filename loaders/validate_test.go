@@ -0,0 +1,298 @@
+package loaders
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+	"github.com/eris-ltd/eris-cli/definitions"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	log.SetLoggers(0, os.Stdout, os.Stderr)
+
+	var err error
+	erisDir, err = ioutil.TempDir("", "eris_loaders")
+	if err != nil {
+		panic(err)
+	}
+	config.ChangeErisDir(erisDir)
+	for _, dir := range []string{ServicesPath, ChainsPath, ActionsPath} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			panic(err)
+		}
+	}
+	writeDefinition(ServicesPath, "keys.toml", "[service]\nimage = \"quay.io/eris/keys:0.12\"\n")
+	writeDefinition(ChainsPath, "simplechain.toml", "name = \"simplechain\"\n")
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func writeDefinition(dir, name, body string) string {
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte(body), 0644); err != nil {
+		panic(err)
+	}
+	return file
+}
+
+// problems returns the problems found in the definition body as
+// "line: field: message" strings.
+func problems(t *testing.T, kind, name, body string) []string {
+	file := writeDefinition(filepath.Join(erisDir, kind), name, body)
+	defer os.Remove(file)
+
+	found, err := ValidateFile(file, kind)
+	if err != nil {
+		t.Fatalf("validate %s: %v", name, err)
+	}
+	var out []string
+	for _, p := range found {
+		out = append(out, strings.TrimPrefix(p.String(), file+":"))
+	}
+	return out
+}
+
+func expectProblems(t *testing.T, got []string, expected ...string) {
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d problems, got %d:\n%s", len(expected), len(got), strings.Join(got, "\n"))
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected problem\n%s\ngot\n%s", expected[i], got[i])
+		}
+	}
+}
+
+func TestValidateService(t *testing.T) {
+	expectProblems(t, problems(t, "services", "good.toml", `
+name = "good"
+
+[service]
+image = "quay.io/eris/ipfs:0.12"
+data_container = true
+ports = ["4001:4001", "127.0.0.1::5001", "8080-8090:8080-8090/udp"]
+expose = ["9000"]
+volumes = ["$eris/files:/home/eris/.eris/files:ro", "/data", "named:/named"]
+links = ["keys:keys", "eris_chain_simplechain_1:chain"]
+exec_host = "GOOD_HOST"
+mem_limit = 1024
+
+[dependencies]
+services = ["keys"]
+chains = ["$chain"]
+
+[machine]
+include = ["docker"]
+`))
+
+	expectProblems(t, problems(t, "services", "bad.toml", `
+name = "bad"
+
+[service]
+image = "quay.io/eris/ipfs"
+imgae = "typo"
+ports = [
+  "4001:4001",
+  "70000:1",
+  "a:b:c:d",
+]
+volumes = ["relative/path:/data", "/host:data"]
+links = ["nope:nope"]
+cpu_shares = "lots"
+environment = "A=B"
+
+[dependencies]
+services = ["missing"]
+`),
+		`5: warning: service.image: image "quay.io/eris/ipfs" has no tag; whatever image is latest will be used`,
+		`6: service.imgae: unknown field (did you mean "image"?)`,
+		`9: service.ports: bad port "70000:1": "70000" is not a port`,
+		`10: service.ports: bad port "a:b:c:d": too many parts`,
+		`12: service.volumes: bad volume "relative/path:/data": the source "relative/path" is neither an absolute path nor a volume name`,
+		`12: service.volumes: bad volume "/host:data": the path in the container "data" is not absolute`,
+		`13: service.links: link "nope:nope" is to an unknown service or chain`,
+		`14: service.cpu_shares: should be a number, not "lots"`,
+		`15: service.environment: should be a list of strings, not the string "A=B"`,
+		`18: dependencies.services: unknown service "missing"`,
+	)
+
+	expectProblems(t, problems(t, "services", "limits.toml", `
+[service]
+image = "quay.io/eris/erisdb:0.12"
+memswap_limit = 2048
+cpuset = "0-1"
+cpu_quota = 50000
+pids_limit = 100
+ulimits = ["nofile=1024:4096", "nproc=x"]
+tmpfs = ["/run:rw,size=64m", "tmp"]
+read_only = true
+extra_hosts = ["db:10.0.0.2", "db"]
+stop_signal = "SIGINT"
+
+[service.log_opt]
+max-size = "10m"
+`),
+		`4: service.memswap_limit: memswap_limit needs a mem_limit`,
+		`8: service.ulimits: bad ulimit "nproc=x": the soft limit "x" is not a number`,
+		`9: service.tmpfs: bad tmpfs "tmp": the path "tmp" is not absolute`,
+		`11: service.extra_hosts: bad extra host "db": not of the form HOST:IP`,
+	)
+
+	expectProblems(t, problems(t, "services", "noimage.toml", "[service]\nname = \"noimage\"\n"),
+		`1: service.image: an image is required`)
+
+	expectProblems(t, problems(t, "services", "built.toml", "[service]\nname = \"built\"\n\n[build]\ncontext = \"src/built\"\ntarget = \"runtime\"\n\n[build.args]\nVERSION = \"0.1.0\"\n"))
+	expectProblems(t, problems(t, "services", "badbuild.toml", "[service]\nname = \"badbuild\"\n\n[build]\ncontext = \"src/built\"\nargs = [\"VERSION=0.1.0\"]\n"),
+		`6: build.args: should be a table, not a list`)
+
+	expectProblems(t, problems(t, "services", "volume.toml", "[service]\nimage = \"quay.io/eris/ipfs:0.12\"\ndata_container = \"volume\"\n"))
+	expectProblems(t, problems(t, "services", "maybe.toml", "[service]\nimage = \"quay.io/eris/ipfs:0.12\"\ndata_container = \"maybe\"\n"),
+		`3: service.data_container: should be true or false, not "maybe"`)
+}
+
+func TestDataVolume(t *testing.T) {
+	file := writeDefinition(ServicesPath, "volumed.toml", "name = \"volumed\"\n\n[service]\nimage = \"quay.io/eris/ipfs:0.12\"\ndata_container = \"volume\"\n")
+	defer os.Remove(file)
+
+	conf, err := config.LoadViperConfig(ServicesPath, "volumed", "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := definitions.BlankServiceDefinition()
+	if err := MarshalServiceDefinition(conf, srv); err != nil {
+		t.Fatal(err)
+	}
+	if !srv.Service.AutoData || !srv.Service.DataVolume {
+		t.Fatalf("expected the data kept in a volume, got data_container %v and volume %v", srv.Service.AutoData, srv.Service.DataVolume)
+	}
+
+	chain := definitions.BlankChain()
+	chainConf, err := config.LoadViperConfig(ServicesPath, "volumed", "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainConf.Set("data_container", "volume")
+	if err := MarshalChainDefinition(chainConf, chain); err != nil {
+		t.Fatal(err)
+	}
+	if !chain.Service.AutoData || !chain.Service.DataVolume {
+		t.Fatalf("expected the chain data kept in a volume, got %+v", chain.Service)
+	}
+}
+
+func TestBuildSection(t *testing.T) {
+	file := writeDefinition(ServicesPath, "built.toml", "name = \"built\"\n\n[service]\nname = \"built\"\n\n[build]\ncontext = \"src/built\"\ndockerfile = \"docker/Dockerfile\"\n\n[build.args]\nVERSION = \"0.1.0\"\n")
+	defer os.Remove(file)
+
+	conf, err := config.LoadViperConfig(ServicesPath, "built", "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := definitions.BlankServiceDefinition()
+	if err := MarshalServiceDefinition(conf, srv); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkImage(srv.Service, srv.Build); err != nil {
+		t.Fatalf("expected a built service to need no image, got %v", err)
+	}
+	buildContext(srv.Build)
+	if srv.Build.Context != filepath.Join(ServicesPath, "src/built") || srv.Build.Dockerfile != "docker/Dockerfile" || srv.Build.Args["VERSION"] != "0.1.0" {
+		t.Fatalf("expected the build settings read, got %+v", srv.Build)
+	}
+
+	if err := checkImage(srv.Service, nil); err == nil {
+		t.Fatalf("expected an error for a service with neither an image nor a build section")
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	expectProblems(t, problems(t, "services", "yamled.yaml", `name: yamled
+service:
+  image: quay.io/eris/keys:0.12
+  ports:
+    - "4001:4001"
+    - "4001:x"
+  volume: /data
+healthcheck:
+  retries: many
+`),
+		`6: service.ports: bad port "4001:x": "x" is not a port`,
+		`7: service.volume: unknown field (did you mean "volumes"?)`,
+		`9: healthcheck.retries: should be a number, not "many"`,
+	)
+
+	expectProblems(t, problems(t, "services", "jsoned.json", `{
+  "name": "jsoned",
+  "service": {
+    "image": "quay.io/eris/keys:0.12",
+    "links": ["keys"],
+    "data_container": "yes"
+  },
+  "maintainer": {"name": "marmot", "phone": "555"}
+}`),
+		`6: service.data_container: should be true or false, not "yes"`,
+		`8: maintainer.phone: unknown field`,
+	)
+
+	got := problems(t, "services", "broken.toml", "[service]\nimage = \"x\"\nports = [\n")
+	if len(got) != 1 || !strings.Contains(got[0], "cannot be read") {
+		t.Fatalf("expected a syntax error, got %v", got)
+	}
+}
+
+func TestValidateChainAndAction(t *testing.T) {
+	expectProblems(t, problems(t, "chains", "chainy.toml", `
+name = "chainy"
+chain_id = "chainy"
+data_container = true
+
+[service]
+ports = ["46656:46656"]
+
+[dependencies]
+services = ["keys"]
+chains = ["nochain"]
+`),
+		`11: dependencies.chains: unknown chain "nochain"`,
+	)
+
+	expectProblems(t, problems(t, "actions", "act.toml", `
+name = "act"
+chain = "$chain"
+steps = ["echo hi"]
+step = ["echo typo"]
+
+[environment]
+HELLO = "world"
+`),
+		`5: step: unknown field (did you mean "steps"?)`,
+	)
+}
+
+func TestCheckDefinition(t *testing.T) {
+	if err := CheckDefinition("services", "keys"); err != nil {
+		t.Fatalf("expected keys to pass, got %v", err)
+	}
+	if err := CheckDefinition("services", "nofile"); err != nil {
+		t.Fatalf("expected a definition without a file to pass, got %v", err)
+	}
+
+	file := writeDefinition(ServicesPath, "typo.toml", "[service]\nimage = \"quay.io/eris/keys\"\nport = [\"1:1\"]\n")
+	defer os.Remove(file)
+	err := CheckDefinition("services", "typo")
+	verr, ok := err.(*ValidationError)
+	if !ok || len(verr.Problems) != 1 || verr.Problems[0].Line != 3 {
+		t.Fatalf("expected a validation error for the unknown field only, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package perform
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/docker/docker/pkg/archive"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// DockerBuild builds the image of the service srv from the build section
+// (if one is given) and points srv.Image at it. The image is tagged with
+// a hash of the context files and the build settings, so the image of an
+// unchanged context is not rebuilt. srv.Image names the repository of the
+// image (eris/NAME by default); its tag is replaced.
+func DockerBuild(srv *def.Service, build *def.Build) error {
+	if build.IsEmpty() {
+		return nil
+	}
+	if util.Images() == nil {
+		return fmt.Errorf("The marmots cannot build images with this backend. Please give the service an image.")
+	}
+
+	dockerfile, err := buildDockerfile(build)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Reading build context =>\t%s\n", build.Context)
+	reader, err := util.Tar(build.Context, archive.Uncompressed)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	var context bytes.Buffer
+	if _, err := io.Copy(&context, reader); err != nil {
+		return err
+	}
+
+	hash, err := buildHash(context.Bytes(), dockerfile, build)
+	if err != nil {
+		return err
+	}
+	image := imageRepository(srv) + ":" + hash
+	srv.Image = image
+
+	if _, err := util.Images().InspectImage(image); err == nil {
+		logger.Infof("Image is up to date =>\t\t%s\n", image)
+		return nil
+	}
+
+	logger.Infof("Building image =>\t\t%s\n", image)
+	opts := docker.BuildImageOptions{
+		Name:           image,
+		Dockerfile:     dockerfile,
+		Target:         build.Target,
+		RmTmpContainer: true,
+		InputStream:    &context,
+		OutputStream:   bytes.NewBuffer([]byte{}),
+	}
+	if logger.Level > 0 {
+		opts.OutputStream = logger.Writer
+	}
+	for _, name := range sortedKeys(build.Args) {
+		opts.BuildArgs = append(opts.BuildArgs, docker.BuildArg{Name: name, Value: build.Args[name]})
+	}
+	return util.Images().BuildImage(opts)
+}
+
+// buildDockerfile returns the path of the Dockerfile relative to the
+// build context, which the daemon expects.
+func buildDockerfile(build *def.Build) (string, error) {
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		return "Dockerfile", nil
+	}
+	if !filepath.IsAbs(dockerfile) {
+		return filepath.ToSlash(filepath.Clean(dockerfile)), nil
+	}
+
+	rel, err := filepath.Rel(build.Context, dockerfile)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("The Dockerfile %s must be in the build context %s.", dockerfile, build.Context)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// buildHash hashes the names, modes and contents of the files in the
+// context tar (but not their modification times, so files checked out
+// anew don't count as changed) and the build settings.
+func buildHash(context []byte, dockerfile string, build *def.Build) (string, error) {
+	hash := sha256.New()
+	files := tar.NewReader(bytes.NewReader(context))
+	for {
+		header, err := files.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00%o\x00%s\x00", header.Name, header.Mode, header.Linkname)
+		if _, err := io.Copy(hash, files); err != nil {
+			return "", err
+		}
+	}
+
+	fmt.Fprintf(hash, "dockerfile=%s\x00target=%s\x00", dockerfile, build.Target)
+	for _, name := range sortedKeys(build.Args) {
+		fmt.Fprintf(hash, "arg=%s=%s\x00", name, build.Args[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
+
+// imageRepository returns srv.Image without a tag or digest or, if no
+// image is given, eris/NAME.
+func imageRepository(srv *def.Service) string {
+	image := srv.Image
+	if image == "" {
+		return "eris/" + strings.ToLower(srv.Name)
+	}
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
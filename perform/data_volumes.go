@@ -0,0 +1,210 @@
+package perform
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// DockerCreateDataVolume creates a blank data volume, the named volume
+// counterpart of DockerCreateData. It returns ErrContainerExists if the
+// data exists (as a volume or a container) or other Docker errors.
+//
+//	ops.DataContainerName  - data volume name to be created
+//	ops.Labels             - creation time labels (use LoadDataDefinition)
+func DockerCreateDataVolume(ops *def.Operation) error {
+	logger.Infof("Creating Data Volume for =>\t%s\n", ops.DataContainerName)
+
+	if util.Volumes() == nil {
+		return fmt.Errorf("The marmots cannot keep data in volumes with this backend. Please use a data container.")
+	}
+	if _, exists := DataContainerExists(ops); exists || util.DataVolumeExists(ops.DataContainerName) {
+		logger.Infoln("Data exists. Not creating.")
+		return ErrContainerExists
+	}
+	return util.CreateDataVolume(ops.DataContainerName, ops.Labels)
+}
+
+// DockerMountData returns the ID of a container to copy files in and out
+// of the data ops.DataContainerName with: the data container itself or,
+// for data kept in a volume, a container with the volume mounted at
+// ErisContainerRoot, which unmount removes.
+//
+//	ops.DataContainerName  - data container or volume name
+func DockerMountData(ops *def.Operation) (id string, unmount func(), err error) {
+	if container, exists := DataContainerExists(ops); exists {
+		return container.ID, func() {}, nil
+	}
+	if !util.DataVolumeExists(ops.DataContainerName) {
+		return "", nil, fmt.Errorf("There is no data container or volume for that service.")
+	}
+	return mountDataVolume(ops.DataContainerName)
+}
+
+// DockerMigrateData copies the data container ops.DataContainerName into
+// a data volume of the same name, then removes the data container and
+// the (stopped) service container using it, which is recreated with the
+// volume on the next start.
+//
+//	ops.DataContainerName  - data container to migrate
+//	ops.Labels             - creation time labels for the volume
+func DockerMigrateData(ops *def.Operation) error {
+	container, exists := DataContainerExists(ops)
+	if !exists {
+		return fmt.Errorf("There is no data container %s to migrate.", ops.DataContainerName)
+	}
+	if util.Volumes() == nil {
+		return fmt.Errorf("The marmots cannot keep data in volumes with this backend.")
+	}
+
+	service := container.Labels[def.Namespace+":"+def.LabelService]
+	if service != "" {
+		if _, running := util.ParseContainers(service, false); running {
+			return fmt.Errorf("The %s container is using the data. Please stop it before migrating the data.", service)
+		}
+	}
+	if util.DataVolumeExists(ops.DataContainerName) {
+		return fmt.Errorf("The data volume %s exists already. Please remove it before migrating the data.", ops.DataContainerName)
+	}
+
+	logger.Infof("Migrating data container =>\t%s\n", ops.DataContainerName)
+	labels := copyLabels(container.Labels)
+	if err := util.CreateDataVolume(ops.DataContainerName, labels); err != nil {
+		return err
+	}
+	if err := copyData(container.ID, ops.DataContainerName); err != nil {
+		util.Volumes().RemoveVolume(ops.DataContainerName)
+		return err
+	}
+
+	if service != "" {
+		if _, exists := util.ParseContainers(service, true); exists {
+			logger.Infof("Removing service container =>\t%s\n", service)
+			if err := removeContainer(service, false); err != nil {
+				return err
+			}
+		}
+	}
+	logger.Infof("Removing data container =>\t%s\n", ops.DataContainerName)
+	return removeContainer(container.ID, true)
+}
+
+// DockerRenameDataVolume renames the data volume ops.DataContainerName
+// after newName. Volumes cannot be renamed, so the data is copied into a
+// new volume and the old one is removed.
+//
+//	ops.DataContainerName  - data volume name
+//	ops.ContainerNumber    - container number
+//	ops.Labels             - creation time labels
+func DockerRenameDataVolume(ops *def.Operation, newName string) error {
+	longNewName := util.DataContainersName(newName, ops.ContainerNumber)
+	logger.Infof("Renaming data volume =>\t\t%s to %s\n", ops.DataContainerName, longNewName)
+
+	if util.DataVolumeExists(longNewName) {
+		return ErrContainerExists
+	}
+	volume, err := util.Volumes().InspectVolume(ops.DataContainerName)
+	if err != nil {
+		return err
+	}
+
+	id, unmount, err := mountDataVolume(ops.DataContainerName)
+	if err != nil {
+		return err
+	}
+	defer unmount()
+
+	if err := util.CreateDataVolume(longNewName, volume.Labels); err != nil {
+		return err
+	}
+	if err := copyData(id, longNewName); err != nil {
+		util.Volumes().RemoveVolume(longNewName)
+		return err
+	}
+
+	unmount()
+	return util.Volumes().RemoveVolume(ops.DataContainerName)
+}
+
+// DockerInspectDataVolume displays the field of the data volume
+// ops.DataContainerName ("all" for all of them).
+func DockerInspectDataVolume(ops *def.Operation, field string) error {
+	volume, err := util.Volumes().InspectVolume(ops.DataContainerName)
+	if err != nil {
+		return err
+	}
+	return util.PrintVolumeReport(volume, field)
+}
+
+// DockerRemoveDataVolume removes the data volume ops.DataContainerName.
+func DockerRemoveDataVolume(ops *def.Operation) error {
+	logger.Infof("Removing data volume =>\t\t%s\n", ops.DataContainerName)
+	return util.Volumes().RemoveVolume(ops.DataContainerName)
+}
+
+// mountDataVolume creates a container (never started) with the volume
+// name mounted at ErisContainerRoot to copy files in and out of the
+// volume with; unmount removes it (and may be called more than once).
+func mountDataVolume(name string) (id string, unmount func(), err error) {
+	opts := docker.CreateContainerOptions{
+		Name: "eris_mount_" + name,
+		Config: &docker.Config{
+			Image:           "quay.io/eris/data",
+			User:            "root",
+			NetworkDisabled: true,
+			Entrypoint:      []string{"true"},
+			Cmd:             []string{},
+		},
+		HostConfig: &docker.HostConfig{
+			Binds: []string{name + ":" + dirs.ErisContainerRoot},
+		},
+	}
+
+	container, err := createContainer(opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	removed := false
+	return container.ID, func() {
+		if removed {
+			return
+		}
+		removed = true
+		if err := removeContainer(container.ID, false); err != nil {
+			logger.Debugf("Could not remove =>\t\t%s (%v)\n", opts.Name, err)
+		}
+	}, nil
+}
+
+// copyData copies ErisContainerRoot of the container id into the data
+// volume name.
+func copyData(id, name string) error {
+	to, unmount, err := mountDataVolume(name)
+	if err != nil {
+		return err
+	}
+	defer unmount()
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(util.Backend.DownloadFromContainer(id, docker.DownloadFromContainerOptions{
+			OutputStream: writer,
+			Path:         dirs.ErisContainerRoot,
+		}))
+	}()
+
+	logger.Debugf("Copying data =>\t\t%s to %s\n", id, name)
+	err = util.Backend.UploadToContainer(to, docker.UploadToContainerOptions{
+		InputStream: reader,
+		Path:        filepath.Dir(dirs.ErisContainerRoot),
+	})
+	reader.Close()
+	return err
+}
@@ -11,7 +11,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/docker/docker/pkg/term"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/moby/term"
 	"github.com/eris-ltd/eris-cli/config"
 	def "github.com/eris-ltd/eris-cli/definitions"
 	"github.com/eris-ltd/eris-cli/util"
@@ -24,6 +24,11 @@ var (
 	ErrContainerExists = errors.New("container exists")
 )
 
+// ServiceCheck, if set, is called with every service before a container
+// is configured for it, and refuses the container if it returns an
+// error. The agent sets it to confine what its callers can run.
+var ServiceCheck func(*def.Service, *def.Operation) error
+
 // DockerCreateData creates a blank data container. It returns ErrContainerExists
 // if such a container exists or other Docker errors.
 //
@@ -172,6 +177,10 @@ func DockerExecData(ops *def.Operation, service *def.Service) (err error) {
 //                          or never if unspecified)
 //
 func DockerRunService(srv *def.Service, ops *def.Operation) error {
+	if Agent != nil {
+		return Agent.RunService(srv, ops)
+	}
+
 	logger.Infof("Starting Service =>\t\t%s\n", srv.Name)
 
 	_, running := ContainerRunning(ops)
@@ -180,10 +189,12 @@ func DockerRunService(srv *def.Service, ops *def.Operation) error {
 		return nil
 	}
 
-	optsServ := configureServiceContainer(srv, ops)
+	optsServ, err := configureServiceContainer(srv, ops)
+	if err != nil {
+		return err
+	}
 
 	// Fix volume paths.
-	var err error
 	srv.Volumes, err = util.FixDirs(srv.Volumes)
 	if err != nil {
 		return err
@@ -192,20 +203,9 @@ func DockerRunService(srv *def.Service, ops *def.Operation) error {
 	// Setup data container.
 	logger.Infof("Manage data containers? =>\t%t\n", srv.AutoData)
 	if srv.AutoData {
-		optsData, err := configureDataContainer(srv, ops, &optsServ)
-		if err != nil {
+		if err := setupData(srv, ops, &optsServ); err != nil {
 			return err
 		}
-
-		if _, exists := util.ParseContainers(ops.DataContainerName, true); exists {
-			logger.Infoln("Data Container already exists, am not creating.")
-		} else {
-			logger.Infoln("Data Container does not exist, creating.")
-			_, err := createContainer(optsData)
-			if err != nil {
-				return err
-			}
-		}
 	}
 
 	// Check existence || create the container.
@@ -254,12 +254,29 @@ func DockerRunService(srv *def.Service, ops *def.Operation) error {
 //
 // See parameter description for DockerRunService.
 func DockerExecService(srv *def.Service, ops *def.Operation) error {
+	if Agent != nil {
+		return Agent.ExecService(srv, ops)
+	}
+
+	return dockerExecService(srv, ops, os.Stdin, config.GlobalConfig.Writer, config.GlobalConfig.ErrorWriter)
+}
+
+// DockerExecServiceTo is DockerExecService with the container output
+// written to stdout and stderr rather than the global writers, and no
+// input attached. eris agents stream exec output with it.
+func DockerExecServiceTo(srv *def.Service, ops *def.Operation, stdout, stderr io.Writer) error {
+	return dockerExecService(srv, ops, nil, stdout, stderr)
+}
+
+func dockerExecService(srv *def.Service, ops *def.Operation, stdin io.Reader, stdout, stderr io.Writer) error {
 	logger.Infof("Starting Service =>\t\t%s\n", srv.Name)
 
-	optsServ := configureInteractiveContainer(srv, ops)
+	optsServ, err := configureInteractiveContainer(srv, ops)
+	if err != nil {
+		return err
+	}
 
 	// Fix volume paths.
-	var err error
 	srv.Volumes, err = util.FixDirs(srv.Volumes)
 	if err != nil {
 		return err
@@ -268,20 +285,9 @@ func DockerExecService(srv *def.Service, ops *def.Operation) error {
 	// Setup data container.
 	logger.Infof("Manage data containers? =>\t%t\n", srv.AutoData)
 	if srv.AutoData {
-		optsData, err := configureDataContainer(srv, ops, &optsServ)
-		if err != nil {
+		if err := setupData(srv, ops, &optsServ); err != nil {
 			return err
 		}
-
-		if _, exists := util.ParseContainers(ops.DataContainerName, true); exists {
-			logger.Infoln("Data container already exists, am not creating.")
-		} else {
-			logger.Infoln("Data container does not exist, creating.")
-			_, err := createContainer(optsData)
-			if err != nil {
-				return err
-			}
-		}
 	}
 
 	logger.Infof("Service container does not exist, creating from image (%s).\n", srv.Image)
@@ -308,7 +314,7 @@ func DockerExecService(srv *def.Service, ops *def.Operation) error {
 	logger.Debugf("\twith Image =>\t\t%v\n", optsServ.Config.Image)
 	logger.Debugf("\twith AllPortsPubl'd =>\t%v\n", optsServ.HostConfig.PublishAllPorts)
 	logger.Debugf("\twith Environment =>\t%v\n", optsServ.Config.Env)
-	if err := startInteractiveContainer(optsServ); err != nil {
+	if err := startAttachedContainer(optsServ, stdin, stdout, stderr); err != nil {
 		return err
 	}
 
@@ -327,6 +333,10 @@ func DockerExecService(srv *def.Service, ops *def.Operation) error {
 //
 // Also see container parameters for DockerRunService.
 func DockerRebuild(srv *def.Service, ops *def.Operation, pullImage bool, timeout uint) error {
+	if Agent != nil {
+		return Agent.RebuildService(srv, ops, pullImage, timeout)
+	}
+
 	var wasRunning bool = false
 
 	logger.Infof("Starting Docker Rebuild =>\t%s\n", srv.Name)
@@ -359,8 +369,10 @@ func DockerRebuild(srv *def.Service, ops *def.Operation, pullImage bool, timeout
 		}
 	}
 
-	opts := configureServiceContainer(srv, ops)
-	var err error
+	opts, err := configureServiceContainer(srv, ops)
+	if err != nil {
+		return err
+	}
 	srv.Volumes, err = util.FixDirs(srv.Volumes)
 	if err != nil {
 		return err
@@ -395,6 +407,10 @@ func DockerRebuild(srv *def.Service, ops *def.Operation, pullImage bool, timeout
 //
 // Also see container parameters for DockerRunService.
 func DockerPull(srv *def.Service, ops *def.Operation) error {
+	if Agent != nil {
+		return Agent.PullService(srv, ops)
+	}
+
 	logger.Infof("Pulling an image (%s) for the service (%s)\n", srv.Image, srv.Name)
 
 	var wasRunning bool = false
@@ -436,10 +452,45 @@ func DockerPull(srv *def.Service, ops *def.Operation) error {
 	return nil
 }
 
+// DockerPullImage pulls the image unless it is found locally.
+func DockerPullImage(image string) error {
+	if util.Images() != nil {
+		if _, err := util.Images().InspectImage(image); err == nil {
+			logger.Debugf("Image found locally =>\t%s\n", image)
+			return nil
+		}
+	}
+
+	logger.Infof("Pulling image =>\t\t%s\n", image)
+	return pullImage(image, nil)
+}
+
+// DockerEnsureImage pulls the image unless it is found locally, asking
+// the user first unless ERIS_PULL_APPROVE is set. Images are left to be
+// pulled as the containers are created if the backend can't inspect
+// them or the containers run through the agent.
+func DockerEnsureImage(image string) error {
+	if Agent != nil || util.Images() == nil {
+		return nil
+	}
+	if _, err := util.Images().InspectImage(image); err == nil {
+		return nil
+	}
+
+	if err := approvePull(image); err != nil {
+		return err
+	}
+	return pullImage(image, nil)
+}
+
 // DockerLogs displays tail number of lines of container ops.SrvContainerName
 // output. If follow is true, it behaves like `tail -f`. It returns Docker
 // errors on exit if not successful.
 func DockerLogs(srv *def.Service, ops *def.Operation, follow bool, tail string) error {
+	if Agent != nil {
+		return Agent.LogsService(srv, ops, follow, tail)
+	}
+
 	if service, exists := ContainerExists(ops); exists {
 		logger.Infof("Getting Logs for Service ID =>\t%s:%v:%v\n", service.ID, follow, tail)
 		if err := logsContainer(service.ID, follow, tail); err != nil {
@@ -457,6 +508,10 @@ func DockerLogs(srv *def.Service, ops *def.Operation, follow bool, tail string)
 // either "line" to display a short info line or "all" to display everything. I
 // DockerInspect returns Docker errors on exit in not successful.
 func DockerInspect(srv *def.Service, ops *def.Operation, field string) error {
+	if Agent != nil {
+		return Agent.InspectService(srv, ops, field)
+	}
+
 	if service, exists := ContainerExists(ops); exists {
 		logger.Infof("Inspecting Service ID =>\t%s\n", service.ID)
 		err := inspectContainer(service.ID, field)
@@ -475,6 +530,10 @@ func DockerInspect(srv *def.Service, ops *def.Operation, field string) error {
 // It returns Docker errors on exit if not successful. DockerStop doesn't return
 // an error if the container isn't running.
 func DockerStop(srv *def.Service, ops *def.Operation, timeout uint) error {
+	if Agent != nil {
+		return Agent.StopService(srv, ops, timeout)
+	}
+
 	// don't limit this to verbose because it takes a few seconds
 	// [zr] unless force sets timeout to 0 (for, eg. stdout)
 	if timeout != 0 {
@@ -517,14 +576,14 @@ func DockerRename(ops *def.Operation, newName string) error {
 
 	logger.Debugln("\tChecking container exist")
 
-	container, err := util.DockerClient.InspectContainer(ops.SrvContainerName)
+	container, err := util.Backend.InspectContainer(ops.SrvContainerName)
 	if err != nil {
 		return err
 	}
 
 	logger.Debugln("\tChecking new container exist (should fail)")
 
-	_, err = util.DockerClient.InspectContainer(longNewName)
+	_, err = util.Backend.InspectContainer(longNewName)
 	if err == nil {
 		return ErrContainerExists
 	}
@@ -533,7 +592,7 @@ func DockerRename(ops *def.Operation, newName string) error {
 	_, wasRunning := ContainerRunning(ops)
 	if wasRunning {
 		logger.Debugln("\tStopping container")
-		if err := util.DockerClient.StopContainer(container.ID, 5); err != nil {
+		if err := util.Backend.StopContainer(container.ID, 5); err != nil {
 			logger.Debugln("\tNot stopped")
 		}
 	}
@@ -545,22 +604,23 @@ func DockerRename(ops *def.Operation, newName string) error {
 		RemoveVolumes: true,
 		Force:         true,
 	}
-	if err := util.DockerClient.RemoveContainer(removeOpts); err != nil {
+	if err := util.Backend.RemoveContainer(removeOpts); err != nil {
 		return err
 	}
 
 	logger.Debugln("\tCreating the new container")
 
 	createOpts := docker.CreateContainerOptions{
-		Name:       longNewName,
-		Config:     container.Config,
-		HostConfig: container.HostConfig,
+		Name:             longNewName,
+		Config:           container.Config,
+		HostConfig:       container.HostConfig,
+		NetworkingConfig: renameNetworks(container, newName),
 	}
 
 	// If VolumesFrom contains links to non-existent containers, remove them.
 	var newVolumesFrom []string
 	for _, name := range createOpts.HostConfig.VolumesFrom {
-		_, err = util.DockerClient.InspectContainer(name)
+		_, err = util.Backend.InspectContainer(name)
 		if err != nil {
 			continue
 		}
@@ -575,7 +635,7 @@ func DockerRename(ops *def.Operation, newName string) error {
 	// Rename labels.
 	createOpts.Config.Labels = util.Labels(newName, ops)
 
-	newContainer, err := util.DockerClient.CreateContainer(createOpts)
+	newContainer, err := createContainer(createOpts)
 	if err != nil {
 		logger.Debugln("Not created")
 		return err
@@ -583,7 +643,7 @@ func DockerRename(ops *def.Operation, newName string) error {
 
 	// Was running before remove.
 	if wasRunning {
-		err := util.DockerClient.StartContainer(newContainer.ID, createOpts.HostConfig)
+		err := util.Backend.StartContainer(newContainer.ID, createOpts.HostConfig)
 		if err != nil {
 			logger.Debugln("Not restarted")
 		}
@@ -594,11 +654,46 @@ func DockerRename(ops *def.Operation, newName string) error {
 	return nil
 }
 
+// renameNetworks returns the networks of the container to recreate it
+// on, with its short name alias changed to newName.
+func renameNetworks(container *docker.Container, newName string) *docker.NetworkingConfig {
+	if container.NetworkSettings == nil || len(container.NetworkSettings.Networks) == 0 {
+		return nil
+	}
+	oldName := container.Config.Labels[def.Namespace+":"+def.LabelShortName]
+
+	config := &docker.NetworkingConfig{
+		EndpointsConfig: make(map[string]*docker.EndpointConfig),
+	}
+	for network, settings := range container.NetworkSettings.Networks {
+		if !isUserNetwork(network) {
+			continue
+		}
+		var aliases []string
+		for _, alias := range settings.Aliases {
+			switch {
+			case alias == oldName:
+				aliases = append(aliases, newName)
+			case strings.HasPrefix(container.ID, alias):
+				// The daemon adds the short ID itself.
+			default:
+				aliases = append(aliases, alias)
+			}
+		}
+		config.EndpointsConfig[network] = &docker.EndpointConfig{Aliases: aliases}
+	}
+	return config
+}
+
 // DockerRemove removes the ops.SrvContainerName container unforcedly.
-// If withData is true, the associated data container is also removed.
+// If withData is true, the associated data container (or data volume) is also removed.
 // If volumes is true, the associated volumes are removed for both containers.
 // DockerRemove returns Docker errors on exit if not successful.
 func DockerRemove(srv *def.Service, ops *def.Operation, withData, volumes bool) error {
+	if Agent != nil {
+		return Agent.RemoveService(srv, ops, withData, volumes)
+	}
+
 	if service, exists := ContainerExists(ops); exists {
 		logger.Infof("Removing Service ID =>\t\t%s\n", service.ID)
 		if err := removeContainer(service.ID, volumes); err != nil {
@@ -610,6 +705,10 @@ func DockerRemove(srv *def.Service, ops *def.Operation, withData, volumes bool)
 				if err := removeContainer(srv.ID, volumes); err != nil {
 					return err
 				}
+			} else if util.DataVolumeExists(ops.DataContainerName) {
+				if err := DockerRemoveDataVolume(ops); err != nil {
+					return err
+				}
 			}
 		}
 	} else {
@@ -646,16 +745,14 @@ func pullImage(name string, writer io.Writer) error {
 	var tag string = "latest"
 	var reg string = ""
 
-	nameSplit := strings.Split(name, ":")
-	if len(nameSplit) == 2 {
-		tag = nameSplit[1]
-	}
-	if len(nameSplit) == 3 {
-		tag = nameSplit[2]
+	// The registry hostname may have a port (localhost:5000/eris/ipfs:0.12).
+	image := name
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		tag = name[i+1:]
+		name = name[:i]
 	}
-	name = nameSplit[0]
 
-	repoSplit := strings.Split(nameSplit[0], "/")
+	repoSplit := strings.Split(name, "/")
 	if len(repoSplit) > 2 {
 		reg = repoSplit[0]
 	}
@@ -671,9 +768,12 @@ func pullImage(name string, writer io.Writer) error {
 		opts.OutputStream = nil
 	}
 
-	auth := docker.AuthConfiguration{}
+	auth, err := util.RegistryAuth(image)
+	if err != nil {
+		return err
+	}
 
-	err := util.DockerClient.PullImage(opts, auth)
+	err = util.Backend.PullImage(opts, auth)
 	if err != nil {
 		return err
 	}
@@ -681,31 +781,45 @@ func pullImage(name string, writer io.Writer) error {
 	return nil
 }
 
+// approvePull asks the user whether the missing image may be pulled,
+// unless ERIS_PULL_APPROVE is set.
+func approvePull(image string) error {
+	if os.Getenv("ERIS_PULL_APPROVE") == "true" {
+		logger.Printf("The docker image (%s) is not found locally.\nThe marmots are approved to pull from the repository on your behalf.\nThis could take a minute.\n", image)
+		return nil
+	}
+
+	var input string
+	logger.Printf("The docker image (%s) is not found locally.\nWould you like the marmots to pull it from the repository? (y/n) ", image)
+	fmt.Scanln(&input)
+
+	if input == "Y" || input == "y" || input == "YES" || input == "Yes" || input == "yes" {
+		logger.Debugf("\nUser assented to pull.\n")
+		return nil
+	}
+	logger.Debugf("\nUser refused to pull.\n")
+	return fmt.Errorf("Cannot start a container based on an image you will not let me pull.\n")
+}
+
 // ----------------------------------------------------------------------------
 // ---------------------    Container Core ------------------------------------
 // ----------------------------------------------------------------------------
 func createContainer(opts docker.CreateContainerOptions) (*docker.Container, error) {
-	dockerContainer, err := util.DockerClient.CreateContainer(opts)
+	endpoints, err := prepareNetworks(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerContainer, err := util.Backend.CreateContainer(opts)
 	if err != nil {
 		if err == docker.ErrNoSuchImage {
-			if os.Getenv("ERIS_PULL_APPROVE") != "true" {
-				var input string
-				logger.Printf("The docker image (%s) is not found locally.\nWould you like the marmots to pull it from the repository? (y/n) ", opts.Config.Image)
-				fmt.Scanln(&input)
-
-				if input == "Y" || input == "y" || input == "YES" || input == "Yes" || input == "yes" {
-					logger.Debugf("\nUser assented to pull.\n")
-				} else {
-					logger.Debugf("\nUser refused to pull.\n")
-					return nil, fmt.Errorf("Cannot start a container based on an image you will not let me pull.\n")
-				}
-			} else {
-				logger.Printf("The docker image (%s) is not found locally.\nThe marmots are approved to pull from the repository on your behalf.\nThis could take a minute.\n", opts.Config.Image)
+			if err := approvePull(opts.Config.Image); err != nil {
+				return nil, err
 			}
 			if err := pullImage(opts.Config.Image, nil); err != nil {
 				return nil, err
 			}
-			dockerContainer, err = util.DockerClient.CreateContainer(opts)
+			dockerContainer, err = util.Backend.CreateContainer(opts)
 			if err != nil {
 				return nil, err
 			}
@@ -713,36 +827,91 @@ func createContainer(opts docker.CreateContainerOptions) (*docker.Container, err
 			return nil, err
 		}
 	}
+
+	for network, endpoint := range endpoints {
+		logger.Debugf("Connecting to network =>	%s\n", network)
+		if err := util.Networks().ConnectNetwork(network, docker.NetworkConnectionOptions{
+			Container:      dockerContainer.ID,
+			EndpointConfig: endpoint,
+		}); err != nil {
+			if e2 := util.Backend.RemoveContainer(docker.RemoveContainerOptions{ID: dockerContainer.ID, Force: true}); e2 != nil {
+				logger.Errorf("Error removing %s: %v\n", opts.Name, e2)
+			}
+			return nil, err
+		}
+	}
 	return dockerContainer, nil
 }
 
+// prepareNetworks creates the user-defined networks the container joins
+// if they don't exist. The daemon only takes one network on creation,
+// so the others are left out of opts and returned, to be connected to
+// once the container is created.
+func prepareNetworks(opts *docker.CreateContainerOptions) (map[string]*docker.EndpointConfig, error) {
+	if opts.HostConfig == nil || !isUserNetwork(opts.HostConfig.NetworkMode) || util.Networks() == nil {
+		return nil, nil
+	}
+	primary := opts.HostConfig.NetworkMode
+	if err := util.EnsureNetwork(primary); err != nil {
+		return nil, err
+	}
+	if opts.NetworkingConfig == nil {
+		return nil, nil
+	}
+
+	endpoints := make(map[string]*docker.EndpointConfig)
+	created := make(map[string]*docker.EndpointConfig)
+	for network, endpoint := range opts.NetworkingConfig.EndpointsConfig {
+		if network == primary {
+			created[network] = endpoint
+			continue
+		}
+		if err := util.EnsureNetwork(network); err != nil {
+			return nil, err
+		}
+		endpoints[network] = endpoint
+	}
+	opts.NetworkingConfig = &docker.NetworkingConfig{EndpointsConfig: created}
+	return endpoints, nil
+}
+
 func startContainer(opts docker.CreateContainerOptions) error {
-	return util.DockerClient.StartContainer(opts.Name, opts.HostConfig)
+	return util.Backend.StartContainer(opts.Name, opts.HostConfig)
 }
 
 func startInteractiveContainer(opts docker.CreateContainerOptions) error {
-	// Set terminal into raw mode, and restore upon container exit.
-	savedState, err := term.SetRawTerminal(os.Stdin.Fd())
-	if err != nil {
-		logger.Infoln("Cannot set the terminal into raw mode")
-	} else {
-		defer term.RestoreTerminal(os.Stdin.Fd(), savedState)
-	}
+	return startAttachedContainer(opts, os.Stdin, config.GlobalConfig.Writer, config.GlobalConfig.ErrorWriter)
+}
 
-	// Trap signals so we can drop out of the container.
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, os.Kill)
-	go func() {
-		<-c
-		logger.Infof("\nCaught signal. Stopping container %s\n", opts.Name)
-		if err = stopContainer(opts.Name, 5); err != nil {
-			logger.Errorf("Error stopping container: %v\n", err)
+// startAttachedContainer starts the container with stdin (if not nil)
+// attached to its input and its output written to stdout and stderr.
+// The terminal is only put into raw mode and signals trapped when the
+// input is os.Stdin.
+func startAttachedContainer(opts docker.CreateContainerOptions, stdin io.Reader, stdout, stderr io.Writer) error {
+	if stdin == os.Stdin {
+		// Set terminal into raw mode, and restore upon container exit.
+		savedState, err := term.SetRawTerminal(os.Stdin.Fd())
+		if err != nil {
+			logger.Infoln("Cannot set the terminal into raw mode")
+		} else {
+			defer term.RestoreTerminal(os.Stdin.Fd(), savedState)
 		}
-	}()
+
+		// Trap signals so we can drop out of the container.
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, os.Kill)
+		go func() {
+			<-c
+			logger.Infof("\nCaught signal. Stopping container %s\n", opts.Name)
+			if err := stopContainer(opts.Name, 5); err != nil {
+				logger.Errorf("Error stopping container: %v\n", err)
+			}
+		}()
+	}
 
 	attached := make(chan struct{})
 	go func(chan struct{}) {
-		attachContainer(opts.Name, attached)
+		attachContainer(opts.Name, attached, stdin, stdout, stderr)
 	}(attached)
 
 	if err := startContainer(opts); err != nil {
@@ -763,33 +932,35 @@ func startInteractiveContainer(opts docker.CreateContainerOptions) error {
 	return nil
 }
 
-func attachContainer(id string, attached chan struct{}) error {
-	// Use a proxy pipe between os.Stdin and an attached container, so that
-	// when the reader end of the pipe is closed, os.Stdin is still open.
-	reader, writer := io.Pipe()
-	go func() {
-		io.Copy(writer, os.Stdin)
-	}()
-
+func attachContainer(id string, attached chan struct{}, stdin io.Reader, stdout, stderr io.Writer) error {
 	opts := docker.AttachToContainerOptions{
 		Container:    id,
-		InputStream:  reader,
-		OutputStream: config.GlobalConfig.Writer,
-		ErrorStream:  config.GlobalConfig.ErrorWriter,
+		OutputStream: stdout,
+		ErrorStream:  stderr,
 		Logs:         false,
 		Stream:       true,
-		Stdin:        true,
 		Stdout:       true,
 		Stderr:       true,
 		RawTerminal:  true,
 		Success:      attached,
 	}
 
-	return util.DockerClient.AttachToContainer(opts)
+	if stdin != nil {
+		// Use a proxy pipe between stdin and an attached container, so that
+		// when the reader end of the pipe is closed, stdin is still open.
+		reader, writer := io.Pipe()
+		go func() {
+			io.Copy(writer, stdin)
+		}()
+		opts.InputStream = reader
+		opts.Stdin = true
+	}
+
+	return util.Backend.AttachToContainer(opts)
 }
 
 func waitContainer(id string) error {
-	exitCode, err := util.DockerClient.WaitContainer(id)
+	exitCode, err := util.Backend.WaitContainer(id)
 	if exitCode != 0 {
 		err1 := fmt.Errorf("Container %s exited with status %d", id, exitCode)
 		if err != nil {
@@ -827,14 +998,14 @@ func logsContainer(id string, follow bool, tail string) error {
 		RawTerminal: true, // Usually true when the container contains a TTY.
 	}
 
-	if err := util.DockerClient.Logs(opts); err != nil {
+	if err := util.Backend.Logs(opts); err != nil {
 		return err
 	}
 	return nil
 }
 
 func inspectContainer(id, field string) error {
-	cont, err := util.DockerClient.InspectContainer(id)
+	cont, err := util.Backend.InspectContainer(id)
 	if err != nil {
 		return err
 	}
@@ -846,7 +1017,7 @@ func inspectContainer(id, field string) error {
 func stopContainer(id string, timeout uint) error {
 	logger.Debugf("\twith ContainerID =>\t%s\n", id)
 	logger.Debugf("\twith Timeout =>\t\t%d\n", timeout)
-	err := util.DockerClient.StopContainer(id, timeout)
+	err := util.Backend.StopContainer(id, timeout)
 	if err != nil {
 		return err
 	}
@@ -860,7 +1031,7 @@ func removeContainer(id string, volumes bool) error {
 		Force:         false,
 	}
 
-	err := util.DockerClient.RemoveContainer(opts)
+	err := util.Backend.RemoveContainer(opts)
 	if err != nil {
 		return err
 	}
@@ -868,8 +1039,11 @@ func removeContainer(id string, volumes bool) error {
 	return nil
 }
 
-func configureInteractiveContainer(srv *def.Service, ops *def.Operation) docker.CreateContainerOptions {
-	opts := configureServiceContainer(srv, ops)
+func configureInteractiveContainer(srv *def.Service, ops *def.Operation) (docker.CreateContainerOptions, error) {
+	opts, err := configureServiceContainer(srv, ops)
+	if err != nil {
+		return opts, err
+	}
 
 	opts.Name = "eris_interactive_" + opts.Name
 	opts.Config.User = "root"
@@ -907,10 +1081,25 @@ func configureInteractiveContainer(srv *def.Service, ops *def.Operation) docker.
 	// we expect to link to the main service container
 	opts.HostConfig.Links = srv.Links
 
-	return opts
+	// The short names belong to the service containers.
+	if opts.NetworkingConfig != nil {
+		for _, endpoint := range opts.NetworkingConfig.EndpointsConfig {
+			endpoint.Aliases = nil
+		}
+	}
+
+	return opts, nil
 }
 
-func configureServiceContainer(srv *def.Service, ops *def.Operation) docker.CreateContainerOptions {
+// configureServiceContainer returns the options of the service container.
+// Malformed ulimit, tmpfs and restart specs are errors (eris validate
+// reports them before the service is started).
+func configureServiceContainer(srv *def.Service, ops *def.Operation) (docker.CreateContainerOptions, error) {
+	if ServiceCheck != nil {
+		if err := ServiceCheck(srv, ops); err != nil {
+			return docker.CreateContainerOptions{}, err
+		}
+	}
 	if ops.ContainerNumber == 0 {
 		ops.ContainerNumber = 1
 	}
@@ -932,23 +1121,61 @@ func configureServiceContainer(srv *def.Service, ops *def.Operation) docker.Crea
 			Labels:          ops.Labels,
 			Image:           srv.Image,
 			NetworkDisabled: false,
+			StopSignal:      srv.StopSignal,
 		},
 		HostConfig: &docker.HostConfig{
 			Binds:           srv.Volumes,
 			Links:           srv.Links,
 			PublishAllPorts: ops.PublishAllPorts,
 			Privileged:      ops.Privileged,
-			ReadonlyRootfs:  false,
+			ReadonlyRootfs:  srv.ReadOnly,
 			DNS:             srv.DNS,
 			DNSSearch:       srv.DNSSearch,
+			ExtraHosts:      srv.ExtraHosts,
 			VolumesFrom:     srv.VolumesFrom,
 			CapAdd:          ops.CapAdd,
 			CapDrop:         ops.CapDrop,
+			SecurityOpt:     srv.SecurityOpt,
 			RestartPolicy:   docker.NeverRestart(),
-			NetworkMode:     "bridge",
+			Memory:          srv.MemLimit,
+			MemorySwap:      srv.MemSwapLimit,
+			CPUShares:       srv.CPUShares,
+			CPUSetCPUs:      srv.CPUSet,
+			CPUQuota:        srv.CPUQuota,
+			CPUPeriod:       srv.CPUPeriod,
+			BlkioWeight:     srv.BlkioWeight,
+			LogConfig: docker.LogConfig{
+				Type:   srv.LogDriver,
+				Config: srv.LogOpt,
+			},
 		},
 	}
 
+	if srv.PidsLimit != 0 {
+		pids := srv.PidsLimit
+		opts.HostConfig.PidsLimit = &pids
+	}
+
+	configureNetworks(&opts, srv, ops)
+
+	for _, spec := range srv.Ulimits {
+		ulimit, err := util.ParseUlimit(spec)
+		if err != nil {
+			return docker.CreateContainerOptions{}, fmt.Errorf("The marmots cannot set the ulimit %q of %s: %v", spec, srv.Name, err)
+		}
+		opts.HostConfig.Ulimits = append(opts.HostConfig.Ulimits, ulimit)
+	}
+	for _, spec := range srv.Tmpfs {
+		path, options, err := util.ParseTmpfs(spec)
+		if err != nil {
+			return docker.CreateContainerOptions{}, fmt.Errorf("The marmots cannot mount the tmpfs %q of %s: %v", spec, srv.Name, err)
+		}
+		if opts.HostConfig.Tmpfs == nil {
+			opts.HostConfig.Tmpfs = make(map[string]string)
+		}
+		opts.HostConfig.Tmpfs[path] = options
+	}
+
 	// some fields may be set in the dockerfile and we only want to overwrite if they are present in the service def
 	if srv.EntryPoint != "" {
 		opts.Config.Entrypoint = strings.Fields(srv.EntryPoint)
@@ -965,7 +1192,7 @@ func configureServiceContainer(srv *def.Service, ops *def.Operation) docker.Crea
 	} else if strings.Contains(ops.Restart, "max") {
 		times, err := strconv.Atoi(strings.Split(ops.Restart, ":")[1])
 		if err != nil {
-			return docker.CreateContainerOptions{}
+			return docker.CreateContainerOptions{}, fmt.Errorf("The marmots cannot read the restart policy %q of %s: %v", ops.Restart, srv.Name, err)
 		}
 		opts.HostConfig.RestartPolicy = docker.RestartOnFailure(times)
 	}
@@ -1008,7 +1235,95 @@ func configureServiceContainer(srv *def.Service, ops *def.Operation) docker.Crea
 		opts.Config.Volumes[strings.Split(vol, ":")[1]] = struct{}{}
 	}
 
-	return opts
+	return opts, nil
+}
+
+// setupData creates the data container or the data volume of the
+// service if it does not exist and mounts it in the service container
+// (optsServ). The data is kept in a volume if srv.DataVolume says so or
+// if it has been migrated to one, unless a data container remains.
+func setupData(srv *def.Service, ops *def.Operation, optsServ *docker.CreateContainerOptions) error {
+	_, containerExists := util.ParseContainers(ops.DataContainerName, true)
+	volumeExists := util.DataVolumeExists(ops.DataContainerName)
+
+	if !containerExists && (volumeExists || srv.DataVolume && util.Volumes() != nil) {
+		if volumeExists {
+			logger.Infoln("Data volume already exists, am not creating.")
+		} else {
+			labels := util.SetLabel(copyLabels(ops.Labels), def.LabelService, optsServ.Name)
+			if err := util.CreateDataVolume(ops.DataContainerName, labels); err != nil {
+				return err
+			}
+		}
+		optsServ.HostConfig.Binds = append(append([]string{}, optsServ.HostConfig.Binds...),
+			ops.DataContainerName+":"+dirs.ErisContainerRoot)
+		return nil
+	}
+	if srv.DataVolume && containerExists {
+		logger.Printf("The data of %s is in a data container. Move it to a volume with [eris data migrate %s].\n", srv.Name, srv.Name)
+	}
+
+	optsData, err := configureDataContainer(srv, ops, optsServ)
+	if err != nil {
+		return err
+	}
+	if containerExists {
+		logger.Infoln("Data container already exists, am not creating.")
+		return nil
+	}
+	logger.Infoln("Data container does not exist, creating.")
+	_, err = createContainer(optsData)
+	return err
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	copied := make(map[string]string)
+	for k, v := range labels {
+		copied[k] = v
+	}
+	return copied
+}
+
+// configureNetworks puts the container on the network srv.Net (the eris
+// network by default) and the srv.Networks networks, known by the short
+// name of the service on each. A chain container is also known as
+// "chain" on the network of the chain. The Docker network modes (host,
+// none, bridge, and container:NAME) are used as they are.
+func configureNetworks(opts *docker.CreateContainerOptions, srv *def.Service, ops *def.Operation) {
+	if srv.Net != "" && !isUserNetwork(srv.Net) {
+		opts.HostConfig.NetworkMode = srv.Net
+		return
+	}
+	if util.Networks() == nil {
+		opts.HostConfig.NetworkMode = "bridge"
+		return
+	}
+
+	primary := srv.Net
+	if primary == "" {
+		primary = util.DefaultNetwork
+	}
+	opts.HostConfig.NetworkMode = primary
+	opts.NetworkingConfig = &docker.NetworkingConfig{
+		EndpointsConfig: make(map[string]*docker.EndpointConfig),
+	}
+	for _, network := range append([]string{primary}, srv.Networks...) {
+		aliases := []string{srv.Name}
+		if ops.ContainerType == def.TypeChain && network == util.ChainNetwork(srv.Name) {
+			aliases = append(aliases, "chain")
+		}
+		opts.NetworkingConfig.EndpointsConfig[network] = &docker.EndpointConfig{Aliases: aliases}
+	}
+}
+
+// isUserNetwork returns true if the network mode mode is a user-defined
+// network rather than one of Docker's own.
+func isUserNetwork(mode string) bool {
+	switch mode {
+	case "", "host", "none", "bridge", "default":
+		return false
+	}
+	return !strings.HasPrefix(mode, "container:")
 }
 
 func configureVolumesFromContainer(ops *def.Operation, service *def.Service) docker.CreateContainerOptions {
@@ -1031,6 +1346,12 @@ func configureVolumesFromContainer(ops *def.Operation, service *def.Service) doc
 		},
 	}
 
+	// Data kept in a volume is mounted where data containers keep it.
+	if util.DataVolumeExists(ops.DataContainerName) {
+		opts.HostConfig.VolumesFrom = nil
+		opts.HostConfig.Binds = []string{ops.DataContainerName + ":" + dirs.ErisContainerRoot}
+	}
+
 	if ops.Interactive {
 		opts.Config.OpenStdin = true
 		opts.Config.Cmd = []string{"/bin/bash"}
@@ -1046,6 +1367,11 @@ func configureVolumesFromContainer(ops *def.Operation, service *def.Service) doc
 		opts.Config.Env = service.Environment
 		opts.HostConfig.Links = service.Links
 		opts.Config.Entrypoint = strings.Fields(service.EntryPoint)
+
+		// Links only work within a network.
+		if util.Networks() != nil {
+			opts.HostConfig.NetworkMode = util.DefaultNetwork
+		}
 	}
 
 	logger.Debugf("configurVolumesFromContainer =>\t%v:%v\n", opts.Config.Cmd, opts.Config.Entrypoint)
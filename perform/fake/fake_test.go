@@ -0,0 +1,21 @@
+// Package fake tests the perform package against util.FakeBackend, so
+// that they run without a Docker daemon (unlike those of perform).
+package fake
+
+import (
+	"os"
+	"testing"
+
+	tests "github.com/eris-ltd/eris-cli/testutils"
+)
+
+func TestMain(m *testing.M) {
+	erisDir, err := tests.FakeInit("eris_perform_fake")
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
@@ -0,0 +1,103 @@
+package fake
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/perform"
+	tests "github.com/eris-ltd/eris-cli/testutils"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+func TestWaitHealthy(t *testing.T) {
+	fake, restore := tests.UseFake()
+	defer restore()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	// a port nothing listens on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, closed, _ := net.SplitHostPort(l.Addr().String())
+	l.Close()
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "healthy"
+	srv.Operations.SrvContainerName = util.ServiceContainersName("healthy", 1)
+	if _, err := fake.CreateContainer(docker.CreateContainerOptions{
+		Name:   srv.Operations.SrvContainerName,
+		Config: &docker.Config{Image: "quay.io/eris/base"},
+		HostConfig: &docker.HostConfig{
+			PortBindings: map[docker.Port][]docker.PortBinding{
+				"80/tcp": {{HostIP: "127.0.0.1", HostPort: port}},
+				"81/tcp": {{HostIP: "127.0.0.1", HostPort: closed}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	check := &def.HealthCheck{TCPPort: "80", Interval: "10ms", Retries: 2}
+	if err := perform.DockerWaitHealthy(srv.Service, srv.Operations, check); err == nil {
+		t.Fatalf("expected an error waiting for a stopped container")
+	}
+
+	if err := fake.StartContainer(srv.Operations.SrvContainerName, nil); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	for _, check := range []*def.HealthCheck{
+		nil,
+		{TCPPort: "80", Interval: "10ms", Retries: 2},
+		{HTTPGet: "80/status", Interval: "10ms", Retries: 2},
+		{ExecCommand: "true", Interval: "10ms", Retries: 2},
+	} {
+		if err := perform.DockerWaitHealthy(srv.Service, srv.Operations, check); err != nil {
+			t.Fatalf("expected %v to pass, got %v", check, err)
+		}
+	}
+
+	for _, check := range []*def.HealthCheck{
+		{HTTPGet: "80/missing", Interval: "10ms", Retries: 2},
+		{TCPPort: "81", Interval: "10ms", Timeout: "100ms", Retries: 2},
+		{TCPPort: "80", Interval: "soon"},
+	} {
+		if err := perform.DockerWaitHealthy(srv.Service, srv.Operations, check); err == nil {
+			t.Fatalf("expected %v to fail", check)
+		}
+	}
+
+	fake.SetExecExitCode(srv.Operations.SrvContainerName, 1)
+	check = &def.HealthCheck{ExecCommand: "false", Interval: "10ms", Retries: 2}
+	if err := perform.DockerWaitHealthy(srv.Service, srv.Operations, check); err == nil || !strings.Contains(err.Error(), "exited with 1") {
+		t.Fatalf("expected the exec check to fail, got %v", err)
+	}
+}
+
+func TestWaitHealthyUnsupported(t *testing.T) {
+	_, restore := tests.UseFake()
+	defer restore()
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "remote"
+	check := &def.HealthCheck{TCPPort: "80"}
+
+	perform.Agent = perform.NewHTTPClient("localhost:1")
+	defer func() { perform.Agent = nil }()
+	if err := perform.DockerWaitHealthy(srv.Service, srv.Operations, check); err == nil || !strings.Contains(err.Error(), "agent") {
+		t.Fatalf("expected health checks through an agent to fail, got %v", err)
+	}
+}
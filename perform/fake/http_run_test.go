@@ -0,0 +1,101 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/perform"
+	tests "github.com/eris-ltd/eris-cli/testutils"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// useAgent points perform.Agent at an agent which records the requests
+// it gets and answers them with status until the returned function is
+// called.
+func useAgent(t *testing.T, status int) (map[string]*perform.HTTPRequest, func()) {
+	requests := make(map[string]*perform.HTTPRequest)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("%s: expected the token, got %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		req := &perform.HTTPRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Errorf("%s: %v", r.URL.Path, err)
+		}
+		requests[r.URL.Path] = req
+
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			json.NewEncoder(w).Encode(perform.HTTPError{Error: "the marmots are asleep"})
+		}
+	}))
+
+	saved := perform.Agent
+	perform.Agent = perform.NewHTTPClient(server.URL)
+	perform.Agent.Token = "secret"
+	return requests, func() {
+		perform.Agent = saved
+		server.Close()
+	}
+}
+
+func TestAgentRouting(t *testing.T) {
+	fake, restore := tests.UseFake()
+	defer restore()
+	requests, restoreAgent := useAgent(t, http.StatusOK)
+	defer restoreAgent()
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "keys"
+	srv.Service.Image = "quay.io/eris/keys"
+	srv.Operations.SrvContainerName = "eris_service_keys_1"
+
+	if err := perform.DockerRemove(srv.Service, srv.Operations, true, true); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := perform.DockerRebuild(srv.Service, srv.Operations, true, 7); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if err := perform.DockerPull(srv.Service, srv.Operations); err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+	if err := perform.DockerStop(srv.Service, srv.Operations, 3); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+
+	if req := requests["/services/remove"]; req == nil || !req.Data || !req.Volumes || req.Operation.SrvContainerName != "eris_service_keys_1" {
+		t.Fatalf("expected a remove request with data and volumes, got %+v", req)
+	}
+	if req := requests["/services/rebuild"]; req == nil || !req.Pull || req.Timeout != 7 || req.Service.Image != "quay.io/eris/keys" {
+		t.Fatalf("expected a rebuild request pulling the image, got %+v", req)
+	}
+	if req := requests["/services/pull"]; req == nil || req.Service.Name != "keys" {
+		t.Fatalf("expected a pull request, got %+v", req)
+	}
+	if req := requests["/services/stop"]; req == nil || req.Timeout != 3 {
+		t.Fatalf("expected a stop request, got %+v", req)
+	}
+
+	// Nothing may have been done locally.
+	if containers, _ := fake.ListContainers(docker.ListContainersOptions{All: true}); len(containers) != 0 {
+		t.Fatalf("expected no local containers, got %v", containers)
+	}
+	if images := fake.Images(); len(images) != 0 {
+		t.Fatalf("expected no local pulls, got %v", images)
+	}
+}
+
+func TestAgentError(t *testing.T) {
+	_, restoreAgent := useAgent(t, http.StatusInternalServerError)
+	defer restoreAgent()
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "keys"
+	if err := perform.DockerRemove(srv.Service, srv.Operations, false, false); err == nil || err.Error() != "the marmots are asleep" {
+		t.Fatalf("expected the agent error, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package fake
+
+import (
+	"fmt"
+	"testing"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/perform"
+	tests "github.com/eris-ltd/eris-cli/testutils"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+func TestRunStopRebuildRemove(t *testing.T) {
+	fake, restore := tests.UseFake()
+	defer restore()
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "marmot"
+	srv.Service.Image = "quay.io/eris/base"
+	srv.Service.AutoData = true
+	srv.Operations.ContainerNumber = 1
+	srv.Operations.SrvContainerName = util.ServiceContainersName("marmot", 1)
+	srv.Operations.DataContainerName = util.DataContainersName("marmot", 1)
+
+	if err := perform.DockerRunService(srv.Service, srv.Operations); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if _, running := perform.ContainerRunning(srv.Operations); !running {
+		t.Fatalf("expected the service to be running")
+	}
+	if _, exists := perform.DataContainerExists(srv.Operations); !exists {
+		t.Fatalf("expected the data container to be created")
+	}
+
+	before, _ := fake.InspectContainer(srv.Operations.SrvContainerName)
+	if err := perform.DockerRebuild(srv.Service, srv.Operations, true, 5); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	after, err := fake.InspectContainer(srv.Operations.SrvContainerName)
+	if err != nil || after.ID == before.ID || !after.State.Running {
+		t.Fatalf("expected a new running container, got %v (%v)", after, err)
+	}
+
+	if err := perform.DockerStop(srv.Service, srv.Operations, 5); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if _, running := perform.ContainerRunning(srv.Operations); running {
+		t.Fatalf("expected the service to be stopped")
+	}
+
+	if err := perform.DockerRemove(srv.Service, srv.Operations, true, true); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, exists := perform.ContainerExists(srv.Operations); exists {
+		t.Fatalf("expected the service to be removed")
+	}
+	if _, exists := perform.DataContainerExists(srv.Operations); exists {
+		t.Fatalf("expected the data container to be removed")
+	}
+}
+
+func TestRunServiceBadSpecs(t *testing.T) {
+	fake, restore := tests.UseFake()
+	defer restore()
+
+	for _, bad := range []struct{ ulimits, tmpfs []string }{
+		{ulimits: []string{"nofile"}},
+		{ulimits: []string{"nofile=4096:1024"}},
+		{tmpfs: []string{"run:rw"}},
+	} {
+		srv := def.BlankServiceDefinition()
+		srv.Service.Name = "limited"
+		srv.Service.Image = "quay.io/eris/base"
+		srv.Service.Ulimits = bad.ulimits
+		srv.Service.Tmpfs = bad.tmpfs
+		srv.Operations.SrvContainerName = util.ServiceContainersName("limited", 1)
+		if err := perform.DockerRunService(srv.Service, srv.Operations); err == nil {
+			t.Fatalf("expected an error running with the ulimits %v and tmpfs %v", bad.ulimits, bad.tmpfs)
+		}
+		if _, err := fake.InspectContainer(srv.Operations.SrvContainerName); err == nil {
+			t.Fatalf("expected no container for the ulimits %v and tmpfs %v", bad.ulimits, bad.tmpfs)
+		}
+	}
+}
+
+// failingConnect is a fake backend which cannot connect containers to
+// networks.
+type failingConnect struct {
+	*util.FakeBackend
+}
+
+func (f failingConnect) ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	return fmt.Errorf("cannot connect to %s", id)
+}
+
+func TestRunServiceConnectFails(t *testing.T) {
+	fake, restore := tests.UseFake()
+	defer restore()
+	util.Backend = failingConnect{fake}
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "marmot"
+	srv.Service.Image = "quay.io/eris/base"
+	srv.Service.Networks = []string{util.ChainNetwork("mychain")}
+	srv.Operations.ContainerNumber = 1
+	srv.Operations.SrvContainerName = util.ServiceContainersName("marmot", 1)
+
+	if err := perform.DockerRunService(srv.Service, srv.Operations); err == nil {
+		t.Fatalf("expected an error connecting to the chain network")
+	}
+	if _, exists := perform.ContainerExists(srv.Operations); exists {
+		t.Fatalf("expected the container to be removed")
+	}
+}
@@ -0,0 +1,186 @@
+package perform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// Health check defaults.
+const (
+	healthInterval = time.Second
+	healthTimeout  = 5 * time.Second
+	healthRetries  = 30
+)
+
+// DockerWaitHealthy blocks until the probes of check pass against the
+// running ops.SrvContainerName container, trying check.Retries times
+// every check.Interval. It returns an error if the container stops or
+// the probes still fail after the last try. An empty check passes at
+// once.
+func DockerWaitHealthy(srv *def.Service, ops *def.Operation, check *def.HealthCheck) error {
+	return DockerWaitHealthyContext(context.Background(), srv, ops, check)
+}
+
+// DockerWaitHealthyContext is DockerWaitHealthy which gives up waiting
+// once ctx is done.
+func DockerWaitHealthyContext(ctx context.Context, srv *def.Service, ops *def.Operation, check *def.HealthCheck) error {
+	if check.IsEmpty() {
+		return nil
+	}
+	if Agent != nil {
+		return fmt.Errorf("The marmots cannot run the health check of %s through the agent %s. Please remove its [healthcheck] to start it remotely", srv.Name, Agent.Host)
+	}
+
+	interval, timeout, retries, err := healthSettings(check)
+	if err != nil {
+		return fmt.Errorf("Bad healthcheck of %s: %v", srv.Name, err)
+	}
+
+	logger.Infof("Waiting for service =>\t\t%s\n", srv.Name)
+	for i := 1; ; i++ {
+		cont, running := ContainerRunning(ops)
+		if !running {
+			return fmt.Errorf("The container of %s stopped before it was ready. Please check its logs.", srv.Name)
+		}
+
+		err = probe(cont.ID, check, timeout)
+		if err == nil {
+			logger.Infof("Service is ready =>\t\t%s\n", srv.Name)
+			return nil
+		}
+		logger.Debugf("Health check %d of %d =>\t%s:%v\n", i, retries, srv.Name, err)
+
+		if i >= retries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("The marmots stopped waiting for %s: %v", srv.Name, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+
+	return fmt.Errorf("The marmots gave up waiting for %s to become ready after %d tries: %v", srv.Name, retries, err)
+}
+
+func healthSettings(check *def.HealthCheck) (interval, timeout time.Duration, retries int, err error) {
+	interval, timeout, retries = healthInterval, healthTimeout, healthRetries
+
+	if check.Interval != "" {
+		if interval, err = time.ParseDuration(check.Interval); err != nil {
+			return
+		}
+	}
+	if check.Timeout != "" {
+		if timeout, err = time.ParseDuration(check.Timeout); err != nil {
+			return
+		}
+	}
+	if check.Retries > 0 {
+		retries = check.Retries
+	}
+	return
+}
+
+// probe runs each of the probes of check once.
+func probe(id string, check *def.HealthCheck, timeout time.Duration) error {
+	if check.TCPPort != "" {
+		addr, err := util.ContainerPortAddress(id, check.TCPPort)
+		if err != nil {
+			return err
+		}
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return err
+		}
+		conn.Close()
+	}
+
+	if check.HTTPGet != "" {
+		parts := strings.SplitN(strings.TrimPrefix(check.HTTPGet, ":"), "/", 2)
+		addr, err := util.ContainerPortAddress(id, parts[0])
+		if err != nil {
+			return err
+		}
+		url := "http://" + addr + "/"
+		if len(parts) == 2 {
+			url += parts[1]
+		}
+
+		client := &http.Client{Timeout: timeout}
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+	}
+
+	if check.ExecCommand != "" {
+		if err := probeExec(id, check.ExecCommand, timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// probeExec runs command in the container id. Natively run containers
+// run it on this host.
+func probeExec(id, command string, timeout time.Duration) error {
+	execs := util.Execs()
+	if execs == nil {
+		return fmt.Errorf("The marmots cannot run exec_command health checks with this backend")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- execProbe(execs, id, command) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("%s timed out after %v", command, timeout)
+	}
+}
+
+func execProbe(execs util.ExecBackend, id, command string) error {
+	e, err := execs.CreateExec(docker.CreateExecOptions{
+		Container:    id,
+		Cmd:          []string{"sh", "-c", command},
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := new(bytes.Buffer)
+	if err := execs.StartExec(e.ID, docker.StartExecOptions{
+		OutputStream: out,
+		ErrorStream:  out,
+	}); err != nil {
+		return err
+	}
+
+	inspect, err := execs.InspectExec(e.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("%s exited with %d: %s", command, inspect.ExitCode, bytes.TrimSpace(out.Bytes()))
+	}
+	return nil
+}
@@ -1,3 +1,287 @@
 package perform
 
-import ()
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// Agent, if set, makes DockerRunService, DockerExecService, DockerStop,
+// DockerRemove, DockerRebuild, DockerPull, DockerLogs and DockerInspect
+// send their operations to a remote eris agent (see `eris agent`)
+// instead of the local Docker daemon. It is also a
+// util.ContainerBackend answering the read-only queries (list, inspect,
+// version) eris needs to resolve the state of remote services.
+var Agent *HTTPClient
+
+// HTTPRequest is the wire payload of an agent call: the same service and
+// operation structures perform works with locally plus the few scalar
+// arguments the perform functions take.
+type HTTPRequest struct {
+	Service   *def.Service   `json:"service"`
+	Operation *def.Operation `json:"operation"`
+
+	Timeout uint   `json:"timeout,omitempty"`
+	Follow  bool   `json:"follow,omitempty"`
+	Tail    string `json:"tail,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Data    bool   `json:"data,omitempty"`
+	Volumes bool   `json:"volumes,omitempty"`
+	Pull    bool   `json:"pull,omitempty"`
+}
+
+// HTTPError is the body the agent returns with a non-2xx status.
+type HTTPError struct {
+	Error string `json:"error"`
+}
+
+// HTTPClient talks to an eris agent over HTTP/JSON.
+type HTTPClient struct {
+	Host string
+	// Token, if set, is sent to the agent as "Authorization: Bearer TOKEN".
+	Token  string
+	client *http.Client
+}
+
+// NewHTTPClient returns a client for the agent at host. The scheme
+// defaults to http when not given. The agent token is taken from the
+// ERIS_AGENT_TOKEN environment variable.
+func NewHTTPClient(host string) *HTTPClient {
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	return &HTTPClient{
+		Host:   strings.TrimSuffix(host, "/"),
+		Token:  os.Getenv("ERIS_AGENT_TOKEN"),
+		client: &http.Client{},
+	}
+}
+
+// RunService starts a service or a chain container on the agent.
+// See DockerRunService for the meaning of the srv and ops fields.
+func (h *HTTPClient) RunService(srv *def.Service, ops *def.Operation) error {
+	logger.Infof("Starting Remote Service =>\t%s:%s\n", h.Host, srv.Name)
+	return h.call("/services/run", &HTTPRequest{Service: srv, Operation: ops}, nil)
+}
+
+// ExecService runs ops.Args in a new container on the agent and streams
+// its output back. Unlike DockerExecService, stdin is not forwarded.
+func (h *HTTPClient) ExecService(srv *def.Service, ops *def.Operation) error {
+	logger.Infof("Exec Remote Service =>\t\t%s:%s:%v\n", h.Host, srv.Name, ops.Args)
+	return h.call("/services/exec", &HTTPRequest{Service: srv, Operation: ops}, writer())
+}
+
+// StopService stops the ops.SrvContainerName container on the agent.
+func (h *HTTPClient) StopService(srv *def.Service, ops *def.Operation, timeout uint) error {
+	logger.Infof("Stopping Remote Service =>\t%s:%s\n", h.Host, srv.Name)
+	return h.call("/services/stop", &HTTPRequest{Service: srv, Operation: ops, Timeout: timeout}, nil)
+}
+
+// RemoveService removes the ops.SrvContainerName container on the
+// agent. See DockerRemove for the meaning of withData and volumes.
+func (h *HTTPClient) RemoveService(srv *def.Service, ops *def.Operation, withData, volumes bool) error {
+	logger.Infof("Removing Remote Service =>\t%s:%s\n", h.Host, srv.Name)
+	return h.call("/services/remove", &HTTPRequest{Service: srv, Operation: ops, Data: withData, Volumes: volumes}, nil)
+}
+
+// RebuildService recreates the ops.SrvContainerName container on the
+// agent, pulling its image first if pull is true.
+func (h *HTTPClient) RebuildService(srv *def.Service, ops *def.Operation, pull bool, timeout uint) error {
+	logger.Infof("Rebuilding Remote Service =>\t%s:%s\n", h.Host, srv.Name)
+	return h.call("/services/rebuild", &HTTPRequest{Service: srv, Operation: ops, Pull: pull, Timeout: timeout}, nil)
+}
+
+// PullService pulls the image of the service on the agent, restarting
+// its container if it was running.
+func (h *HTTPClient) PullService(srv *def.Service, ops *def.Operation) error {
+	logger.Infof("Pulling Remote Service =>\t%s:%s\n", h.Host, srv.Name)
+	return h.call("/services/pull", &HTTPRequest{Service: srv, Operation: ops}, nil)
+}
+
+// LogsService streams the logs of the ops.SrvContainerName container.
+func (h *HTTPClient) LogsService(srv *def.Service, ops *def.Operation, follow bool, tail string) error {
+	logger.Infof("Getting Remote Logs =>\t\t%s:%s\n", h.Host, srv.Name)
+	return h.call("/services/logs", &HTTPRequest{Service: srv, Operation: ops, Follow: follow, Tail: tail}, writer())
+}
+
+// InspectService displays the inspection report (see DockerInspect)
+// produced by the agent.
+func (h *HTTPClient) InspectService(srv *def.Service, ops *def.Operation, field string) error {
+	logger.Infof("Inspecting Remote Service =>\t%s:%s\n", h.Host, srv.Name)
+	return h.call("/services/inspect", &HTTPRequest{Service: srv, Operation: ops, Field: field}, writer())
+}
+
+func (h *HTTPClient) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	query := url.Values{}
+	if opts.All {
+		query.Set("all", "true")
+	}
+	for _, label := range opts.Filters["label"] {
+		query.Add("label", label)
+	}
+
+	var containers []docker.APIContainers
+	if err := h.get("/containers?"+query.Encode(), &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+func (h *HTTPClient) InspectContainer(id string) (*docker.Container, error) {
+	container := &docker.Container{}
+	if err := h.get("/containers/"+url.QueryEscape(id), container); err != nil {
+		if err == errNotFound {
+			return nil, &docker.NoSuchContainer{ID: id}
+		}
+		return nil, err
+	}
+	return container, nil
+}
+
+func (h *HTTPClient) Version() (*docker.Env, error) {
+	env := &docker.Env{}
+	if err := h.get("/version", env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// The container-level operations are not exposed by the agent; the
+// service-level calls above are used instead.
+func (h *HTTPClient) CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error) {
+	return nil, h.unsupported("create")
+}
+
+func (h *HTTPClient) StartContainer(id string, hostConfig *docker.HostConfig) error {
+	return h.unsupported("start")
+}
+
+func (h *HTTPClient) StopContainer(id string, timeout uint) error {
+	return h.unsupported("stop")
+}
+
+func (h *HTTPClient) RemoveContainer(opts docker.RemoveContainerOptions) error {
+	return h.unsupported("remove")
+}
+
+func (h *HTTPClient) WaitContainer(id string) (int, error) {
+	return 0, h.unsupported("wait for")
+}
+
+func (h *HTTPClient) AttachToContainer(opts docker.AttachToContainerOptions) error {
+	return h.unsupported("attach to")
+}
+
+func (h *HTTPClient) Logs(opts docker.LogsOptions) error {
+	return h.unsupported("get logs of")
+}
+
+func (h *HTTPClient) UploadToContainer(id string, opts docker.UploadToContainerOptions) error {
+	return h.unsupported("upload to")
+}
+
+func (h *HTTPClient) DownloadFromContainer(id string, opts docker.DownloadFromContainerOptions) error {
+	return h.unsupported("download from")
+}
+
+func (h *HTTPClient) PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error {
+	return h.unsupported("pull images for")
+}
+
+var errNotFound = errors.New("not found")
+
+func (h *HTTPClient) unsupported(what string) error {
+	return fmt.Errorf("The marmots cannot %s individual containers on the remote agent %s", what, h.Host)
+}
+
+// call POSTs req to the agent. The response body is copied to out if
+// it is not nil.
+func (h *HTTPClient) call(path string, req *HTTPRequest, out io.Writer) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("Calling the agent =>\t\t%s%s\n", h.Host, path)
+	request, err := h.request("POST", path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("The marmots could not reach the agent at %s: %v", h.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	if out != nil {
+		_, err = io.Copy(out, resp.Body)
+	}
+	return err
+}
+
+func (h *HTTPClient) get(path string, v interface{}) error {
+	logger.Debugf("Querying the agent =>\t\t%s%s\n", h.Host, path)
+	request, err := h.request("GET", path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("The marmots could not reach the agent at %s: %v", h.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (h *HTTPClient) request(method, path string, body io.Reader) (*http.Request, error) {
+	request, err := http.NewRequest(method, h.Host+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if h.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+h.Token)
+	}
+	return request, nil
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var e HTTPError
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || e.Error == "" {
+		return fmt.Errorf("The agent responded with %s", resp.Status)
+	}
+	return errors.New(e.Error)
+}
+
+func writer() io.Writer {
+	if config.GlobalConfig != nil && config.GlobalConfig.Writer != nil {
+		return config.GlobalConfig.Writer
+	}
+	return os.Stdout
+}
@@ -0,0 +1,204 @@
+// Package native tests perform.NativeBackend, which runs services as
+// host processes, so that they run without a Docker daemon (unlike
+// those of perform).
+package native
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eris-ltd/eris-cli/perform"
+	tests "github.com/eris-ltd/eris-cli/testutils"
+
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	var err error
+	erisDir, err = tests.FakeInit("eris_native")
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func TestStartStopInspect(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test processes need sh")
+	}
+	n := perform.NewNativeBackend()
+
+	volume := filepath.Join(erisDir, "volume")
+	if _, err := n.CreateContainer(docker.CreateContainerOptions{
+		Name: "native_sleeper",
+		Config: &docker.Config{
+			Entrypoint: []string{"sh", "-c", "echo $ERIS $VOLUME $OTHER; exec sleep 30"},
+			Env: []string{
+				"VOLUME=/data/keys",
+				"OTHER=/database/keys",
+			},
+		},
+		HostConfig: &docker.HostConfig{Binds: []string{volume + ":/data"}},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := n.CreateContainer(docker.CreateContainerOptions{Name: "native_sleeper", Config: &docker.Config{}}); err != docker.ErrContainerAlreadyExists {
+		t.Fatalf("expected the container to exist, got %v", err)
+	}
+
+	if err := n.StartContainer("native_sleeper", nil); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	c, err := n.InspectContainer("native_sleeper")
+	if err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+	if !c.State.Running || c.State.Pid <= 0 {
+		t.Fatalf("expected a running process, got %+v", c.State)
+	}
+	if err := n.StartContainer("native_sleeper", nil); err == nil {
+		t.Fatalf("expected an error starting a running process")
+	}
+
+	data := filepath.Join(erisDir, "native", "native_sleeper", "data")
+	want := data + " " + volume + "/keys /database/keys\n"
+	var logs bytes.Buffer
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		logs.Reset()
+		if err := n.Logs(docker.LogsOptions{Container: "native_sleeper", OutputStream: &logs}); err != nil {
+			t.Fatalf("logs: %v", err)
+		}
+		if logs.Len() > 0 {
+			break
+		}
+	}
+	if logs.String() != want {
+		t.Fatalf("expected the paths to be mapped to %q, got %q", want, logs.String())
+	}
+
+	containers, err := n.ListContainers(docker.ListContainersOptions{})
+	if err != nil || len(containers) != 1 || containers[0].Names[0] != "/native_sleeper" {
+		t.Fatalf("expected the running process to be listed, got %v (%v)", containers, err)
+	}
+
+	if err := n.StopContainer("native_sleeper", 5); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if c, err = n.InspectContainer("native_sleeper"); err != nil || c.State.Running || c.State.Pid != 0 {
+		t.Fatalf("expected a stopped process, got %+v (%v)", c.State, err)
+	}
+	if err := n.StopContainer("native_sleeper", 5); err == nil {
+		t.Fatalf("expected an error stopping a stopped process")
+	}
+
+	if err := n.RemoveContainer(docker.RemoveContainerOptions{ID: "native_sleeper"}); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := n.InspectContainer("native_sleeper"); err == nil {
+		t.Fatalf("expected the container to be removed")
+	}
+}
+
+func TestWaitAndExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test processes need sh")
+	}
+	n := perform.NewNativeBackend()
+
+	if _, err := n.CreateContainer(docker.CreateContainerOptions{
+		Name:   "native_exiter",
+		Config: &docker.Config{Entrypoint: []string{"sh", "-c", "exit 3"}},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	defer n.RemoveContainer(docker.RemoveContainerOptions{ID: "native_exiter", Force: true})
+	if err := n.StartContainer("native_exiter", nil); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if code, err := n.WaitContainer("native_exiter"); err != nil || code != 3 {
+		t.Fatalf("expected the exit code 3, got %d (%v)", code, err)
+	}
+	if _, err := n.CreateExec(docker.CreateExecOptions{Container: "native_exiter", Cmd: []string{"true"}}); err == nil {
+		t.Fatalf("expected an error exec'ing in a stopped process")
+	}
+
+	if _, err := n.CreateContainer(docker.CreateContainerOptions{
+		Name:   "native_execer",
+		Config: &docker.Config{Entrypoint: []string{"sleep", "30"}},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := n.StartContainer("native_execer", nil); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer n.RemoveContainer(docker.RemoveContainerOptions{ID: "native_execer", Force: true})
+
+	exec, err := n.CreateExec(docker.CreateExecOptions{Container: "native_execer", Cmd: []string{"sh", "-c", "pwd; exit 4"}})
+	if err != nil {
+		t.Fatalf("create exec: %v", err)
+	}
+	var out bytes.Buffer
+	if err := n.StartExec(exec.ID, docker.StartExecOptions{OutputStream: &out, ErrorStream: &out}); err != nil {
+		t.Fatalf("start exec: %v", err)
+	}
+	inspect, err := n.InspectExec(exec.ID)
+	if err != nil || inspect.ExitCode != 4 {
+		t.Fatalf("expected the exit code 4, got %+v (%v)", inspect, err)
+	}
+	if dir := filepath.Join(erisDir, "native", "native_execer", "data"); strings.TrimSpace(out.String()) != dir {
+		t.Fatalf("expected the exec to run in %s, got %q", dir, out.String())
+	}
+}
+
+func TestUploadDownload(t *testing.T) {
+	n := perform.NewNativeBackend()
+
+	if _, err := n.CreateContainer(docker.CreateContainerOptions{
+		Name:   "native_files",
+		Config: &docker.Config{},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	defer n.RemoveContainer(docker.RemoveContainerOptions{ID: "native_files"})
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	content := []byte("marmots")
+	tw.WriteHeader(&tar.Header{Name: "genesis.json", Mode: 0644, Size: int64(len(content))})
+	tw.Write(content)
+	tw.Close()
+
+	dest := dirs.ErisContainerRoot + "/chains/mychain"
+	if err := n.UploadToContainer("native_files", docker.UploadToContainerOptions{Path: dest, InputStream: &archive}); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	file := filepath.Join(erisDir, "native", "native_files", "data", "chains", "mychain", "genesis.json")
+	if got, err := ioutil.ReadFile(file); err != nil || string(got) != "marmots" {
+		t.Fatalf("expected the file to be uploaded to the data directory, got %q (%v)", got, err)
+	}
+
+	var out bytes.Buffer
+	if err := n.DownloadFromContainer("native_files", docker.DownloadFromContainerOptions{Path: dest + "/genesis.json", OutputStream: &out}); err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	tr := tar.NewReader(&out)
+	if header, err := tr.Next(); err != nil || header.Name != "genesis.json" {
+		t.Fatalf("expected the file in the archive, got %v (%v)", header, err)
+	}
+	if err := n.DownloadFromContainer("native_files", docker.DownloadFromContainerOptions{Path: dest + "x", OutputStream: &out}); err == nil {
+		t.Fatalf("expected an error downloading a missing file")
+	}
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+// +build !windows
+
+package perform
+
+import (
+	"os"
+	"syscall"
+)
+
+// pidAlive returns true if the process pid exists (signal 0 checks
+// that without sending anything).
+func pidAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// terminate asks the process to exit.
+func terminate(proc *os.Process) error {
+	return proc.Signal(syscall.SIGTERM)
+}
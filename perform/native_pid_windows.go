@@ -0,0 +1,33 @@
+package perform
+
+import (
+	"os"
+	"syscall"
+)
+
+// STILL_ACTIVE is the exit code of processes which have not exited.
+const stillActive = 259
+
+// pidAlive returns true if the process pid has not exited. Windows
+// has no signal 0, so ask for the exit code instead.
+func pidAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(h)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
+
+// terminate kills the process: Windows cannot deliver SIGTERM.
+func terminate(proc *os.Process) error {
+	return proc.Kill()
+}
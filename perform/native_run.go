@@ -1,47 +1,658 @@
 package perform
 
-// import (
-// 	"bytes"
-// 	"fmt"
-// 	"log"
-// 	"os/exec"
-// )
-
-// // Helper function to execute the cli commands and send back
-// // the result of the command to the caller.
-// func NativeCommand(name string, args ...string) {
-// 	product, err := NativeCommand(name, args...)
-// 	if err != nil {
-// 		// TODO: fix
-// 		return
-// 	}
-// 	fmt.Print(product)
-// }
-
-// // Assembles and Executes the command.
-// func NativeCommand(name string, args ...string) (string, error) {
-// 	var cmd *exec.Cmd
-
-// 	cmd = exec.Command(name, args...)
-
-// 	var out bytes.Buffer
-// 	var errOut bytes.Buffer
-// 	cmd.Stdout = &out
-// 	cmd.Stderr = &errOut
-
-// 	err := cmd.Run()
-// 	if err != nil {
-// 		// TODO: handle errors
-// 		log.Fatal(err)
-// 		return "", err
-// 	}
-
-// 	if errOut.String() != "" {
-// 		err := fmt.Errorf(errOut.String())
-// 		// TODO: handle
-// 		log.Fatal(err)
-// 		return "", err
-// 	}
-
-// 	return out.String(), nil
-// }
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/eris-ltd/eris-cli/util"
+	"github.com/eris-ltd/eris-cli/version"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/docker/docker/pkg/archive"
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// NativeBackend is a util.ContainerBackend which runs a service's
+// entry_point and command as a host process rather than inside a
+// container. Assigning it to util.Backend makes DockerRunService,
+// DockerStop, DockerLogs, DockerExecService and friends work on hosts
+// without a Docker daemon, against locally installed binaries.
+//
+// Each "container" is a directory under ~/.eris/native holding its
+// definition (container.json, which also records the PID), its log file
+// and its data directory. Paths under dirs.ErisContainerRoot in the
+// environment, work_dir and arguments are rewritten to the data
+// directory of the first volumes_from container (the data container
+// when data_container = true) or to the container's own one otherwise.
+// Volumes ("host:container") are mapped back to their host path.
+type NativeBackend struct {
+	sync.Mutex
+
+	// Processes started by this process (needed to wait on, attach to
+	// and reap them).
+	procs    map[string]*nativeProc
+	attached map[string]docker.AttachToContainerOptions
+
+	// Commands exec'd next to the processes.
+	execs   map[string]*docker.ExecInspect
+	counter int
+}
+
+type nativeProc struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+	code int
+}
+
+func NewNativeBackend() *NativeBackend {
+	return &NativeBackend{
+		procs:    make(map[string]*nativeProc),
+		attached: make(map[string]docker.AttachToContainerOptions),
+		execs:    make(map[string]*docker.ExecInspect),
+	}
+}
+
+func (n *NativeBackend) CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error) {
+	if opts.Config == nil {
+		return nil, fmt.Errorf("cannot create a container without a config")
+	}
+	if opts.Name == "" {
+		return nil, fmt.Errorf("the marmots need a name to run a native process")
+	}
+	if _, err := n.load(opts.Name); err == nil {
+		return nil, docker.ErrContainerAlreadyExists
+	}
+
+	if err := os.MkdirAll(n.dataDir(opts.Name), 0755); err != nil {
+		return nil, err
+	}
+
+	config := *opts.Config
+	hostConfig := &docker.HostConfig{}
+	if opts.HostConfig != nil {
+		*hostConfig = *opts.HostConfig
+	}
+
+	ports := make(map[docker.Port][]docker.PortBinding)
+	for port := range config.ExposedPorts {
+		// A host process binds its ports directly.
+		ports[port] = []docker.PortBinding{{HostIP: "0.0.0.0", HostPort: port.Port()}}
+	}
+	for port, bindings := range hostConfig.PortBindings {
+		ports[port] = bindings
+	}
+
+	c := &docker.Container{
+		ID:         opts.Name,
+		Name:       "/" + opts.Name,
+		Created:    time.Now(),
+		Path:       strings.Join(config.Entrypoint, " "),
+		Args:       config.Cmd,
+		Config:     &config,
+		Image:      config.Image,
+		HostConfig: hostConfig,
+		LogPath:    n.logFile(opts.Name),
+		NetworkSettings: &docker.NetworkSettings{
+			IPAddress: "127.0.0.1",
+			Ports:     ports,
+		},
+	}
+
+	return c, n.save(c)
+}
+
+func (n *NativeBackend) StartContainer(id string, hostConfig *docker.HostConfig) error {
+	c, err := n.load(id)
+	if err != nil {
+		return err
+	}
+	if c.State.Running {
+		return &docker.ContainerAlreadyRunning{ID: id}
+	}
+	if hostConfig != nil {
+		c.HostConfig = hostConfig
+	}
+
+	argv := append(append([]string{}, c.Config.Entrypoint...), c.Config.Cmd...)
+	if len(argv) == 0 {
+		return fmt.Errorf("The marmots cannot run %s natively: the service definition has neither an entry_point nor a command", id)
+	}
+	cmd, err := n.command(c, argv)
+	if err != nil {
+		return err
+	}
+
+	n.Lock()
+	attach, isAttached := n.attached[c.ID]
+	delete(n.attached, c.ID)
+	n.Unlock()
+
+	var logFile *os.File
+	if isAttached {
+		cmd.Stdin = attach.InputStream
+		cmd.Stdout = attach.OutputStream
+		cmd.Stderr = attach.ErrorStream
+	} else {
+		logFile, err = os.OpenFile(n.logFile(c.ID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+	}
+
+	logger.Debugf("Starting native process =>\t%s:%v\n", cmd.Path, cmd.Args[1:])
+	logger.Debugf("\twith WorkDir =>\t\t%s\n", cmd.Dir)
+	if err := cmd.Start(); err != nil {
+		if logFile != nil {
+			logFile.Close()
+		}
+		return err
+	}
+
+	proc := &nativeProc{cmd: cmd, done: make(chan struct{})}
+	n.Lock()
+	n.procs[c.ID] = proc
+	n.Unlock()
+
+	// Reap the process so that it does not linger as a zombie while
+	// this eris process is still around, and record its exit status.
+	go func() {
+		err := cmd.Wait()
+		if logFile != nil {
+			logFile.Close()
+		}
+		proc.code = exitCode(cmd, err)
+		close(proc.done)
+		if c, err := n.load(id); err == nil && c.State.Pid == cmd.Process.Pid {
+			c.State.Running = false
+			c.State.Pid = 0
+			c.State.ExitCode = proc.code
+			c.State.FinishedAt = time.Now()
+			n.save(c)
+		}
+	}()
+
+	c.State.Running = true
+	c.State.Pid = cmd.Process.Pid
+	c.State.StartedAt = time.Now()
+	c.State.ExitCode = 0
+	logger.Infof("Native process started =>\t%s (pid %d)\n", id, c.State.Pid)
+	return n.save(c)
+}
+
+func (n *NativeBackend) StopContainer(id string, timeout uint) error {
+	c, err := n.load(id)
+	if err != nil {
+		return err
+	}
+	if !c.State.Running {
+		return &docker.ContainerNotRunning{ID: id}
+	}
+
+	logger.Debugf("Stopping native process =>\t%s (pid %d)\n", id, c.State.Pid)
+	if proc, err := os.FindProcess(c.State.Pid); err == nil {
+		terminate(proc)
+
+		deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+		for pidAlive(c.State.Pid) && time.Now().Before(deadline) {
+			time.Sleep(100 * time.Millisecond)
+		}
+		if pidAlive(c.State.Pid) {
+			proc.Kill()
+		}
+	}
+
+	c.State.Running = false
+	c.State.Pid = 0
+	c.State.FinishedAt = time.Now()
+	return n.save(c)
+}
+
+func (n *NativeBackend) RemoveContainer(opts docker.RemoveContainerOptions) error {
+	c, err := n.load(opts.ID)
+	if err != nil {
+		return err
+	}
+	if c.State.Running {
+		if !opts.Force {
+			return fmt.Errorf("Conflict, You cannot remove a running container. Stop the container before attempting removal or use -f")
+		}
+		if err := n.StopContainer(opts.ID, 0); err != nil {
+			return err
+		}
+	}
+
+	logger.Debugf("Removing native state =>\t%s\n", n.dir(c.ID))
+	return os.RemoveAll(n.dir(c.ID))
+}
+
+func (n *NativeBackend) InspectContainer(id string) (*docker.Container, error) {
+	return n.load(id)
+}
+
+func (n *NativeBackend) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	entries, err := ioutil.ReadDir(nativeRoot())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out []docker.APIContainers
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		c, err := n.load(entry.Name())
+		if err != nil {
+			continue
+		}
+		if !opts.All && !c.State.Running {
+			continue
+		}
+		if !util.MatchLabelFilters(c.Config.Labels, opts.Filters["label"]) {
+			continue
+		}
+
+		status := fmt.Sprintf("Exited (%d)", c.State.ExitCode)
+		if c.State.Running {
+			status = fmt.Sprintf("Up (pid %d)", c.State.Pid)
+		}
+
+		out = append(out, docker.APIContainers{
+			ID:      c.ID,
+			Image:   c.Image,
+			Command: strings.TrimSpace(c.Path + " " + strings.Join(c.Args, " ")),
+			Created: c.Created.Unix(),
+			Status:  status,
+			Names:   []string{c.Name},
+			Labels:  c.Config.Labels,
+		})
+	}
+	return out, nil
+}
+
+func (n *NativeBackend) WaitContainer(id string) (int, error) {
+	c, err := n.load(id)
+	if err != nil {
+		return 0, err
+	}
+
+	n.Lock()
+	proc, ok := n.procs[c.ID]
+	n.Unlock()
+
+	if ok {
+		<-proc.done
+		return proc.code, nil
+	}
+
+	// Started by another eris invocation; the exit code is not ours to know.
+	for pidAlive(c.State.Pid) {
+		time.Sleep(100 * time.Millisecond)
+	}
+	return c.State.ExitCode, nil
+}
+
+// AttachToContainer registers the streams for the next start of the
+// container, which then runs in the foreground with them as its stdio.
+func (n *NativeBackend) AttachToContainer(opts docker.AttachToContainerOptions) error {
+	c, err := n.load(opts.Container)
+	if err != nil {
+		return err
+	}
+
+	n.Lock()
+	n.attached[c.ID] = opts
+	n.Unlock()
+
+	if opts.Success != nil {
+		opts.Success <- struct{}{}
+		<-opts.Success
+	}
+	return nil
+}
+
+func (n *NativeBackend) Logs(opts docker.LogsOptions) error {
+	c, err := n.load(opts.Container)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(n.logFile(c.ID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	if lines, err := strconv.Atoi(opts.Tail); err == nil {
+		content = tailLines(content, lines)
+	}
+	if _, err := opts.OutputStream.Write(content); err != nil {
+		return err
+	}
+
+	if !opts.Follow {
+		return nil
+	}
+
+	buf := make([]byte, 4096)
+	for {
+		count, err := f.Read(buf)
+		if count > 0 {
+			opts.OutputStream.Write(buf[:count])
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if !pidAlive(c.State.Pid) {
+			return nil
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+func (n *NativeBackend) UploadToContainer(id string, opts docker.UploadToContainerOptions) error {
+	c, err := n.load(id)
+	if err != nil {
+		return err
+	}
+
+	dest := n.mapPath(c, opts.Path)
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	return util.Untar(opts.InputStream, c.ID, dest)
+}
+
+func (n *NativeBackend) DownloadFromContainer(id string, opts docker.DownloadFromContainerOptions) error {
+	c, err := n.load(id)
+	if err != nil {
+		return err
+	}
+
+	src := n.mapPath(c, opts.Path)
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("Could not find the file %s in container %s", opts.Path, id)
+	}
+
+	// Docker archives a directory under its own base name.
+	reader, err := archive.TarWithOptions(filepath.Dir(src), &archive.TarOptions{
+		IncludeFiles: []string{filepath.Base(src)},
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(opts.OutputStream, reader)
+	return err
+}
+
+// PullImage is a no-op: native processes use the binaries on the host.
+func (n *NativeBackend) PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error {
+	logger.Debugf("Native mode, not pulling =>\t%s:%s\n", opts.Repository, opts.Tag)
+	return nil
+}
+
+// CreateExec sets up opts.Cmd to be run on this host next to the
+// process of the container.
+func (n *NativeBackend) CreateExec(opts docker.CreateExecOptions) (*docker.Exec, error) {
+	c, err := n.load(opts.Container)
+	if err != nil {
+		return nil, err
+	}
+	if !c.State.Running {
+		return nil, fmt.Errorf("Container %s is not running", opts.Container)
+	}
+	if len(opts.Cmd) == 0 {
+		return nil, fmt.Errorf("No exec command specified")
+	}
+
+	n.Lock()
+	defer n.Unlock()
+	n.counter++
+	id := fmt.Sprintf("%s_exec_%d", c.ID, n.counter)
+	n.execs[id] = &docker.ExecInspect{
+		ID:            id,
+		ProcessConfig: docker.ExecProcessConfig{EntryPoint: opts.Cmd[0], Arguments: opts.Cmd[1:]},
+		ContainerID:   c.ID,
+	}
+	return &docker.Exec{ID: id}, nil
+}
+
+// StartExec runs the exec id to completion.
+func (n *NativeBackend) StartExec(id string, opts docker.StartExecOptions) error {
+	n.Lock()
+	e, ok := n.execs[id]
+	n.Unlock()
+	if !ok {
+		return &docker.NoSuchExec{ID: id}
+	}
+
+	c, err := n.load(e.ContainerID)
+	if err != nil {
+		return err
+	}
+
+	argv := append([]string{e.ProcessConfig.EntryPoint}, e.ProcessConfig.Arguments...)
+	cmd, err := n.command(c, argv)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = opts.InputStream
+	cmd.Stdout = opts.OutputStream
+	cmd.Stderr = opts.ErrorStream
+
+	logger.Debugf("Exec native process =>\t%s:%v\n", cmd.Path, cmd.Args[1:])
+	err = cmd.Run()
+	if _, exited := err.(*exec.ExitError); err != nil && !exited {
+		return err
+	}
+
+	n.Lock()
+	e.ExitCode = exitCode(cmd, err)
+	n.Unlock()
+	return nil
+}
+
+func (n *NativeBackend) InspectExec(id string) (*docker.ExecInspect, error) {
+	n.Lock()
+	defer n.Unlock()
+
+	e, ok := n.execs[id]
+	if !ok {
+		return nil, &docker.NoSuchExec{ID: id}
+	}
+	inspect := *e
+	return &inspect, nil
+}
+
+// Version reports the minimum Docker version eris supports, so that the
+// rest of eris treats the native backend as a fully capable one.
+func (n *NativeBackend) Version() (*docker.Env, error) {
+	return &docker.Env{
+		fmt.Sprintf("Version=%v.0", version.DVER_MIN),
+		"APIVersion=1.20",
+	}, nil
+}
+
+// nativeRoot is where native containers keep their state.
+func nativeRoot() string {
+	return filepath.Join(dirs.ErisRoot, "native")
+}
+
+func (n *NativeBackend) dir(name string) string {
+	return filepath.Join(nativeRoot(), strings.TrimPrefix(name, "/"))
+}
+
+func (n *NativeBackend) dataDir(name string) string {
+	return filepath.Join(n.dir(name), "data")
+}
+
+func (n *NativeBackend) logFile(name string) string {
+	return filepath.Join(n.dir(name), "output.log")
+}
+
+func (n *NativeBackend) load(id string) (*docker.Container, error) {
+	content, err := ioutil.ReadFile(filepath.Join(n.dir(id), "container.json"))
+	if err != nil {
+		return nil, &docker.NoSuchContainer{ID: id}
+	}
+
+	c := &docker.Container{}
+	if err := json.Unmarshal(content, c); err != nil {
+		return nil, err
+	}
+
+	// Processes can die behind our back.
+	if c.State.Running && !pidAlive(c.State.Pid) {
+		c.State.Running = false
+		c.State.Pid = 0
+	}
+	return c, nil
+}
+
+func (n *NativeBackend) save(c *docker.Container) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(n.dir(c.ID), "container.json"), content, 0644)
+}
+
+// command returns the host process for argv run in the container c,
+// with the container paths of argv, the environment and the working
+// directory mapped to the host.
+func (n *NativeBackend) command(c *docker.Container, argv []string) (*exec.Cmd, error) {
+	args := make([]string, len(argv))
+	for i := range argv {
+		args[i] = n.mapPath(c, argv[i])
+	}
+	bin, err := lookNativeBinary(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(bin, args[1:]...)
+	cmd.Env = os.Environ()
+	for _, env := range c.Config.Env {
+		cmd.Env = append(cmd.Env, n.mapPath(c, env))
+	}
+	cmd.Env = append(cmd.Env, "ERIS="+n.mapPath(c, dirs.ErisContainerRoot))
+
+	cmd.Dir = n.mapPath(c, dirs.ErisContainerRoot)
+	if c.Config.WorkingDir != "" {
+		cmd.Dir = n.mapPath(c, c.Config.WorkingDir)
+	}
+	if err := os.MkdirAll(cmd.Dir, 0755); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// mapPath rewrites container paths found in s to their host equivalents.
+// Only whole path segments match: a volume on /data maps /data/x but
+// not /database.
+func (n *NativeBackend) mapPath(c *docker.Container, s string) string {
+	for _, bind := range c.HostConfig.Binds {
+		parts := strings.Split(bind, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		if mapped := replacePath(s, strings.TrimSuffix(parts[1], "/"), parts[0]); mapped != s {
+			return mapped
+		}
+	}
+
+	data := n.dataDir(c.ID)
+	for _, from := range c.HostConfig.VolumesFrom {
+		from = strings.TrimSuffix(strings.TrimSuffix(from, ":ro"), ":rw")
+		if _, err := os.Stat(n.dir(from)); err == nil {
+			data = n.dataDir(from)
+			break
+		}
+	}
+	return replacePath(s, dirs.ErisContainerRoot, data)
+}
+
+// replacePath replaces the container path dir with host wherever it
+// appears as a whole path in s (e.g. in KEY=/path or --dir=/path/x).
+func replacePath(s, dir, host string) string {
+	if dir == "" {
+		return s
+	}
+	var out string
+	for {
+		i := strings.Index(s, dir)
+		if i == -1 {
+			return out + s
+		}
+		end := i + len(dir)
+		if (i == 0 || !isPathByte(s[i-1])) && (end == len(s) || s[end] == '/' || !isPathByte(s[end])) {
+			out += s[:i] + host
+		} else {
+			out += s[:end]
+		}
+		s = s[end:]
+	}
+}
+
+// isPathByte returns true for the bytes which continue a path segment.
+func isPathByte(b byte) bool {
+	return b == '/' || b == '.' || b == '_' || b == '-' ||
+		'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+// lookNativeBinary finds the binary on the host. Images often refer to
+// binaries by an absolute path, so fall back to the base name in PATH.
+func lookNativeBinary(name string) (string, error) {
+	if path, err := exec.LookPath(name); err == nil {
+		return path, nil
+	}
+	if path, err := exec.LookPath(filepath.Base(name)); err == nil {
+		return path, nil
+	}
+	return "", fmt.Errorf("The marmots could not find %s on this host. Please install it to run natively", name)
+}
+
+func exitCode(cmd *exec.Cmd, err error) int {
+	if err == nil {
+		return 0
+	}
+	if status, ok := cmd.ProcessState.Sys().(syscall.WaitStatus); ok {
+		return status.ExitStatus()
+	}
+	return 1
+}
+
+func tailLines(content []byte, n int) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if n < len(lines) {
+		lines = lines[len(lines)-n:]
+	}
+	return []byte(strings.Join(lines, ""))
+}
@@ -1,15 +1,24 @@
 package perform
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/eris-ltd/eris-cli/config"
 	def "github.com/eris-ltd/eris-cli/definitions"
 	"github.com/eris-ltd/eris-cli/loaders"
 	tests "github.com/eris-ltd/eris-cli/testutils"
 	"github.com/eris-ltd/eris-cli/util"
 
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
 )
 
 func TestMain(m *testing.M) {
@@ -1007,3 +1016,318 @@ func TestStopDataContainer(t *testing.T) {
 
 	tests.RemoveAllContainers()
 }
+
+func TestConfigureServiceResources(t *testing.T) {
+	srv := def.BlankService()
+	srv.Image = "quay.io/eris/ipfs"
+	srv.MemLimit = 1 << 30
+	srv.MemSwapLimit = -1
+	srv.CPUSet = "0-1"
+	srv.CPUQuota = 50000
+	srv.CPUPeriod = 100000
+	srv.PidsLimit = 200
+	srv.Ulimits = []string{"nofile=1024:4096"}
+	srv.BlkioWeight = 300
+	srv.Tmpfs = []string{"/run:rw,size=64m", "/tmp"}
+	srv.ReadOnly = true
+	srv.SecurityOpt = []string{"no-new-privileges"}
+	srv.ExtraHosts = []string{"db:10.0.0.2"}
+	srv.LogDriver = "json-file"
+	srv.LogOpt = map[string]string{"max-size": "10m"}
+	srv.StopSignal = "SIGINT"
+
+	opts, err := configureServiceContainer(srv, def.BlankOperation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := opts.HostConfig
+	if host.Memory != 1<<30 || host.MemorySwap != -1 || host.CPUSetCPUs != "0-1" ||
+		host.CPUQuota != 50000 || host.CPUPeriod != 100000 || host.PidsLimit == nil || *host.PidsLimit != 200 || host.BlkioWeight != 300 {
+		t.Fatalf("expected the cpu and memory limits to be set, got %+v", host)
+	}
+	if len(host.Ulimits) != 1 || host.Ulimits[0] != (docker.ULimit{Name: "nofile", Soft: 1024, Hard: 4096}) {
+		t.Fatalf("expected the ulimit, got %v", host.Ulimits)
+	}
+	if len(host.Tmpfs) != 2 || host.Tmpfs["/run"] != "rw,size=64m" {
+		t.Fatalf("expected two tmpfs mounts, got %v", host.Tmpfs)
+	}
+	if !host.ReadonlyRootfs || host.SecurityOpt[0] != "no-new-privileges" || host.ExtraHosts[0] != "db:10.0.0.2" {
+		t.Fatalf("expected read only, security options and extra hosts, got %+v", host)
+	}
+	if host.LogConfig.Type != "json-file" || host.LogConfig.Config["max-size"] != "10m" {
+		t.Fatalf("expected the log driver, got %v", host.LogConfig)
+	}
+	if opts.Config.StopSignal != "SIGINT" {
+		t.Fatalf("expected the stop signal, got %q", opts.Config.StopSignal)
+	}
+
+	for _, bad := range []struct{ ulimits, tmpfs []string }{
+		{ulimits: []string{"bad"}},
+		{ulimits: []string{"nofile=4096:1024"}},
+		{tmpfs: []string{"tmp"}},
+	} {
+		srv.Ulimits, srv.Tmpfs = bad.ulimits, bad.tmpfs
+		if _, err := configureServiceContainer(srv, def.BlankOperation()); err == nil {
+			t.Fatalf("expected an error for the ulimits %v and tmpfs %v", bad.ulimits, bad.tmpfs)
+		}
+	}
+}
+
+func TestRunServiceNetworks(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	chain := def.BlankServiceDefinition()
+	chain.Service.Name = "mychain"
+	chain.Service.Image = "quay.io/eris/erisdb"
+	chain.Service.Networks = []string{util.ChainNetwork("mychain")}
+	chain.Operations.ContainerType = def.TypeChain
+	chain.Operations.SrvContainerName = util.ChainContainersName("mychain", 1)
+	chain.Operations.Labels = util.Labels("mychain", chain.Operations)
+	opts, err := configureServiceContainer(chain.Service, chain.Operations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := createContainer(opts); err != nil {
+		t.Fatal(err)
+	}
+
+	container, err := fake.InspectContainer(chain.Operations.SrvContainerName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if networks := util.ContainerNetworks(container); !reflect.DeepEqual(networks, []string{util.DefaultNetwork, util.ChainNetwork("mychain")}) {
+		t.Fatalf("expected the chain on the eris and chain networks, got %v", networks)
+	}
+	if aliases := container.NetworkSettings.Networks[util.ChainNetwork("mychain")].Aliases; !reflect.DeepEqual(aliases, []string{"mychain", "chain"}) {
+		t.Fatalf("expected the chain known as chain on its network, got %v", aliases)
+	}
+
+	if err := DockerRename(chain.Operations, "yourchain"); err != nil {
+		t.Fatal(err)
+	}
+	container, err = fake.InspectContainer(util.ChainContainersName("yourchain", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliases := container.NetworkSettings.Networks[util.DefaultNetwork].Aliases; !reflect.DeepEqual(aliases, []string{"yourchain"}) {
+		t.Fatalf("expected the alias renamed, got %v", aliases)
+	}
+
+	host := def.BlankService()
+	host.Name = "host"
+	host.Net = "host"
+	host.Networks = []string{"ignored"}
+	opts, err = configureServiceContainer(host, def.BlankOperation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.HostConfig.NetworkMode != "host" || opts.NetworkingConfig != nil {
+		t.Fatalf("expected the host network mode only, got %q %v", opts.HostConfig.NetworkMode, opts.NetworkingConfig)
+	}
+}
+
+func TestRunServiceDataVolume(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "ipfs"
+	srv.Service.Image = "quay.io/eris/ipfs"
+	srv.Service.AutoData = true
+	srv.Service.DataVolume = true
+	srv.Operations.SrvContainerName = util.ServiceContainersName("ipfs", 1)
+	srv.Operations.DataContainerName = util.DataContainersName("ipfs", 1)
+	srv.Operations.Labels = util.Labels("ipfs", srv.Operations)
+
+	opts, err := configureServiceContainer(srv.Service, srv.Operations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setupData(srv.Service, srv.Operations, &opts); err != nil {
+		t.Fatal(err)
+	}
+	if !util.DataVolumeExists(srv.Operations.DataContainerName) {
+		t.Fatalf("expected the data volume created")
+	}
+	if _, exists := util.ParseContainers(srv.Operations.DataContainerName, true); exists {
+		t.Fatalf("expected no data container")
+	}
+	bind := srv.Operations.DataContainerName + ":" + dirs.ErisContainerRoot
+	if binds := opts.HostConfig.Binds; len(binds) != 1 || binds[0] != bind {
+		t.Fatalf("expected the volume mounted, got %v", binds)
+	}
+}
+
+func TestMigrateData(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	srv := def.BlankServiceDefinition()
+	srv.Service.Name = "ipfs"
+	srv.Service.Image = "quay.io/eris/ipfs"
+	srv.Service.AutoData = true
+	srv.Operations.SrvContainerName = util.ServiceContainersName("ipfs", 1)
+	srv.Operations.DataContainerName = util.DataContainersName("ipfs", 1)
+	srv.Operations.Labels = util.Labels("ipfs", srv.Operations)
+
+	opts, err := configureServiceContainer(srv.Service, srv.Operations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setupData(srv.Service, srv.Operations, &opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := createContainer(opts); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := DataContainerExists(srv.Operations)
+	if err := fake.UploadToContainer(data.ID, docker.UploadToContainerOptions{
+		Path:        dirs.ErisContainerRoot,
+		InputStream: bytes.NewBufferString("data"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DockerMigrateData(srv.Operations); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := util.ParseContainers(srv.Operations.DataContainerName, true); exists {
+		t.Fatalf("expected the data container removed")
+	}
+	if _, exists := util.ParseContainers(srv.Operations.SrvContainerName, true); exists {
+		t.Fatalf("expected the service container removed")
+	}
+	volume, err := fake.InspectVolume(srv.Operations.DataContainerName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume.Labels[def.Namespace+":"+def.LabelService] != srv.Operations.SrvContainerName {
+		t.Fatalf("expected the data container labels kept, got %v", volume.Labels)
+	}
+
+	id, unmount, err := DockerMountData(srv.Operations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unmount()
+	var out bytes.Buffer
+	if err := fake.DownloadFromContainer(id, docker.DownloadFromContainerOptions{
+		Path:         "/home/eris",
+		OutputStream: &out,
+	}); err != nil || out.String() != "data" {
+		t.Fatalf("expected the data copied into the volume, got %q (%v)", out.String(), err)
+	}
+
+	if err := DockerMigrateData(srv.Operations); err == nil {
+		t.Fatalf("expected an error migrating data which is not in a data container")
+	}
+}
+
+func TestBuildService(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	context, err := ioutil.TempDir("", "eris_build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(context)
+	if err := ioutil.WriteFile(filepath.Join(context, "Dockerfile"), []byte("FROM quay.io/eris/base\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := def.BlankService()
+	srv.Name = "Built"
+	build := &def.Build{Context: context, Args: map[string]string{"VERSION": "0.1.0"}}
+	if err := DockerBuild(srv, build); err != nil {
+		t.Fatal(err)
+	}
+	image := srv.Image
+	if !strings.HasPrefix(image, "eris/built:") || fake.Builds() != 1 {
+		t.Fatalf("expected the image built and tagged with a hash, got %q (%d builds)", image, fake.Builds())
+	}
+
+	// Unchanged contexts are not rebuilt.
+	srv.Image = "quay.io/eris/built:0.1"
+	if err := DockerBuild(srv, build); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Image != "quay.io/eris/built"+image[strings.Index(image, ":"):] || fake.Builds() != 2 {
+		t.Fatalf("expected the image repository from the definition, got %q (%d builds)", srv.Image, fake.Builds())
+	}
+	if err := DockerBuild(srv, build); err != nil {
+		t.Fatal(err)
+	}
+	if fake.Builds() != 2 {
+		t.Fatalf("expected an unchanged context not rebuilt, got %d builds", fake.Builds())
+	}
+
+	// Neither are the files of the context touched.
+	now := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(context, "Dockerfile"), now, now)
+	if err := DockerBuild(srv, build); err != nil {
+		t.Fatal(err)
+	}
+	if fake.Builds() != 2 {
+		t.Fatalf("expected a touched context not rebuilt, got %d builds", fake.Builds())
+	}
+
+	build.Args["VERSION"] = "0.2.0"
+	if err := DockerBuild(srv, build); err != nil {
+		t.Fatal(err)
+	}
+	if fake.Builds() != 3 {
+		t.Fatalf("expected changed build args rebuilt, got %d builds", fake.Builds())
+	}
+
+	build.Dockerfile = "/elsewhere/Dockerfile"
+	if err := DockerBuild(srv, build); err == nil {
+		t.Fatalf("expected an error for a Dockerfile outside the context")
+	}
+}
+
+func TestPullImageAuth(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	dir, err := ioutil.TempDir("", "eris_auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("DOCKER_CONFIG", os.Getenv("DOCKER_CONFIG"))
+	os.Setenv("DOCKER_CONFIG", dir)
+	savedConfig := config.GlobalConfig
+	defer func() { config.GlobalConfig = savedConfig }()
+	config.GlobalConfig = &config.ErisCli{Config: &config.ErisConfig{
+		Registries: map[string]*config.Registry{
+			"localhost:5000": {Username: "marmot", Password: "secret"},
+		},
+	}}
+
+	if err := pullImage("localhost:5000/eris/ipfs:0.12", nil); err != nil {
+		t.Fatal(err)
+	}
+	want := docker.AuthConfiguration{Username: "marmot", Password: "secret", ServerAddress: "localhost:5000"}
+	if auth := fake.PullAuth("localhost:5000/eris/ipfs:0.12"); auth != want {
+		t.Fatalf("expected the image pulled with the registry credentials, got %+v", auth)
+	}
+
+	if err := pullImage("quay.io/eris/data", nil); err != nil {
+		t.Fatal(err)
+	}
+	if auth := fake.PullAuth("quay.io/eris/data:latest"); auth != (docker.AuthConfiguration{}) {
+		t.Fatalf("expected no credentials for other registries, got %+v", auth)
+	}
+}
@@ -0,0 +1,7 @@
+package projects
+
+import (
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+)
+
+var logger = AddLogger("projects")
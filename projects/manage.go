@@ -1,49 +1,501 @@
 package projects
 
 import (
-	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/services"
+	"github.com/eris-ltd/eris-cli/util"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/ipfs"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/olekukonko/tablewriter"
 )
 
-func Get(cmd *cobra.Command, args []string) {
+// Name (without the extension) of the project definition file looked
+// up in the root of a project fetched with Get.
+const definitionFileName = "project"
+
+// Get fetches a project and registers it with eris. A github.com (or any
+// git) location is cloned into ~/.eris/apps/NAME and the project
+// definition file in the root of the clone is added; if there is none,
+// the clone is registered as the project's contracts package. Anything
+// else is taken to be the IPFS hash of a project definition file.
+//
+//	do.Name - project name
+//	do.Path - github.com/USER/REPO, a git url, a git: or github:
+//	          location, or an IPFS hash
+func Get(do *def.Do) error {
+	if IsKnown(do.Name) {
+		return fmt.Errorf("A project named %s already exists.", do.Name)
+	}
+
+	if !isGitLocation(do.Path) {
+		fileName := filepath.Join(util.ProjectsPath(), do.Name+".toml")
+		if err := os.MkdirAll(util.ProjectsPath(), 0755); err != nil {
+			return err
+		}
+		logger.Infof("Getting project from IPFS =>\t%s\n", do.Path)
+		if err := ipfs.GetFromIPFS(do.Path, fileName, "", output()); err != nil {
+			return err
+		}
+		if _, err := LoadProjectDefinition(do.Name); err != nil {
+			os.Remove(fileName)
+			return fmt.Errorf("Your project definition file looks improperly formatted and will not marshal.")
+		}
+		do.Result = "success"
+		return nil
+	}
+
+	dir := filepath.Join(AppsPath, do.Name)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("The directory %s already exists. Please remove it or choose a different name.", dir)
+	}
+
+	loc, err := projectLocation(do.Path)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Cloning project =>\t\t%s:%s\n", loc.URL, dir)
+	repo, err := util.CloneGitLocation(loc, output())
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(repo)
+	if err := os.MkdirAll(AppsPath, 0755); err != nil {
+		return err
+	}
+	// The clone is in the temporary directory, which may be on another
+	// device, so fall back to copying it.
+	if err := os.Rename(repo, dir); err != nil {
+		if err := Copy(repo, dir); err != nil {
+			os.RemoveAll(dir)
+			return err
+		}
+	}
+
+	do.Path = findDefinitionFile(dir)
+	if do.Path == "" {
+		logger.Debugf("No project definition found. Registering the clone as the contracts package.\n")
+		do.Project.Contracts = dir
+	}
+	return Add(do)
+}
+
+// New writes a new project definition file. The chain, services,
+// actions and contracts package are taken from do.Project.
+func New(do *def.Do) error {
+	if IsKnown(do.Name) {
+		return fmt.Errorf("A project named %s already exists.", do.Name)
+	}
+
+	prj := def.BlankProject()
+	mergeProject(prj, do.Project)
+	prj.Name = do.Name
+
+	var err error
+	prj.Maintainer.Name, prj.Maintainer.Email, err = config.GitConfigUser()
+	if err != nil {
+		logger.Debugf("%v\n", err)
+	}
+
+	logger.Debugf("Creating a new project def file =>\t%s\n", prj.Name)
+	if err := WriteProjectDefinitionFile(prj, ""); err != nil {
+		return err
+	}
+	do.Result = "success"
+	return nil
+}
+
+// Add registers a project with eris from a project definition file
+// (JSON, TOML or YAML). Fields given in do.Project take precedence.
+//
+//	do.Name - project name
+//	do.Path - optional project definition file
+func Add(do *def.Do) error {
+	if IsKnown(do.Name) {
+		return fmt.Errorf("A project named %s already exists.", do.Name)
+	}
+
+	prj := def.BlankProject()
+	if do.Path != "" {
+		var err error
+		if prj, err = readProjectDefinition(do.Path); err != nil {
+			return err
+		}
+	}
+	mergeProject(prj, do.Project)
+	prj.Name = do.Name
+
+	logger.Infof("Adding project =>\t\t%s\n", prj.Name)
+	if err := WriteProjectDefinitionFile(prj, ""); err != nil {
+		return err
+	}
+	do.Result = "success"
+	return nil
+}
+
+// Install pulls the images of the project's chain and services (and of
+// the services they depend on) so that the project can be started.
+// If do.Path is given, the project is first added from that file.
+func Install(do *def.Do) error {
+	if do.Path != "" {
+		if err := Add(do); err != nil {
+			return err
+		}
+	}
+
+	prj, err := LoadProjectDefinition(do.Name)
+	if err != nil {
+		return err
+	}
+
+	if prj.Chain != "" {
+		chain, err := loaders.LoadChainDefinition(prj.Chain, false, do.Operations.ContainerNumber)
+		if err != nil {
+			return err
+		}
+		logger.Infof("Installing chain =>\t\t%s\n", prj.Chain)
+		if err := perform.DockerPull(chain.Service, chain.Operations); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range prj.Services {
+		group, err := services.BuildServicesGroup(name, do.Operations.ContainerNumber)
+		if err != nil {
+			return err
+		}
+		for _, srv := range group {
+			logger.Infof("Installing service =>\t\t%s\n", srv.Name)
+			if err := perform.DockerPull(srv.Service, srv.Operations); err != nil {
+				return err
+			}
+		}
+	}
 
+	for _, name := range prj.Actions {
+		if util.GetFileByNameAndType("actions", name) == "" {
+			logger.Infof("The action %s of the project is not known. Import it with [eris actions import].\n", name)
+		}
+	}
+
+	do.Result = "success"
+	return nil
 }
 
-func New(cmd *cobra.Command, args []string) {
+// ListProjects displays the known projects in a table; the checked out
+// project is marked with a star. do.Result is set to the comma separated
+// list of their names.
+func ListProjects(do *def.Do) error {
+	names := util.GetGlobalLevelConfigFilesByType("projects", false)
+	head, _ := util.GetProjectHead()
+
+	buf := new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetHeader([]string{"PROJECT NAME", "CHAIN", "SERVICES", "ACTIONS", "CONTRACTS"})
+	for _, name := range names {
+		prj, err := LoadProjectDefinition(name)
+		if err != nil {
+			logger.Infof("Could not read project %s: %v\n", name, err)
+			continue
+		}
+		if name == head {
+			name = name + " *"
+		}
+		table.Append([]string{name, prj.Chain, strings.Join(prj.Services, ","), strings.Join(prj.Actions, ","), prj.Contracts})
+	}
+
+	table.SetBorder(false)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator("-")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
 
+	if !do.Quiet {
+		logger.Printf("%s", buf.String())
+	}
+	do.Result = strings.Join(names, ",")
+	return nil
 }
 
-func Add(cmd *cobra.Command, args []string) {
+// ListServices displays the services of the do.Name project (or of the
+// checked out project if do.Name is empty).
+func ListServices(do *def.Do) error {
+	prj, err := loadNamedOrHead(do)
+	if err != nil {
+		return err
+	}
+	do.Result = strings.Join(prj.Services, "\n")
+	if do.Result != "" && !do.Quiet {
+		logger.Println(do.Result)
+	}
+	return nil
+}
 
+// ListActions displays the actions of the do.Name project (or of the
+// checked out project if do.Name is empty).
+func ListActions(do *def.Do) error {
+	prj, err := loadNamedOrHead(do)
+	if err != nil {
+		return err
+	}
+	do.Result = strings.Join(prj.Actions, "\n")
+	if do.Result != "" && !do.Quiet {
+		logger.Println(do.Result)
+	}
+	return nil
 }
 
-func Install(cmd *cobra.Command, args []string) {
+func Rename(do *def.Do) error {
+	if do.Name == do.NewName {
+		return fmt.Errorf("Cannot rename to same name")
+	}
+	if IsKnown(do.NewName) {
+		return fmt.Errorf("A project named %s already exists.", do.NewName)
+	}
+
+	prj, err := LoadProjectDefinition(do.Name)
+	if err != nil {
+		return err
+	}
+	oldFile := util.GetFileByNameAndType("projects", do.Name)
+
+	prj.Name = do.NewName
+	newFile := filepath.Join(util.ProjectsPath(), do.NewName+filepath.Ext(oldFile))
+	logger.Infof("Renaming project =>\t\t%s:%s\n", oldFile, newFile)
+	if err := WriteProjectDefinitionFile(prj, newFile); err != nil {
+		return err
+	}
+	if err := os.Remove(oldFile); err != nil {
+		return err
+	}
 
+	if head, _ := util.GetProjectHead(); head == do.Name {
+		if err := util.ChangeProjectHead(do.NewName); err != nil {
+			return err
+		}
+	}
+
+	do.Result = "success"
+	return nil
 }
 
-func ListProjects() {
+// Redefine replaces the definition of the do.Name project with the
+// project definition file do.Path.
+func Redefine(do *def.Do) error {
+	oldFile := util.GetFileByNameAndType("projects", do.Name)
+	if oldFile == "" {
+		return unknownProject(do.Name)
+	}
+
+	prj, err := readProjectDefinition(do.Path)
+	if err != nil {
+		return err
+	}
+	prj.Name = do.Name
 
+	logger.Infof("Redefining project =>\t\t%s:%s\n", do.Name, do.Path)
+	// Write the new definition next to the old one first, so that
+	// a failed write leaves the project as it was.
+	newFile := filepath.Join(util.ProjectsPath(), do.Name+".toml")
+	tmpFile := filepath.Join(util.ProjectsPath(), "."+do.Name+".new.toml")
+	if err := WriteProjectDefinitionFile(prj, tmpFile); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	if err := os.Rename(tmpFile, newFile); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	if oldFile != newFile {
+		if err := os.Remove(oldFile); err != nil {
+			return err
+		}
+	}
+	do.Result = "success"
+	return nil
 }
 
-func ListServices(cmd *cobra.Command, args []string) {
+// Rm unregisters the projects named in do.Operations.Args. The project's
+// chain and services are left alone (see Clean).
+func Rm(do *def.Do) error {
+	head, _ := util.GetProjectHead()
+	for _, name := range do.Operations.Args {
+		file := util.GetFileByNameAndType("projects", name)
+		if file == "" {
+			return unknownProject(name)
+		}
+		logger.Infof("Removing file =>\t\t%s\n", file)
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+		if name == head {
+			if err := util.NullProjectHead(); err != nil {
+				return err
+			}
+		}
+	}
+	do.Result = "success"
+	return nil
+}
+
+// Clean kills the do.Name project (or the checked out project), removing
+// its containers and their data containers, and then unregisters it.
+func Clean(do *def.Do) error {
+	prj, err := loadNamedOrHead(do)
+	if err != nil {
+		return err
+	}
+
+	do.Name = prj.Name
+	do.Rm = true
+	do.RmD = true
+	if err := Kill(do); err != nil {
+		return err
+	}
 
+	do.Operations.Args = []string{prj.Name}
+	return Rm(do)
 }
 
-func ListActions(cmd *cobra.Command, args []string) {
+// IsKnown returns true if a project definition file exists for name.
+func IsKnown(name string) bool {
+	return name != "" && util.IsKnownProject(name)
+}
+
+func LoadProjectDefinition(name string) (*def.Project, error) {
+	logger.Debugf("Reading project def file =>\t%s\n", name)
+	if !IsKnown(name) {
+		return nil, unknownProject(name)
+	}
+
+	conf, err := config.LoadViperConfig(util.ProjectsPath(), name, "project")
+	if err != nil {
+		return nil, err
+	}
 
+	prj := def.BlankProject()
+	if err := conf.Marshal(prj); err != nil {
+		return nil, fmt.Errorf("Tragic! The marmots could not read that project definition file:\n%v\n", err)
+	}
+	if prj.Name == "" {
+		prj.Name = name
+	}
+	return prj, nil
 }
 
-func Rename(cmd *cobra.Command, args []string) {
+// WriteProjectDefinitionFile writes the project to fileName (in the
+// format given by its extension) or to ~/.eris/projects/NAME.toml if
+// fileName is empty.
+func WriteProjectDefinitionFile(prj *def.Project, fileName string) error {
+	if fileName == "" {
+		fileName = filepath.Join(util.ProjectsPath(), prj.Name+".toml")
+	}
+	return util.WriteDefinitionFile(prj, fileName)
+}
+
+func readProjectDefinition(file string) (*def.Project, error) {
+	conf, err := config.LoadViperConfig(filepath.Dir(file), strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)), "project")
+	if err != nil {
+		return nil, err
+	}
+
+	prj := def.BlankProject()
+	if err := conf.Marshal(prj); err != nil {
+		return nil, fmt.Errorf("Tragic! The marmots could not read that project definition file:\n%v\n", err)
+	}
 
+	// a relative contracts path is relative to the definition file
+	if prj.Contracts != "" && !filepath.IsAbs(prj.Contracts) {
+		dir, err := filepath.Abs(filepath.Dir(file))
+		if err != nil {
+			return nil, err
+		}
+		prj.Contracts = filepath.Join(dir, prj.Contracts)
+	}
+	return prj, nil
 }
 
-func Redefine(cmd *cobra.Command, args []string) {
+// loadNamedOrHead loads the do.Name project, or the checked out project
+// if do.Name is empty.
+func loadNamedOrHead(do *def.Do) (*def.Project, error) {
+	name := do.Name
+	if name == "" {
+		var err error
+		if name, err = util.GetProjectHead(); err != nil {
+			return nil, fmt.Errorf("%v. Please give a project name or check one out with [eris projects checkout NAME]", err)
+		}
+	}
+	return LoadProjectDefinition(name)
+}
 
+// mergeProject overwrites fields of prj with the non-empty fields of over.
+func mergeProject(prj, over *def.Project) {
+	if over == nil {
+		return
+	}
+	if over.Chain != "" {
+		prj.Chain = over.Chain
+	}
+	if len(over.Services) != 0 {
+		prj.Services = over.Services
+	}
+	if len(over.Actions) != 0 {
+		prj.Actions = over.Actions
+	}
+	if over.Contracts != "" {
+		prj.Contracts = over.Contracts
+	}
 }
 
-func Rm(cmd *cobra.Command, args []string) {
+func findDefinitionFile(dir string) string {
+	for _, ext := range []string{".toml", ".json", ".yaml"} {
+		file := filepath.Join(dir, definitionFileName+ext)
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+	}
+	return ""
+}
 
+// projectLocation returns the git location of a project given as
+// github.com/USER/REPO, a git url, or a git: or github: location.
+func projectLocation(location string) (*util.GitLocation, error) {
+	if util.IsGitLocation(location) {
+		return util.ParseGitLocation(location)
+	}
+	if strings.HasPrefix(location, "github.com/") {
+		location = "https://" + location
+	}
+	return &util.GitLocation{URL: location}, nil
 }
 
-func Clean(cmd *cobra.Command, args []string) {
+func isGitLocation(location string) bool {
+	return util.IsGitLocation(location) ||
+		strings.HasPrefix(location, "github.com/") ||
+		strings.Contains(location, "://") ||
+		strings.HasPrefix(location, "git@") ||
+		strings.HasSuffix(location, ".git")
+}
+
+func output() io.Writer {
+	if logger.Level > 0 {
+		return logger.Writer
+	}
+	return new(bytes.Buffer)
+}
 
+func unknownProject(name string) error {
+	return fmt.Errorf("I do not know the project %s. Check your projects with [eris projects ls]", name)
 }
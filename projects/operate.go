@@ -1,21 +1,182 @@
 package projects
 
 import (
-	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/spf13/cobra"
+	"fmt"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/chains"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/services"
+	"github.com/eris-ltd/eris-cli/util"
 )
 
-func Checkout(cmd *cobra.Command, args []string) {
+// Checkout records do.Name as the current project in the projects HEAD
+// file (~/.eris/projects/HEAD). An empty name clears the checkout.
+func Checkout(do *def.Do) error {
+	if do.Name == "" {
+		do.Result = "nil"
+		return util.NullProjectHead()
+	}
+
+	if !IsKnown(do.Name) {
+		return unknownProject(do.Name)
+	}
+
+	curHead, _ := util.GetProjectHead()
+	if do.Name == curHead {
+		do.Result = "no change"
+		return nil
+	}
+
+	if err := util.ChangeProjectHead(do.Name); err != nil {
+		return err
+	}
+	do.Result = "success"
+	return nil
+}
+
+// Current displays the checked out project.
+func Current(do *def.Do) error {
+	head, _ := util.GetProjectHead()
+
+	if head == "" {
+		head = "There is no project checked out."
+	}
+
+	logger.Println(head)
+	do.Result = head
+
+	return nil
+}
+
+// Configure sets fields of the do.Name project (or of the checked out
+// project) from the KEY:VAL pairs in do.Operations.Args. Known keys are
+// chain, services, actions (comma separated lists), and contracts.
+func Configure(do *def.Do) error {
+	prj, err := loadNamedOrHead(do)
+	if err != nil {
+		return err
+	}
+
+	for _, arg := range do.Operations.Args {
+		kv := strings.SplitN(arg, ":", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("Please give the project settings as KEY:VAL (got %s)", arg)
+		}
 
+		key, val := kv[0], kv[1]
+		logger.Debugf("Setting project field =>\t%s:%s\n", key, val)
+		switch key {
+		case "chain":
+			prj.Chain = val
+		case "services":
+			prj.Services = splitList(val)
+		case "actions":
+			prj.Actions = splitList(val)
+		case "contracts":
+			prj.Contracts = val
+		default:
+			return fmt.Errorf("Unknown project setting %s. Known settings are chain, services, actions, and contracts.", key)
+		}
+	}
+
+	if err := WriteProjectDefinitionFile(prj, util.GetFileByNameAndType("projects", prj.Name)); err != nil {
+		return err
+	}
+	do.Result = "success"
+	return nil
 }
 
-func Configure(cmd *cobra.Command, args []string) {
+// Start brings the do.Name project (or the checked out project) up: the
+// chain first, then the services, each after the services it depends
+// on. Services which use a `$chain` are connected to the project chain.
+func Start(do *def.Do) error {
+	prj, err := loadNamedOrHead(do)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Starting Project =>\t\t%s\n", prj.Name)
+
+	if prj.Chain != "" {
+		doChain := def.NowDo()
+		doChain.Name = prj.Chain
+		doChain.Operations.ContainerNumber = do.Operations.ContainerNumber
+		logger.Debugf("Starting Project Chain =>\t%s\n", prj.Chain)
+		if err := chains.StartChain(doChain); err != nil {
+			return err
+		}
+		if doChain.Result == "no file" {
+			return fmt.Errorf("The chain %s of the project %s is not known.", prj.Chain, prj.Name)
+		}
+	}
 
+	if len(prj.Services) != 0 {
+		doSrvs := def.NowDo()
+		doSrvs.Operations.Args = prj.Services
+		doSrvs.Operations.ContainerNumber = do.Operations.ContainerNumber
+		doSrvs.ChainName = prj.Chain
+		doSrvs.Env = do.Env
+		doSrvs.Links = do.Links
+		logger.Debugf("Starting Project Services =>\t%v\n", prj.Services)
+		if err := services.StartService(doSrvs); err != nil {
+			return err
+		}
+	}
+
+	do.Result = "success"
+	return nil
 }
 
-func Start(cmd *cobra.Command, args []string) {
+// Kill tears the do.Name project (or the checked out project) down in
+// the reverse order of Start: the services (last first), then the chain.
+// do.Rm, do.RmD, do.Volumes, do.Force and do.Timeout are honored as
+// with [eris services stop].
+func Kill(do *def.Do) error {
+	prj, err := loadNamedOrHead(do)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Killing Project =>\t\t%s\n", prj.Name)
+
+	for i := len(prj.Services) - 1; i >= 0; i-- {
+		doSrv := killDo(do)
+		doSrv.Operations.Args = []string{prj.Services[i]}
+		logger.Debugf("Killing Project Service =>\t%s\n", prj.Services[i])
+		if err := services.KillService(doSrv); err != nil {
+			return err
+		}
+	}
 
+	if prj.Chain != "" {
+		doChain := killDo(do)
+		doChain.Name = prj.Chain
+		logger.Debugf("Killing Project Chain =>\t%s\n", prj.Chain)
+		if err := chains.KillChain(doChain); err != nil {
+			return err
+		}
+	}
+
+	do.Result = "success"
+	return nil
 }
 
-func Kill(cmd *cobra.Command, args []string) {
+func killDo(do *def.Do) *def.Do {
+	d := def.NowDo()
+	d.Operations.ContainerNumber = do.Operations.ContainerNumber
+	d.Force = do.Force
+	d.Timeout = do.Timeout
+	d.Rm = do.Rm
+	d.RmD = do.RmD
+	d.Volumes = do.Volumes
+	return d
+}
 
+func splitList(val string) []string {
+	var list []string
+	for _, s := range strings.Split(val, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
 }
@@ -0,0 +1,195 @@
+package projects
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	log.SetLoggers(0, os.Stdout, os.Stderr)
+
+	var err error
+	erisDir, err = ioutil.TempDir("", "eris_projects")
+	if err != nil {
+		panic(err)
+	}
+	config.ChangeErisDir(erisDir)
+	config.GlobalConfig, err = config.SetGlobalObject(os.Stdout, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func TestAddAndConfigure(t *testing.T) {
+	file := filepath.Join(erisDir, "project.toml")
+	if err := ioutil.WriteFile(file, []byte(`
+chain = "simplechain"
+services = ["ipfs", "keys"]
+contracts = "contracts"
+`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	do := def.NowDo()
+	do.Name = "idi"
+	do.Path = file
+	do.Project.Services = []string{"keys"}
+	if err := Add(do); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	prj, err := LoadProjectDefinition("idi")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if prj.Chain != "simplechain" || len(prj.Services) != 1 || prj.Services[0] != "keys" {
+		t.Fatalf("expected the flags to take precedence over the file, got %v", prj)
+	}
+	if prj.Contracts != filepath.Join(erisDir, "contracts") {
+		t.Fatalf("expected the contracts path relative to the definition file, got %s", prj.Contracts)
+	}
+
+	do = def.NowDo()
+	do.Name = "idi"
+	do.Operations.Args = []string{"services:ipfs, keys", "actions:deploy"}
+	if err := Configure(do); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if prj, _ = LoadProjectDefinition("idi"); len(prj.Services) != 2 || prj.Services[0] != "ipfs" || len(prj.Actions) != 1 {
+		t.Fatalf("expected the project to be configured, got %v", prj)
+	}
+
+	do.Operations.Args = []string{"nope:1"}
+	if err := Configure(do); err == nil {
+		t.Fatalf("expected an error configuring an unknown field")
+	}
+
+	do = def.NowDo()
+	do.Operations.Args = []string{"idi"}
+	if err := Rm(do); err != nil {
+		t.Fatalf("rm: %v", err)
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	do := def.NowDo()
+	do.Name = "marmots"
+	if err := New(do); err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	if err := Checkout(do); err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	if do.Result != "success" {
+		t.Fatalf("expected a successful checkout, got %q", do.Result)
+	}
+	if head, _ := util.GetProjectHead(); head != "marmots" {
+		t.Fatalf("expected marmots to be checked out, got %q", head)
+	}
+
+	do.NewName = "beavers"
+	if err := Rename(do); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if head, _ := util.GetProjectHead(); head != "beavers" {
+		t.Fatalf("expected the checkout to follow the rename, got %q", head)
+	}
+
+	do = def.NowDo()
+	if err := ListServices(do); err != nil {
+		t.Fatalf("expected the checked out project to be used, got %v", err)
+	}
+
+	do.Operations.Args = []string{"beavers"}
+	if err := Rm(do); err != nil {
+		t.Fatalf("rm: %v", err)
+	}
+	if head, err := util.GetProjectHead(); err == nil {
+		t.Fatalf("expected no project checked out, got %q", head)
+	}
+
+	do = def.NowDo()
+	do.Name = "nope"
+	if err := Checkout(do); err == nil {
+		t.Fatalf("expected an error checking out an unknown project")
+	}
+}
+
+func TestGetAndRedefine(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo, err := ioutil.TempDir("", "eris_project_repo")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(repo)
+	if err := ioutil.WriteFile(filepath.Join(repo, "project.toml"), []byte(`chain = "simplechain"`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "project.toml"},
+		{"-c", "user.name=marmot", "-c", "user.email=marmot@erisindustries.com", "commit", "--quiet", "-m", "project"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	do := def.NowDo()
+	do.Name = "cloned"
+	do.Path = "git:file://" + repo
+	if err := Get(do); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(common.AppsPath, "cloned", ".git")); err != nil {
+		t.Fatalf("expected the project to be cloned, got %v", err)
+	}
+	if prj, err := LoadProjectDefinition("cloned"); err != nil || prj.Chain != "simplechain" {
+		t.Fatalf("expected the cloned definition to be added, got %v (%v)", prj, err)
+	}
+
+	file := filepath.Join(erisDir, "redefined.toml")
+	if err := ioutil.WriteFile(file, []byte(`chain = "otherchain"`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	do = def.NowDo()
+	do.Name = "cloned"
+	do.Path = file
+	if err := Redefine(do); err != nil {
+		t.Fatalf("redefine: %v", err)
+	}
+	if prj, err := LoadProjectDefinition("cloned"); err != nil || prj.Chain != "otherchain" {
+		t.Fatalf("expected the project to be redefined, got %v (%v)", prj, err)
+	}
+	if leftover, _ := filepath.Glob(filepath.Join(util.ProjectsPath(), ".*")); len(leftover) != 0 {
+		t.Fatalf("expected no temporary files, got %v", leftover)
+	}
+
+	do = def.NowDo()
+	do.Operations.Args = []string{"cloned"}
+	if err := Rm(do); err != nil {
+		t.Fatalf("rm: %v", err)
+	}
+}
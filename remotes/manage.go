@@ -1,23 +1,193 @@
 package remotes
 
-import ()
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
-func Add(args []string) {
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
 
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/olekukonko/tablewriter"
+)
+
+// AddRemote writes the ~/.eris/remotes/NAME.toml remote definition file.
+// If do.Path is given, the definition is read from that file (JSON, TOML
+// or YAML) first; the fields given in do.Remote take precedence.
+//
+//	do.Name           - remote name
+//	do.Path           - optional remote definition file to import
+//	do.Remote         - host, docker_host, docker_cert_path, ssh_user, agent
+func AddRemote(do *def.Do) error {
+	if do.Name == "" {
+		return fmt.Errorf("The marmots need a name for the remote.")
+	}
+	if IsKnown(do.Name) {
+		return fmt.Errorf("A remote named %s already exists. Edit it with [eris remotes edit %s]", do.Name, do.Name)
+	}
+
+	remote := def.BlankRemote()
+	if do.Path != "" {
+		conf, err := config.LoadViperConfig(filepath.Dir(do.Path), strings.TrimSuffix(filepath.Base(do.Path), filepath.Ext(do.Path)), "remote")
+		if err != nil {
+			return err
+		}
+		if err := conf.Marshal(remote); err != nil {
+			return fmt.Errorf("Tragic! The marmots could not read that remote definition file:\n%v\n", err)
+		}
+	}
+	if err := util.Merge(remote, do.Remote); err != nil {
+		return err
+	}
+	remote.Name = do.Name
+
+	if err := checkRemote(remote); err != nil {
+		return err
+	}
+
+	logger.Infof("Adding remote =>\t\t%s:%s\n", remote.Name, remote.Host)
+	if err := WriteRemoteDefinitionFile(remote, ""); err != nil {
+		return err
+	}
+	do.Result = "success"
+	return nil
 }
 
-func List() {
+// ListRemotes displays the known remotes in a table. do.Result is set
+// to the comma separated list of their names.
+func ListRemotes(do *def.Do) error {
+	names := util.GetGlobalLevelConfigFilesByType("remotes", false)
 
+	buf := new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetHeader([]string{"REMOTE NAME", "HOST", "DOCKER HOST", "SSH USER", "AGENT"})
+	for _, name := range names {
+		remote, err := LoadRemoteDefinition(name)
+		if err != nil {
+			logger.Infof("Could not read remote %s: %v\n", name, err)
+			continue
+		}
+		table.Append([]string{remote.Name, remote.Host, remote.DockerHost, remote.SSHUser, remote.Agent})
+	}
+
+	table.SetBorder(false)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator("-")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+
+	if !do.Quiet {
+		logger.Printf("%s", buf.String())
+	}
+	do.Result = strings.Join(names, ",")
+	return nil
 }
 
-func Edit(args []string) {
+func EditRemote(do *def.Do) error {
+	file := util.GetFileByNameAndType("remotes", do.Name)
+	if file == "" {
+		return unknownRemote(do.Name)
+	}
+	logger.Infof("Editing Remote =>\t\t%s\n", file)
+	do.Result = "success"
+	return Editor(file)
+}
+
+func RenameRemote(do *def.Do) error {
+	if do.Name == do.NewName {
+		return fmt.Errorf("Cannot rename to same name")
+	}
+	if IsKnown(do.NewName) {
+		return fmt.Errorf("A remote named %s already exists.", do.NewName)
+	}
+
+	remote, err := LoadRemoteDefinition(do.Name)
+	if err != nil {
+		return err
+	}
+	oldFile := util.GetFileByNameAndType("remotes", do.Name)
 
+	remote.Name = do.NewName
+	newFile := filepath.Join(util.RemotesPath(), do.NewName+filepath.Ext(oldFile))
+	logger.Infof("Renaming remote =>\t\t%s:%s\n", oldFile, newFile)
+	if err := WriteRemoteDefinitionFile(remote, newFile); err != nil {
+		return err
+	}
+	if err := os.Remove(oldFile); err != nil {
+		return err
+	}
+
+	do.Result = "success"
+	return nil
 }
 
-func Rename(args []string) {
+func RmRemote(do *def.Do) error {
+	for _, name := range do.Operations.Args {
+		file := util.GetFileByNameAndType("remotes", name)
+		if file == "" {
+			return unknownRemote(name)
+		}
+		logger.Infof("Removing file =>\t\t%s\n", file)
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+	do.Result = "success"
+	return nil
+}
 
+// IsKnown returns true if a remote definition file exists for name.
+func IsKnown(name string) bool {
+	return name != "" && util.GetFileByNameAndType("remotes", name) != ""
 }
 
-func Remove(args []string) {
+func LoadRemoteDefinition(name string) (*def.Remote, error) {
+	logger.Debugf("Reading remote def file =>\t%s\n", name)
+	if !IsKnown(name) {
+		return nil, unknownRemote(name)
+	}
+
+	conf, err := config.LoadViperConfig(util.RemotesPath(), name, "remote")
+	if err != nil {
+		return nil, err
+	}
+
+	remote := def.BlankRemote()
+	if err := conf.Marshal(remote); err != nil {
+		return nil, fmt.Errorf("Tragic! The marmots could not read that remote definition file:\n%v\n", err)
+	}
+	if remote.Name == "" {
+		remote.Name = name
+	}
+	return remote, nil
+}
+
+// WriteRemoteDefinitionFile writes the remote to fileName (in the format
+// given by its extension) or to ~/.eris/remotes/NAME.toml if fileName
+// is empty.
+func WriteRemoteDefinitionFile(remote *def.Remote, fileName string) error {
+	if fileName == "" {
+		fileName = filepath.Join(util.RemotesPath(), remote.Name+".toml")
+	}
+	return util.WriteDefinitionFile(remote, fileName)
+}
+
+func checkRemote(remote *def.Remote) error {
+	if remote.Host == "" && remote.DockerHost == "" && remote.Agent == "" {
+		return fmt.Errorf("The marmots need at least a host, a docker host or an agent to reach the remote.")
+	}
+	if remote.DockerHost == "" && remote.Agent == "" && remote.SSHUser == "" {
+		return fmt.Errorf("The marmots need either a docker host, an agent or an ssh user to reach the remote's docker daemon.")
+	}
+	return nil
+}
 
+func unknownRemote(name string) error {
+	return fmt.Errorf("I do not know the remote %s. Check your remotes with [eris remotes ls]", name)
 }
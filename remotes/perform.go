@@ -1,7 +1,168 @@
 package remotes
 
-import ()
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
 
-func Do(args []string) {
+	"github.com/eris-ltd/eris-cli/actions"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/util"
+)
 
+// DoRemote performs an action against the Docker daemon of a remote.
+// Services and chains the action depends on are started on the remote,
+// and its steps run with DOCKER_HOST (and DOCKER_CERT_PATH) pointing at
+// the remote's daemon. The agent of a remote does not run shell steps,
+// so only actions without steps can be performed through it.
+//
+//	do.Name            - remote name
+//	do.Operations.Args - action name (and its variables)
+func DoRemote(do *def.Do) error {
+	remote, err := LoadRemoteDefinition(do.Name)
+	if err != nil {
+		return err
+	}
+
+	if remote.Agent != "" {
+		action, _, err := actions.LoadActionDefinition(strings.Join(do.Operations.Args, "_"))
+		if err != nil {
+			return err
+		}
+		if len(action.Steps) != 0 {
+			return fmt.Errorf("The marmots cannot run the steps of the %s action through the agent of %s. Give the remote a docker_host or an ssh_user to perform it there.", action.Name, remote.Name)
+		}
+	}
+
+	disconnect, err := Connect(remote)
+	if err != nil {
+		return err
+	}
+	defer disconnect()
+
+	logger.Infof("Performing action on remote =>\t%s:%v\n", remote.Name, do.Operations.Args)
+	if err := actions.Do(do); err != nil {
+		return err
+	}
+	do.Result = "success"
+	return nil
+}
+
+// Connect makes the remote the target of all container operations and
+// of docker commands run by eris. If the remote has an agent, operations
+// go through the agent (see perform.HTTPClient). Otherwise eris talks to
+// the remote's Docker daemon, over an ssh tunnel to its socket when no
+// docker_host is given. The returned function tears down the connection.
+func Connect(remote *def.Remote) (func(), error) {
+	if remote.Agent != "" {
+		logger.Debugf("Connecting to remote agent =>\t%s\n", remote.Agent)
+		saved := util.Backend
+		perform.Agent = perform.NewHTTPClient(remote.Agent)
+		util.Backend = perform.Agent
+		return func() {
+			perform.Agent = nil
+			util.Backend = saved
+		}, nil
+	}
+
+	endpoint := remote.DockerHost
+	closeTunnel := func() {}
+	if endpoint == "" {
+		var err error
+		endpoint, closeTunnel, err = tunnel(remote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Debugf("Connecting to remote docker =>\t%s:%s\n", endpoint, remote.DockerCertPath)
+	if err := util.DockerConnectHost(endpoint, remote.DockerCertPath); err != nil {
+		closeTunnel()
+		return nil, fmt.Errorf("The marmots could not connect to the docker daemon of %s.\nERROR =>\t\t\t%v\n", remote.Name, err)
+	}
+
+	restore := setEnv(map[string]string{
+		"DOCKER_HOST":       endpoint,
+		"DOCKER_CERT_PATH":  remote.DockerCertPath,
+		"DOCKER_TLS_VERIFY": tlsVerify(remote),
+	})
+
+	return func() {
+		restore()
+		closeTunnel()
+	}, nil
+}
+
+// tunnel forwards a local port to the remote's docker socket over ssh.
+func tunnel(remote *def.Remote) (string, func(), error) {
+	if remote.SSHUser == "" || remote.Host == "" {
+		return "", nil, fmt.Errorf("The remote %s needs either a docker_host or a host and an ssh_user.", remote.Name)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	local := listener.Addr().String()
+	listener.Close()
+
+	logger.Infof("Opening ssh tunnel =>\t\t%s@%s:%s\n", remote.SSHUser, remote.Host, local)
+	cmd := exec.Command("ssh", "-N",
+		"-o", "ExitOnForwardFailure=yes",
+		"-L", local+":/var/run/docker.sock",
+		remote.SSHUser+"@"+remote.Host)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return "", nil, fmt.Errorf("The marmots could not start ssh: %v", err)
+	}
+	closeTunnel := func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}
+
+	for i := 0; i < 100; i++ {
+		if conn, err := net.Dial("tcp", local); err == nil {
+			conn.Close()
+			return "tcp://" + local, closeTunnel, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	closeTunnel()
+	return "", nil, fmt.Errorf("The ssh tunnel to %s did not come up.", remote.Host)
+}
+
+func tlsVerify(remote *def.Remote) string {
+	if remote.DockerCertPath != "" {
+		return "1"
+	}
+	return ""
+}
+
+// setEnv sets (or unsets, for empty values) environment variables and
+// returns a function putting the old values back.
+func setEnv(vars map[string]string) func() {
+	old := make(map[string]string)
+	for k, v := range vars {
+		old[k] = os.Getenv(k)
+		if strings.TrimSpace(v) == "" {
+			os.Unsetenv(k)
+		} else {
+			os.Setenv(k, v)
+		}
+	}
+
+	return func() {
+		for k, v := range old {
+			if v == "" {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, v)
+			}
+		}
+	}
 }
@@ -0,0 +1,173 @@
+package remotes
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/util"
+
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	log.SetLoggers(0, os.Stdout, os.Stderr)
+
+	var err error
+	erisDir, err = ioutil.TempDir("", "eris_remotes")
+	if err != nil {
+		panic(err)
+	}
+	config.ChangeErisDir(erisDir)
+	config.GlobalConfig, err = config.SetGlobalObject(os.Stdout, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func TestAddRenameRm(t *testing.T) {
+	file := filepath.Join(erisDir, "remote.yaml")
+	if err := ioutil.WriteFile(file, []byte("host: 10.0.0.5\nssh_user: marmot\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	do := def.NowDo()
+	do.Name = "burrow"
+	do.Path = file
+	do.Remote.DockerHost = "tcp://10.0.0.5:2376"
+	if err := AddRemote(do); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	remote, err := LoadRemoteDefinition("burrow")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if remote.Host != "10.0.0.5" || remote.SSHUser != "marmot" || remote.DockerHost != "tcp://10.0.0.5:2376" {
+		t.Fatalf("expected the file and the flags to be merged, got %+v", remote)
+	}
+	if err := AddRemote(do); err == nil {
+		t.Fatalf("expected an error adding a known remote")
+	}
+
+	do = def.NowDo()
+	do.Quiet = true
+	if err := ListRemotes(do); err != nil || do.Result != "burrow" {
+		t.Fatalf("expected the remote to be listed, got %q (%v)", do.Result, err)
+	}
+
+	do = def.NowDo()
+	do.Name = "burrow"
+	do.NewName = "den"
+	if err := RenameRemote(do); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if IsKnown("burrow") || !IsKnown("den") {
+		t.Fatalf("expected the remote to be renamed")
+	}
+	if remote, err := LoadRemoteDefinition("den"); err != nil || remote.Name != "den" || remote.Host != "10.0.0.5" {
+		t.Fatalf("expected the renamed remote, got %+v (%v)", remote, err)
+	}
+
+	do = def.NowDo()
+	do.Operations.Args = []string{"den"}
+	if err := RmRemote(do); err != nil {
+		t.Fatalf("rm: %v", err)
+	}
+	if IsKnown("den") {
+		t.Fatalf("expected the remote to be removed")
+	}
+	if err := RmRemote(do); err == nil {
+		t.Fatalf("expected an error removing an unknown remote")
+	}
+}
+
+func TestCheckRemote(t *testing.T) {
+	for _, remote := range []*def.Remote{
+		{Name: "docker", DockerHost: "tcp://10.0.0.5:2376"},
+		{Name: "ssh", Host: "10.0.0.5", SSHUser: "marmot"},
+		{Name: "agent", Agent: "https://10.0.0.5:46651"},
+	} {
+		if err := checkRemote(remote); err != nil {
+			t.Fatalf("%s: expected no error, got %v", remote.Name, err)
+		}
+	}
+	for _, remote := range []*def.Remote{
+		{Name: "empty"},
+		{Name: "host", Host: "10.0.0.5"},
+	} {
+		if err := checkRemote(remote); err == nil {
+			t.Fatalf("%s: expected an error", remote.Name)
+		}
+	}
+}
+
+func TestSetEnv(t *testing.T) {
+	os.Setenv("ERIS_TEST_SET", "old")
+	os.Unsetenv("ERIS_TEST_UNSET")
+	defer os.Unsetenv("ERIS_TEST_SET")
+
+	restore := setEnv(map[string]string{"ERIS_TEST_SET": "", "ERIS_TEST_UNSET": "new"})
+	if _, ok := os.LookupEnv("ERIS_TEST_SET"); ok || os.Getenv("ERIS_TEST_UNSET") != "new" {
+		t.Fatalf("expected the variables to be changed")
+	}
+	restore()
+	if os.Getenv("ERIS_TEST_SET") != "old" {
+		t.Fatalf("expected the old value back, got %q", os.Getenv("ERIS_TEST_SET"))
+	}
+	if _, ok := os.LookupEnv("ERIS_TEST_UNSET"); ok {
+		t.Fatalf("expected the variable to be unset again")
+	}
+}
+
+func TestAgentRemote(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	disconnect, err := Connect(&def.Remote{Name: "agent", Agent: "localhost:46651"})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if perform.Agent == nil || util.Backend != perform.Agent {
+		t.Fatalf("expected the operations to go through the agent")
+	}
+	disconnect()
+	if perform.Agent != nil || util.Backend != fake {
+		t.Fatalf("expected the backend to be restored")
+	}
+
+	if err := os.MkdirAll(dirs.ActionsPath, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dirs.ActionsPath, "hello.toml"), []byte(`
+name = "hello"
+steps = ["echo hello"]
+`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := WriteRemoteDefinitionFile(&def.Remote{Name: "agent", Agent: "localhost:46651"}, ""); err != nil {
+		t.Fatalf("write remote: %v", err)
+	}
+
+	do := def.NowDo()
+	do.Name = "agent"
+	do.Operations.Args = []string{"hello"}
+	if err := DoRemote(do); err == nil {
+		t.Fatalf("expected an error running action steps through an agent")
+	}
+	if perform.Agent != nil || util.Backend != fake {
+		t.Fatalf("expected nothing to be done through the agent")
+	}
+}
@@ -0,0 +1,146 @@
+// Package fake tests the services package against util.FakeBackend, so
+// that they run without a Docker daemon (unlike those of services).
+package fake
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/services"
+	tests "github.com/eris-ltd/eris-cli/testutils"
+	"github.com/eris-ltd/eris-cli/util"
+
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	var err error
+	erisDir, err = tests.FakeInit("eris_services_fake")
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func writeService(t *testing.T, name, definition string) {
+	if err := ioutil.WriteFile(filepath.Join(dirs.ServicesPath, name+".toml"), []byte(definition), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func TestStartKillRm(t *testing.T) {
+	_, restore := tests.UseFake()
+	defer restore()
+
+	writeService(t, "marmot", `
+name = "marmot"
+
+[service]
+image = "quay.io/eris/base:latest"
+data_container = true
+`)
+
+	do := def.NowDo()
+	do.Operations.Args = []string{"marmot"}
+	if err := services.StartService(do); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	srv := &def.Service{Name: "marmot"}
+	ops := def.BlankOperation()
+	ops.ContainerNumber = 1
+	ops.SrvContainerName = util.ServiceContainersName("marmot", 1)
+	ops.DataContainerName = util.DataContainersName("marmot", 1)
+	if !services.IsServiceRunning(srv, ops) {
+		t.Fatalf("expected the service to be running")
+	}
+	if _, exists := util.ParseContainers(ops.DataContainerName, true); !exists {
+		t.Fatalf("expected the data container to be created")
+	}
+
+	do = def.NowDo()
+	do.Operations.Args = []string{"marmot"}
+	if err := services.KillService(do); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	if services.IsServiceRunning(srv, ops) || !services.IsServiceExisting(srv, ops) {
+		t.Fatalf("expected the service to be stopped")
+	}
+
+	do = def.NowDo()
+	do.Operations.Args = []string{"marmot"}
+	do.RmD = true
+	if err := services.RmService(do); err != nil {
+		t.Fatalf("rm: %v", err)
+	}
+	if services.IsServiceExisting(srv, ops) {
+		t.Fatalf("expected the service to be removed")
+	}
+	if _, exists := util.ParseContainers(ops.DataContainerName, true); exists {
+		t.Fatalf("expected the data container to be removed")
+	}
+}
+
+func TestStartDependencies(t *testing.T) {
+	fake, restore := tests.UseFake()
+	defer restore()
+
+	writeService(t, "keys", `
+name = "keys"
+
+[service]
+image = "quay.io/eris/keys:latest"
+`)
+	writeService(t, "wallet", `
+name = "wallet"
+
+[service]
+image = "quay.io/eris/base:latest"
+
+[dependencies]
+services = ["keys"]
+`)
+
+	do := def.NowDo()
+	do.Operations.Args = []string{"wallet"}
+	if err := services.StartService(do); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	containers, err := fake.ListContainers(docker.ListContainersOptions{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	running := make(map[string]bool)
+	for _, c := range containers {
+		running[c.Names[0]] = true
+	}
+	for _, name := range []string{"wallet", "keys"} {
+		if !running["/"+util.ServiceContainersName(name, 1)] {
+			t.Fatalf("expected %s to be running, got %v", name, running)
+		}
+	}
+	for _, image := range []string{"quay.io/eris/keys:latest", "quay.io/eris/base:latest"} {
+		if _, err := fake.InspectImage(image); err != nil {
+			t.Fatalf("expected %s to be pulled before the start, got %v", image, err)
+		}
+	}
+
+	do = def.NowDo()
+	do.Operations.Args = []string{"wallet", "keys"}
+	do.Rm = true
+	if err := services.KillService(do); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	if containers, _ := fake.ListContainers(docker.ListContainersOptions{All: true}); len(containers) != 0 {
+		t.Fatalf("expected the services to be removed, got %v", containers)
+	}
+}
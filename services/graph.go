@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/util"
+)
+
+// Services of a group which do not depend on each other are started
+// concurrently, startWorkers at a time at most.
+const startWorkers = 4
+
+// A groupBuilder loads a service and its dependencies depth first into
+// group, dependencies before their dependents. Services are identified
+// by their container names.
+type groupBuilder struct {
+	cNum  int
+	group []*definitions.ServiceDefinition
+	added map[string]bool
+	path  []string // the services being loaded, for cycle errors
+}
+
+func newGroupBuilder(cNum int, group []*definitions.ServiceDefinition) *groupBuilder {
+	b := &groupBuilder{cNum: cNum, added: make(map[string]bool)}
+	for _, srv := range group {
+		b.added[srv.Operations.SrvContainerName] = true
+	}
+	return b
+}
+
+// add loads the service (or the chain, typ being definitions.TypeChain)
+// name and its dependencies.
+func (b *groupBuilder) add(typ, name string) error {
+	key := util.ContainersName(typ, name, b.cNum)
+	if b.added[key] {
+		return nil
+	}
+	for i, k := range b.path {
+		if k == key {
+			return cycleError(append(b.path[i:], key))
+		}
+	}
+
+	var srv *definitions.ServiceDefinition
+	var err error
+	if typ == definitions.TypeChain {
+		srv, err = loaders.ChainsAsAService(name, false, b.cNum)
+	} else {
+		srv, err = loaders.LoadServiceDefinition(name, false, b.cNum)
+	}
+	if err != nil {
+		return err
+	}
+
+	b.path = append(b.path, key)
+	if srv.Dependencies != nil {
+		for _, dep := range srv.Dependencies.Services {
+			logger.Debugf("Found service dependency =>\t%s\n", dep)
+			if err := b.add(definitions.TypeService, dep); err != nil {
+				return err
+			}
+		}
+		for _, dep := range srv.Dependencies.Chains {
+			logger.Debugf("Found chain dependency =>\t%s\n", dep)
+			if err := b.add(definitions.TypeChain, dep); err != nil {
+				return err
+			}
+		}
+	}
+	b.path = b.path[:len(b.path)-1]
+
+	b.added[key] = true
+	b.added[srv.Operations.SrvContainerName] = true
+	b.group = append(b.group, srv)
+	return nil
+}
+
+// A serviceGraph is the dependency graph of a group of services.
+type serviceGraph struct {
+	nodes []*serviceNode
+}
+
+type serviceNode struct {
+	srv        *definitions.ServiceDefinition
+	key        string
+	deps       []*serviceNode
+	dependents []*serviceNode
+}
+
+// newServiceGraph links the services of group to the services and chains
+// of the group they depend on. Dependencies which are not in the group
+// are left out (they are expected to be running already). Services
+// given more than once are only kept once.
+func newServiceGraph(group []*definitions.ServiceDefinition) (*serviceGraph, error) {
+	g := &serviceGraph{}
+	nodes := make(map[string]*serviceNode)
+	for _, srv := range group {
+		key := srv.Operations.SrvContainerName
+		if _, ok := nodes[key]; ok {
+			continue
+		}
+		n := &serviceNode{srv: srv, key: key}
+		nodes[key] = n
+		g.nodes = append(g.nodes, n)
+	}
+
+	for _, n := range g.nodes {
+		for _, key := range dependencyKeys(n.srv) {
+			if dep, ok := nodes[key]; ok && dep != n {
+				n.deps = append(n.deps, dep)
+				dep.dependents = append(dep.dependents, n)
+			}
+		}
+	}
+
+	if err := g.checkCycles(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// checkCycles returns an error with the path of the first dependency
+// cycle found.
+func (g *serviceGraph) checkCycles() error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[*serviceNode]int)
+	var path []string
+
+	var visit func(n *serviceNode) error
+	visit = func(n *serviceNode) error {
+		switch state[n] {
+		case visited:
+			return nil
+		case visiting:
+			for i, k := range path {
+				if k == n.key {
+					return cycleError(append(path[i:], n.key))
+				}
+			}
+		}
+
+		state[n] = visiting
+		path = append(path, n.key)
+		for _, dep := range n.deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[n] = visited
+		return nil
+	}
+
+	for _, n := range g.nodes {
+		if err := visit(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sorted returns the services of the graph with each one after the
+// services it depends on.
+func (g *serviceGraph) sorted() []*definitions.ServiceDefinition {
+	var sorted []*definitions.ServiceDefinition
+	done := make(map[*serviceNode]bool)
+
+	var visit func(n *serviceNode)
+	visit = func(n *serviceNode) {
+		if done[n] {
+			return
+		}
+		done[n] = true
+		for _, dep := range n.deps {
+			visit(dep)
+		}
+		sorted = append(sorted, n.srv)
+	}
+	for _, n := range g.nodes {
+		visit(n)
+	}
+	return sorted
+}
+
+// walk calls run for each service of the graph once the services it
+// depends on are done, with at most workers runs at a time. After the
+// first error no more runs are begun and the context given to the runs
+// under way is canceled; they are waited for. The services run was
+// called for are returned in that order.
+func (g *serviceGraph) walk(workers int, run func(context.Context, *definitions.ServiceDefinition) error) ([]*definitions.ServiceDefinition, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type result struct {
+		node *serviceNode
+		err  error
+	}
+
+	var ready []*serviceNode
+	waiting := make(map[*serviceNode]int)
+	for _, n := range g.nodes {
+		waiting[n] = len(n.deps)
+		if len(n.deps) == 0 {
+			ready = append(ready, n)
+		}
+	}
+
+	var attempted []*definitions.ServiceDefinition
+	var firstErr error
+	results := make(chan result)
+	running := 0
+	for {
+		for firstErr == nil && running < workers && len(ready) > 0 {
+			n := ready[0]
+			ready = ready[1:]
+			attempted = append(attempted, n.srv)
+			running++
+			go func(n *serviceNode) {
+				results <- result{n, run(ctx, n.srv)}
+			}(n)
+		}
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+				cancel()
+			}
+			continue
+		}
+		for _, n := range r.node.dependents {
+			waiting[n]--
+			if waiting[n] == 0 {
+				ready = append(ready, n)
+			}
+		}
+	}
+
+	return attempted, firstErr
+}
+
+// cycleError reports the dependency cycle of the containers path.
+func cycleError(path []string) error {
+	names := make([]string, len(path))
+	for i, key := range path {
+		cont := util.ContainerDisassemble(key)
+		names[i] = cont.ShortName
+		if cont.Type == definitions.TypeChain {
+			names[i] += " (chain)"
+		}
+	}
+	return fmt.Errorf("The marmots found a dependency cycle: %s", strings.Join(names, " -> "))
+}
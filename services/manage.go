@@ -16,6 +16,7 @@ import (
 	"github.com/eris-ltd/eris-cli/perform"
 	"github.com/eris-ltd/eris-cli/util"
 
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/BurntSushi/toml"
 	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/ipfs"
 )
@@ -27,7 +28,13 @@ func ImportService(do *definitions.Do) error {
 	}
 
 	var err error
-	if logger.Level > 0 {
+	if util.IsGitLocation(do.Hash) {
+		if logger.Level > 0 {
+			_, err = util.GetFromGit(do.Hash, do.Name, ServicesPath, nil, logger.Writer)
+		} else {
+			_, err = util.GetFromGit(do.Hash, do.Name, ServicesPath, nil, bytes.NewBuffer([]byte{}))
+		}
+	} else if logger.Level > 0 {
 		err = ipfs.GetFromIPFS(do.Hash, fileName, "", logger.Writer)
 	} else {
 		err = ipfs.GetFromIPFS(do.Hash, fileName, "", bytes.NewBuffer([]byte{}))
@@ -204,7 +211,16 @@ func UpdateService(do *definitions.Do) error {
 	}
 	service.Service.Environment = append(service.Service.Environment, do.Env...)
 	service.Service.Links = append(service.Service.Links, do.Links...)
-	err = perform.DockerRebuild(service.Service, service.Operations, do.Pull, do.Timeout)
+
+	// A built image is not in a registry to pull it from.
+	pull := do.Pull
+	if !service.Build.IsEmpty() {
+		if err := perform.DockerBuild(service.Service, service.Build); err != nil {
+			return err
+		}
+		pull = false
+	}
+	err = perform.DockerRebuild(service.Service, service.Operations, pull, do.Timeout)
 	if err != nil {
 		return err
 	}
@@ -242,6 +258,10 @@ func RmService(do *definitions.Do) error {
 }
 
 func CatService(do *definitions.Do) error {
+	if do.Resolved {
+		return catResolvedService(do)
+	}
+
 	configs := util.GetGlobalLevelConfigFilesByType("services", true)
 	for _, c := range configs {
 		cName := strings.Split(filepath.Base(c), ".")[0]
@@ -258,6 +278,25 @@ func CatService(do *definitions.Do) error {
 	return fmt.Errorf("Unknown service %s or invalid file extension", do.Name)
 }
 
+// catResolvedService displays the service definition of do.Name with
+// the definitions it extends merged in, as TOML.
+func catResolvedService(do *definitions.Do) error {
+	settings, err := loaders.ResolvedSettings(ServicesPath, do.Name, "service")
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	enc := toml.NewEncoder(buf)
+	enc.Indent = ""
+	if err := enc.Encode(settings); err != nil {
+		return err
+	}
+	do.Result = buf.String()
+	logger.Println(do.Result)
+	return nil
+}
+
 func InspectServiceByService(srv *definitions.Service, ops *definitions.Operation, field string) error {
 	err := perform.DockerInspect(srv, ops, field)
 	if err != nil {
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -51,15 +52,24 @@ func StartService(do *definitions.Do) (err error) {
 	return StartGroup(services)
 }
 
+// KillService stops the services do.Operations.Args and the services
+// they depend on, dependents first. Dependencies which other running
+// services or chains still need are left running, and chains are not
+// stopped as dependencies. With do.Cascade, the running services and
+// chains which depend on the services are stopped first.
 func KillService(do *definitions.Do) (err error) {
 	var services []*definitions.ServiceDefinition
+	targets := make(map[string]bool)
 
 	logger.Infof("Building the Services Group =>\t%v\n", do.Operations.Args)
 	for _, servName := range do.Operations.Args {
-		s, e := BuildServicesGroup(servName, do.Operations.ContainerNumber)
+		s, e := BuildServicesGroup(servName, do.Operations.ContainerNumber, services...)
 		if e != nil {
 			return e
 		}
+		if len(s) > 0 {
+			targets[s[len(s)-1].Operations.SrvContainerName] = true
+		}
 		services = append(services, s...)
 	}
 
@@ -68,25 +78,7 @@ func KillService(do *definitions.Do) (err error) {
 		do.Timeout = 0
 	}
 
-	for _, service := range services {
-		if IsServiceRunning(service.Service, service.Operations) {
-			logger.Debugf("Stopping Service =>\t\t%s:%d\n", service.Service.Name, service.Operations.ContainerNumber)
-			if err := perform.DockerStop(service.Service, service.Operations, do.Timeout); err != nil {
-				return err
-			}
-
-		} else {
-			logger.Infof("Service (%s) not currently running. Skipping.\n", service.Service.Name)
-		}
-
-		if do.Rm {
-			if err := perform.DockerRemove(service.Service, service.Operations, do.RmD, do.Volumes); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
+	return stopServices(stopPlan(services, targets, runningServices(), do.Cascade), do)
 }
 
 func ExecService(do *definitions.Do) error {
@@ -116,55 +108,153 @@ func ExecService(do *definitions.Do) error {
 		service.Service.Links = do.Links
 	}
 
+	if err := perform.DockerBuild(service.Service, service.Build); err != nil {
+		return err
+	}
 	return perform.DockerExecService(service.Service, service.Operations)
 }
 
-// TODO: test this recursion and service deps generally
+// BuildServicesGroup loads the service srvName and the services and
+// chains it depends on, recursively. Each of them is returned once,
+// after its dependencies (srvName is last). Those already in services
+// are not returned again. A dependency cycle is an error giving the
+// cycle's path.
 func BuildServicesGroup(srvName string, cNum int, services ...*definitions.ServiceDefinition) ([]*definitions.ServiceDefinition, error) {
 	logger.Debugf("BuildServicesGroup for =>\t%s:%d\n", srvName, len(services))
-	srv, err := loaders.LoadServiceDefinition(srvName, false, cNum)
-	if err != nil {
+	b := newGroupBuilder(cNum, services)
+	if err := b.add(definitions.TypeService, srvName); err != nil {
 		return nil, err
 	}
-	if srv.Dependencies != nil {
-		for _, sName := range srv.Dependencies.Services {
-			logger.Debugf("Found service dependency =>\t%s\n", sName)
-			s, e := BuildServicesGroup(sName, cNum)
-			if e != nil {
-				return nil, e
-			}
-			services = append(services, s...)
+	return b.group, nil
+}
+
+// BuildGroup loads the chains and services given and the services and
+// chains they depend on, recursively, as BuildServicesGroup does.
+func BuildGroup(services, chains []string, cNum int) ([]*definitions.ServiceDefinition, error) {
+	b := newGroupBuilder(cNum, nil)
+	for _, name := range chains {
+		if err := b.add(definitions.TypeChain, name); err != nil {
+			return nil, err
 		}
 	}
-	services = append(services, srv)
-	return services, nil
+	for _, name := range services {
+		if err := b.add(definitions.TypeService, name); err != nil {
+			return nil, err
+		}
+	}
+	return b.group, nil
 }
 
-// start a group of chains or services. catch errors on a channel so we can stop as soon as something goes wrong
+// StartGroup starts the services (and chains) of group once the services
+// and chains of the group they depend on are running and, for those with
+// a [healthcheck], healthy. Services which do not depend on each other
+// are started concurrently. On the first failure no more services are
+// started and the containers started by this call are stopped again
+// (and removed if this call created them). The definition files of the
+// services and chains to start are validated first.
 func StartGroup(group []*definitions.ServiceDefinition) error {
 	logger.Debugf("Starting services group =>\t%d Services\n", len(group))
+	graph, err := newServiceGraph(group)
+	if err != nil {
+		return err
+	}
+
+	// what was there before, to know what to roll back
+	existing := make(map[string]bool)
+	running := make(map[string]bool)
+	for _, srv := range group {
+		_, existing[srv.Operations.SrvContainerName] = perform.ContainerExists(srv.Operations)
+		_, running[srv.Operations.SrvContainerName] = perform.ContainerRunning(srv.Operations)
+	}
+
 	for _, srv := range group {
+		if running[srv.Operations.SrvContainerName] {
+			continue
+		}
+		kind := "services"
+		if util.ContainersType(srv.Operations.SrvContainerName) == definitions.TypeChain {
+			kind = "chains"
+		}
+		if err := loaders.CheckDefinition(kind, srv.Name); err != nil {
+			return err
+		}
+	}
+
+	// Missing images are pulled (and the user asked about them) one at
+	// a time, before any service is started.
+	for _, srv := range graph.sorted() {
+		name := srv.Operations.SrvContainerName
+		if existing[name] || running[name] || !srv.Build.IsEmpty() || srv.Service.Image == "" {
+			continue
+		}
+		if err := perform.DockerEnsureImage(srv.Service.Image); err != nil {
+			return fmt.Errorf("StartGroup. Err pulling srv =>\t%s:%v\n", srv.Name, err)
+		}
+	}
+
+	attempted, err := graph.walk(startWorkers, func(ctx context.Context, srv *definitions.ServiceDefinition) error {
+		if err := perform.DockerBuild(srv.Service, srv.Build); err != nil {
+			return fmt.Errorf("StartGroup. Err building srv =>\t%s:%v\n", srv.Name, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		logger.Debugf("Telling Docker to start srv =>\t%s\n", srv.Name)
 		if err := perform.DockerRunService(srv.Service, srv.Operations); err != nil {
 			return fmt.Errorf("StartGroup. Err starting srv =>\t%s:%v\n", srv.Name, err)
 		}
+		return perform.DockerWaitHealthyContext(ctx, srv.Service, srv.Operations, srv.HealthCheck)
+	})
+	if err != nil {
+		rollbackGroup(attempted, existing, running)
+		return err
 	}
 	return nil
 }
 
+// rollbackGroup stops the services of attempted which were not running
+// before, last started first, and removes the containers which did not
+// exist before.
+func rollbackGroup(attempted []*definitions.ServiceDefinition, existing, running map[string]bool) {
+	for i := len(attempted) - 1; i >= 0; i-- {
+		srv := attempted[i]
+		name := srv.Operations.SrvContainerName
+		if running[name] {
+			continue
+		}
+
+		logger.Infof("Rolling back =>\t\t\t%s\n", srv.Name)
+		if _, ok := perform.ContainerRunning(srv.Operations); ok {
+			if err := perform.DockerStop(srv.Service, srv.Operations, 10); err != nil {
+				logger.Errorf("Error stopping %s: %v\n", srv.Name, err)
+				continue
+			}
+		}
+		if _, ok := perform.ContainerExists(srv.Operations); ok && !existing[name] {
+			if err := perform.DockerRemove(srv.Service, srv.Operations, false, false); err != nil {
+				logger.Errorf("Error removing %s: %v\n", srv.Name, err)
+			}
+		}
+	}
+}
+
 // BuildChainGroup adds the chain specified in each service definition to the service group.
 // If chainName is not empty, it will overwrite chains specified in the defs.
 // Service defs which don't specify a chain or $chain won't connect to a chain.
 // NOTE: chains have to be started before services that depend on them.
 func BuildChainGroup(chainName string, services []*definitions.ServiceDefinition) (servicesAndChains []*definitions.ServiceDefinition, err error) {
 	var chains = make(map[string]*definitions.ServiceDefinition)
+	var inGroup = make(map[string]bool)
+	for _, srv := range services {
+		inGroup[srv.Operations.SrvContainerName] = true
+	}
 	for _, srv := range services {
 		if srv.Chain != "" {
 			s, err := ConnectChainToService(chainName, srv.Chain, srv)
 			if err != nil {
 				return nil, err
 			}
-			if _, ok := chains[s.Name]; !ok {
+			if _, ok := chains[s.Name]; !ok && !inGroup[s.Operations.SrvContainerName] {
 				chains[s.Name] = s
 			}
 		}
@@ -196,5 +286,16 @@ func ConnectChainToService(chainFlag, chainNameAndOpts string, srv *definitions.
 	// XXX: we may have name collision here if we're not careful.
 	loaders.ConnectToAChain(srv.Service, srv.Operations, chainName, internalName, link, mount)
 
+	// the service is started after the chain
+	if srv.Dependencies == nil {
+		srv.Dependencies = &definitions.Dependencies{}
+	}
+	for _, c := range srv.Dependencies.Chains {
+		if c == chainName {
+			return s, nil
+		}
+	}
+	srv.Dependencies.Chains = append(srv.Dependencies.Chains, chainName)
+
 	return s, nil
 }
@@ -1,18 +1,24 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	def "github.com/eris-ltd/eris-cli/definitions"
 	ini "github.com/eris-ltd/eris-cli/initialize"
 	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
 	tests "github.com/eris-ltd/eris-cli/testutils"
 	"github.com/eris-ltd/eris-cli/util"
 
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
 )
 
@@ -389,9 +395,238 @@ func TestStartKillServiceWithDependencies(t *testing.T) {
 	testNumbersExistAndRun(t, servName, 1, 1)
 }
 
+func TestServicesGraph(t *testing.T) {
+	a := testGroupService("a")
+	b := testGroupService("b", "a")
+	c := testGroupService("c", "a")
+	d := testGroupService("d", "b", "c")
+	group := []*def.ServiceDefinition{d, c, b, a, b}
+
+	graph, err := newServiceGraph(group)
+	if err != nil {
+		tests.IfExit(err)
+	}
+
+	// b and c only start when both of them are running at once
+	var mu sync.Mutex
+	var order []string
+	both := make(chan struct{})
+	var once sync.Once
+	inFlight := 0
+	run := func(ctx context.Context, srv *def.ServiceDefinition) error {
+		mu.Lock()
+		order = append(order, srv.Name)
+		inFlight++
+		if inFlight == 2 {
+			once.Do(func() { close(both) })
+		}
+		mu.Unlock()
+
+		if srv.Name == "b" || srv.Name == "c" {
+			select {
+			case <-both:
+			case <-time.After(5 * time.Second):
+				return fmt.Errorf("%s was not started along with its sibling", srv.Name)
+			}
+		}
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	}
+	attempted, err := graph.walk(2, run)
+	if err != nil {
+		tests.IfExit(err)
+	}
+	if len(attempted) != 4 || order[0] != "a" || order[3] != "d" {
+		tests.IfExit(fmt.Errorf("wrong start order %v", order))
+	}
+
+	// a failure stops what depends on it
+	order = nil
+	attempted, err = graph.walk(1, func(ctx context.Context, srv *def.ServiceDefinition) error {
+		order = append(order, srv.Name)
+		if srv.Name == "b" {
+			return fmt.Errorf("b failed")
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "b failed" {
+		tests.IfExit(fmt.Errorf("expected b to fail, got %v", err))
+	}
+	for _, srv := range attempted {
+		if srv.Name == "d" {
+			tests.IfExit(fmt.Errorf("d was started after b failed: %v", order))
+		}
+	}
+
+	// a failure cancels the runs under way
+	attempted, err = graph.walk(2, func(ctx context.Context, srv *def.ServiceDefinition) error {
+		switch srv.Name {
+		case "b":
+			return fmt.Errorf("b failed")
+		case "c":
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return fmt.Errorf("c was not canceled after b failed")
+			}
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "b failed" {
+		tests.IfExit(fmt.Errorf("expected b to fail, got %v", err))
+	}
+	if len(attempted) != 3 {
+		tests.IfExit(fmt.Errorf("expected a, b and c to be started, got %d", len(attempted)))
+	}
+
+	// cycles are reported with their path
+	x := testGroupService("x", "y")
+	y := testGroupService("y", "z")
+	z := testGroupService("z", "x")
+	_, err = newServiceGraph([]*def.ServiceDefinition{a, x, y, z})
+	if err == nil || !strings.Contains(err.Error(), "x -> y -> z -> x") {
+		tests.IfExit(fmt.Errorf("expected a cycle error, got %v", err))
+	}
+}
+
+func TestBuildServicesGroupCycle(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	util.Backend = util.NewFakeBackend()
+
+	for name, deps := range map[string]string{
+		"graph_top":   `"graph_left", "graph_right"`,
+		"graph_left":  `"graph_base"`,
+		"graph_right": `"graph_base"`,
+		"graph_base":  ``,
+		"cycle_a":     `"cycle_b"`,
+		"cycle_b":     `"cycle_a"`,
+	} {
+		file := filepath.Join(common.ServicesPath, name+".toml")
+		contents := fmt.Sprintf("name = %q\n\n[service]\nimage = \"quay.io/eris/base\"\n\n[dependencies]\nservices = [%s]\n", name, deps)
+		if err := ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
+			tests.IfExit(err)
+		}
+		defer os.Remove(file)
+	}
+
+	group, err := BuildServicesGroup("graph_top", 1)
+	if err != nil {
+		tests.IfExit(err)
+	}
+	var names []string
+	for _, srv := range group {
+		names = append(names, srv.Name)
+	}
+	if strings.Join(names, ",") != "graph_base,graph_left,graph_right,graph_top" {
+		tests.IfExit(fmt.Errorf("wrong services group %v", names))
+	}
+
+	if _, err := BuildServicesGroup("cycle_a", 1); err == nil || !strings.Contains(err.Error(), "cycle_a -> cycle_b -> cycle_a") {
+		tests.IfExit(fmt.Errorf("expected a cycle error, got %v", err))
+	}
+}
+
+func TestStartGroupRollback(t *testing.T) {
+	saved := util.Backend
+	defer func() { util.Backend = saved }()
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+
+	up := testGroupService("rollback_up")
+	if err := perform.DockerRunService(up.Service, up.Operations); err != nil {
+		tests.IfExit(err)
+	}
+	base := testGroupService("rollback_base", "rollback_up")
+	broken := testGroupService("rollback_broken", "rollback_base")
+	broken.HealthCheck = &def.HealthCheck{ExecCommand: "false", Interval: "10ms", Retries: 1}
+
+	if err := StartGroup([]*def.ServiceDefinition{up, base, broken}); err == nil {
+		tests.IfExit(fmt.Errorf("expected an error starting a service which never gets healthy"))
+	}
+
+	if _, running := perform.ContainerRunning(up.Operations); !running {
+		tests.IfExit(fmt.Errorf("the service running before was stopped"))
+	}
+	for _, srv := range []*def.ServiceDefinition{base, broken} {
+		if _, exists := perform.ContainerExists(srv.Operations); exists {
+			tests.IfExit(fmt.Errorf("the container of %s was not rolled back", srv.Name))
+		}
+	}
+}
+
+func TestStopPlan(t *testing.T) {
+	keys := testGroupService("keys")
+	db := testGroupService("db", "keys")
+	app1 := testGroupService("app1", "db")
+	app2 := testGroupService("app2", "db")
+	chain := testGroupService("chainy", "keys")
+	chain.Operations.SrvContainerName = util.ChainContainersName("chainy", 1)
+	web := testGroupService("web", "app1")
+	web.Dependencies.Chains = []string{"chainy"}
+
+	running := make(map[string]*def.ServiceDefinition)
+	for _, srv := range []*def.ServiceDefinition{keys, db, app1, app2, chain, web} {
+		running[srv.Operations.SrvContainerName] = srv
+	}
+	plan := func(cascade bool, group ...*def.ServiceDefinition) string {
+		targets := map[string]bool{group[len(group)-1].Operations.SrvContainerName: true}
+		var names []string
+		for _, srv := range stopPlan(group, targets, running, cascade) {
+			names = append(names, srv.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	for _, test := range []struct {
+		cascade  bool
+		group    []*def.ServiceDefinition
+		expected string
+	}{
+		// db is still needed by app2, and keys by the chain
+		{false, []*def.ServiceDefinition{keys, db, app1}, "app1"},
+		// the dependents go first, the chain is not stopped as a dependency
+		{true, []*def.ServiceDefinition{keys, db, app1}, "web,app1"},
+		{false, []*def.ServiceDefinition{keys, db, app1, chain, web}, "web,app1"},
+		{true, []*def.ServiceDefinition{keys}, "app2,web,app1,db,chainy,keys"},
+	} {
+		if got := plan(test.cascade, test.group...); got != test.expected {
+			tests.IfExit(fmt.Errorf("wrong stop plan for %s (cascade %v). Got %s, expected %s", test.group[len(test.group)-1].Name, test.cascade, got, test.expected))
+		}
+	}
+
+	// with both of its users gone db is stopped, after them
+	delete(running, web.Operations.SrvContainerName)
+	targets := map[string]bool{app1.Operations.SrvContainerName: true, app2.Operations.SrvContainerName: true}
+	var names []string
+	for _, srv := range stopPlan([]*def.ServiceDefinition{keys, db, app1, app2}, targets, running, false) {
+		names = append(names, srv.Name)
+	}
+	if strings.Join(names, ",") != "app2,app1,db" {
+		tests.IfExit(fmt.Errorf("wrong stop plan for app1 and app2: %v", names))
+	}
+}
+
 //----------------------------------------------------------------------
 // test utils!
 
+// testGroupService returns a service definition depending on the
+// services deps for the services graph tests.
+func testGroupService(name string, deps ...string) *def.ServiceDefinition {
+	srv := def.BlankServiceDefinition()
+	srv.Name = name
+	srv.Service.Name = name
+	srv.Service.Image = "quay.io/eris/base"
+	srv.Operations.ContainerNumber = 1
+	srv.Operations.SrvContainerName = util.ServiceContainersName(name, 1)
+	srv.Dependencies = &def.Dependencies{Services: deps}
+	return srv
+}
+
 func testStartService(t *testing.T, serviceName string, publishAll bool) {
 	do := def.NowDo()
 	do.Operations.Args = []string{serviceName}
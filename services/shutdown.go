@@ -0,0 +1,238 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/util"
+)
+
+// KillDependents stops the running services and chains which depend on
+// the container of the service or chain (typ) name, dependents first.
+// They are removed as well with do.Rm.
+//
+//	do.Operations.ContainerNumber - container number
+//	do.Timeout                    - timeout to stop the containers
+//	do.Rm, do.RmD, do.Volumes     - remove the containers
+func KillDependents(typ, name string, do *definitions.Do) error {
+	key := util.ContainersName(typ, name, do.Operations.ContainerNumber)
+	running := runningServices()
+
+	plan := dependentsOf(map[string]bool{key: true}, running)
+	return stopServices(stopOrder(plan), do)
+}
+
+// stopPlan returns the services to stop to take down the services of
+// targets (container names), dependents first. group are the targets
+// and their dependencies, as BuildServicesGroup returns them. A
+// dependency is only stopped when no running service or chain outside
+// of the plan depends on it; chains are not stopped as dependencies.
+// With cascade, the running services and chains which depend on the
+// targets are stopped too.
+func stopPlan(group []*definitions.ServiceDefinition, targets map[string]bool, running map[string]*definitions.ServiceDefinition, cascade bool) []*definitions.ServiceDefinition {
+	stop := make(map[string]bool)
+	var plan []*definitions.ServiceDefinition
+	for _, srv := range group {
+		if key := srv.Operations.SrvContainerName; targets[key] && !stop[key] {
+			stop[key] = true
+			plan = append(plan, srv)
+		}
+	}
+
+	if cascade {
+		for _, srv := range dependentsOf(stop, running) {
+			if !stop[srv.Operations.SrvContainerName] {
+				stop[srv.Operations.SrvContainerName] = true
+				plan = append(plan, srv)
+			}
+		}
+	} else {
+		for _, srv := range dependentsOf(stop, running) {
+			if names := dependencyNames(srv, stop); len(names) > 0 {
+				logger.Infof("%s still depends on %s. Stop it as well with --cascade.\n", srv.Name, strings.Join(names, ", "))
+			}
+		}
+	}
+
+	// dependents come after their dependencies in group
+	for i := len(group) - 1; i >= 0; i-- {
+		srv := group[i]
+		key := srv.Operations.SrvContainerName
+		if stop[key] || util.ContainersType(key) == definitions.TypeChain {
+			continue
+		}
+		if user := neededBy(key, stop, running); user != "" {
+			logger.Infof("Leaving %s running for =>\t%s\n", srv.Name, util.ContainersShortName(user))
+			continue
+		}
+		stop[key] = true
+		plan = append(plan, srv)
+	}
+
+	return stopOrder(plan)
+}
+
+// stopServices stops (and removes with do.Rm) the services of plan in
+// order.
+func stopServices(plan []*definitions.ServiceDefinition, do *definitions.Do) error {
+	for _, srv := range plan {
+		if IsServiceRunning(srv.Service, srv.Operations) {
+			logger.Debugf("Stopping Service =>\t\t%s:%d\n", srv.Service.Name, srv.Operations.ContainerNumber)
+			if err := perform.DockerStop(srv.Service, srv.Operations, do.Timeout); err != nil {
+				return err
+			}
+		} else {
+			logger.Infof("Service (%s) not currently running. Skipping.\n", srv.Service.Name)
+		}
+
+		if do.Rm {
+			if err := perform.DockerRemove(srv.Service, srv.Operations, do.RmD, do.Volumes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stopOrder sorts plan so that services come before the services of
+// plan they depend on.
+func stopOrder(plan []*definitions.ServiceDefinition) []*definitions.ServiceDefinition {
+	graph, err := newServiceGraph(plan)
+	if err != nil {
+		// running services cannot depend on each other in circles
+		logger.Debugf("%v\n", err)
+		return plan
+	}
+	sorted := graph.sorted()
+	for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	}
+	return sorted
+}
+
+// dependentsOf returns the running services and chains which depend,
+// directly or not, on the containers of keys.
+func dependentsOf(keys map[string]bool, running map[string]*definitions.ServiceDefinition) []*definitions.ServiceDefinition {
+	found := make(map[string]bool)
+	for key := range keys {
+		found[key] = true
+	}
+
+	var dependents []*definitions.ServiceDefinition
+	for more := true; more; {
+		more = false
+		for _, key := range sortedKeys(running) {
+			srv := running[key]
+			if found[key] {
+				continue
+			}
+			for _, dep := range dependencyKeys(srv) {
+				if found[dep] {
+					found[key] = true
+					dependents = append(dependents, srv)
+					more = true
+					break
+				}
+			}
+		}
+	}
+	return dependents
+}
+
+// neededBy returns a running service or chain, not in stop, which
+// depends on the container key, or "".
+func neededBy(key string, stop map[string]bool, running map[string]*definitions.ServiceDefinition) string {
+	for _, user := range sortedKeys(running) {
+		if stop[user] {
+			continue
+		}
+		for _, dep := range dependencyKeys(running[user]) {
+			if dep == key {
+				return user
+			}
+		}
+	}
+	return ""
+}
+
+func sortedKeys(running map[string]*definitions.ServiceDefinition) []string {
+	var keys []string
+	for key := range running {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// dependencyNames returns the names of the dependencies of srv in keys.
+func dependencyNames(srv *definitions.ServiceDefinition, keys map[string]bool) []string {
+	var names []string
+	for _, dep := range dependencyKeys(srv) {
+		if keys[dep] {
+			names = append(names, util.ContainersShortName(dep))
+		}
+	}
+	return names
+}
+
+// dependencyKeys returns the container names of the services and chains
+// srv depends on, the chain it is connected to included.
+func dependencyKeys(srv *definitions.ServiceDefinition) []string {
+	cNum := srv.Operations.ContainerNumber
+	var keys []string
+	if srv.Dependencies != nil {
+		for _, dep := range srv.Dependencies.Services {
+			name, _, _, _ := util.ParseDependency(dep)
+			keys = append(keys, util.ServiceContainersName(name, cNum))
+		}
+		for _, dep := range srv.Dependencies.Chains {
+			name, _, _, _ := util.ParseDependency(dep)
+			keys = append(keys, util.ChainContainersName(name, cNum))
+		}
+	}
+	if srv.Chain != "" {
+		name, _, _, _ := util.ParseDependency(srv.Chain)
+		if strings.HasPrefix(name, "$") {
+			name, _ = util.GetHead()
+		}
+		if name != "" {
+			keys = append(keys, util.ChainContainersName(name, cNum))
+		}
+	}
+	return keys
+}
+
+// runningServices loads the definitions of the running services and
+// chains by container name. Those without a definition file are left
+// out.
+func runningServices() map[string]*definitions.ServiceDefinition {
+	running := make(map[string]*definitions.ServiceDefinition)
+
+	for _, cont := range util.ErisContainersByType(definitions.TypeService, false) {
+		srv, err := loaders.LoadServiceDefinition(cont.ShortName, false, cont.Number)
+		if err != nil {
+			logger.Debugf("Cannot load the running service %s: %v\n", cont.ShortName, err)
+			continue
+		}
+		running[cont.FullName] = srv
+	}
+
+	for _, cont := range util.ErisContainersByType(definitions.TypeChain, false) {
+		chain, err := loaders.LoadChainDefinition(cont.ShortName, false, cont.Number)
+		if err != nil {
+			logger.Debugf("Cannot load the running chain %s: %v\n", cont.ShortName, err)
+			continue
+		}
+		running[cont.FullName] = &definitions.ServiceDefinition{
+			Name:         chain.Name,
+			Dependencies: chain.Dependencies,
+			Service:      chain.Service,
+			Operations:   chain.Operations,
+		}
+	}
+
+	return running
+}
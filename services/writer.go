@@ -69,6 +69,14 @@ func WriteServiceDefinitionFile(serviceDef *def.ServiceDefinition, fileName stri
 		enc.Encode(serviceDef.Location)
 		writer.Write([]byte("\n[machine]\n"))
 		enc.Encode(serviceDef.Machine)
+		if !serviceDef.HealthCheck.IsEmpty() {
+			writer.Write([]byte("\n[healthcheck]\n"))
+			enc.Encode(serviceDef.HealthCheck)
+		}
+		if !serviceDef.Build.IsEmpty() {
+			writer.Write([]byte("\n[build]\n"))
+			enc.Encode(serviceDef.Build)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,146 @@
+package stacks
+
+import (
+	"sort"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+)
+
+// What [eris stack up] does to an entry of a stack.
+const (
+	actionCreate    = "create"
+	actionUpdate    = "update"
+	actionStart     = "start"
+	actionRemove    = "remove"
+	actionUnchanged = "unchanged"
+)
+
+// typeAction is the type of the action entries of a stack.
+const typeAction = "action"
+
+// A change is what is to be done to an entry of a stack.
+type change struct {
+	Action string
+	Type   string
+	Name   string
+	hash   string
+}
+
+// existsFunc tells whether the container of the chain or service (typ)
+// name exists and whether it is running. For data, running is ignored.
+type existsFunc func(typ, name string) (exists, running bool)
+
+// diffStack compares the stack to the state it was last brought up with
+// and to the containers which exist. It returns the changes in the order
+// they are to be made: removals (services before chains), then chains,
+// data, services and actions as given in the stack.
+//
+// An entry whose container does not exist is created. One whose spec
+// changed since it was brought up is updated (recreated). One which
+// exists but is stopped is started. Entries of the state which are not
+// in the stack anymore are removed. Actions are performed when they are
+// new or changed.
+func diffStack(stack *def.Stack, state *stackState, exists existsFunc) []*change {
+	var changes []*change
+	removed := func(names map[string]bool, typ string, state map[string]string) {
+		for _, name := range sortedNames(state) {
+			if !names[name] {
+				changes = append(changes, &change{Action: actionRemove, Type: typ, Name: name})
+			}
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, srv := range stack.Services {
+		names[srv.Name] = true
+	}
+	removed(names, def.TypeService, state.Services)
+
+	names = make(map[string]bool)
+	for _, chain := range stack.Chains {
+		names[chain.Name] = true
+	}
+	removed(names, def.TypeChain, state.Chains)
+
+	names = make(map[string]bool)
+	for _, data := range stack.Data {
+		names[dataKey(data)] = true
+	}
+	removed(names, def.TypeData, state.Data)
+
+	names = make(map[string]bool)
+	for _, act := range stack.Actions {
+		names[act.Name] = true
+	}
+	removed(names, typeAction, state.Actions)
+
+	for _, chain := range stack.Chains {
+		changes = append(changes, containerChange(def.TypeChain, chain.Name, chainHash(chain), state.Chains, exists))
+	}
+
+	for _, data := range stack.Data {
+		c := &change{Type: def.TypeData, Name: dataKey(data), hash: dataHash(data)}
+		prev, known := state.Data[c.Name]
+		switch {
+		case !known:
+			c.Action = actionCreate
+		case prev != c.hash:
+			c.Action = actionUpdate
+		default:
+			c.Action = actionUnchanged
+		}
+		if ok, _ := exists(def.TypeData, data.Name); !ok {
+			c.Action = actionCreate
+		}
+		changes = append(changes, c)
+	}
+
+	for _, srv := range stack.Services {
+		changes = append(changes, containerChange(def.TypeService, srv.Name, serviceHash(srv), state.Services, exists))
+	}
+
+	for _, act := range stack.Actions {
+		c := &change{Type: typeAction, Name: act.Name, hash: actionHash(act)}
+		prev, known := state.Actions[act.Name]
+		switch {
+		case !known:
+			c.Action = actionCreate
+		case prev != c.hash:
+			c.Action = actionUpdate
+		default:
+			c.Action = actionUnchanged
+		}
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// containerChange is the change to the chain or service (typ) name. A
+// container which exists but was not brought up by the stack is taken
+// over as it is.
+func containerChange(typ, name, hash string, state map[string]string, exists existsFunc) *change {
+	c := &change{Type: typ, Name: name, hash: hash}
+	ok, running := exists(typ, name)
+	prev, known := state[name]
+	switch {
+	case !ok:
+		c.Action = actionCreate
+	case known && prev != hash:
+		c.Action = actionUpdate
+	case !running:
+		c.Action = actionStart
+	default:
+		c.Action = actionUnchanged
+	}
+	return c
+}
+
+func sortedNames(m map[string]string) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
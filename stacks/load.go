@@ -0,0 +1,193 @@
+package stacks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/util"
+)
+
+// LoadStack reads the stack manifest file (TOML, YAML or JSON). Relative
+// genesis and data source paths are taken to be relative to the
+// manifest. The stack is named after the file unless it sets a name.
+func LoadStack(file string) (*def.Stack, error) {
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	conf, err := config.LoadViperConfig(filepath.Dir(file), base, "stack")
+	if err != nil {
+		return nil, err
+	}
+
+	stack := def.BlankStack()
+	if err := conf.Marshal(stack); err != nil {
+		return nil, fmt.Errorf("Tragic! The marmots could not read that stack file:\n%v\n", err)
+	}
+	if stack.Name == "" {
+		stack.Name = base
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, chain := range stack.Chains {
+		if err := checkEntry(seen, "chain", chain.Name); err != nil {
+			return nil, err
+		}
+		if chain.Genesis != "" && !filepath.IsAbs(chain.Genesis) {
+			chain.Genesis = filepath.Join(dir, chain.Genesis)
+		}
+	}
+	for _, data := range stack.Data {
+		if err := checkEntry(seen, "data", data.Name+":"+data.Destination); err != nil {
+			return nil, err
+		}
+		if data.Source == "" || data.Destination == "" {
+			return nil, fmt.Errorf("The data of %s in the stack %s needs a source and a destination.", data.Name, stack.Name)
+		}
+		if !filepath.IsAbs(data.Source) {
+			data.Source = filepath.Join(dir, data.Source)
+		}
+	}
+	for _, srv := range stack.Services {
+		if err := checkEntry(seen, "service", srv.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, act := range stack.Actions {
+		if err := checkEntry(seen, "action", act.Name); err != nil {
+			return nil, err
+		}
+	}
+	return stack, nil
+}
+
+func checkEntry(seen map[string]bool, typ, name string) error {
+	if name == "" || strings.HasPrefix(name, ":") {
+		return fmt.Errorf("Every %s of a stack needs a name.", typ)
+	}
+	if seen[typ+"/"+name] {
+		return fmt.Errorf("The %s %s is in the stack more than once.", typ, name)
+	}
+	seen[typ+"/"+name] = true
+	return nil
+}
+
+// stackState records what [eris stack up] brought up for a stack: the
+// spec hash of each of its entries by name.
+type stackState struct {
+	Name     string            `json:"name"`
+	File     string            `json:"file"`
+	Chains   map[string]string `json:"chains"`
+	Data     map[string]string `json:"data"`
+	Services map[string]string `json:"services"`
+	Actions  map[string]string `json:"actions"`
+}
+
+func newStackState(name string) *stackState {
+	return &stackState{
+		Name:     name,
+		Chains:   make(map[string]string),
+		Data:     make(map[string]string),
+		Services: make(map[string]string),
+		Actions:  make(map[string]string),
+	}
+}
+
+func stateFile(name string) string {
+	return filepath.Join(util.StacksPath(), name+".json")
+}
+
+// readState reads the state of the stack name. A stack which was never
+// brought up has an empty state.
+func readState(name string) (*stackState, error) {
+	state := newStackState(name)
+	body, err := ioutil.ReadFile(stateFile(name))
+	if os.IsNotExist(err) {
+		return state, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, state); err != nil {
+		return nil, fmt.Errorf("The marmots could not read the state of the stack %s: %v", name, err)
+	}
+	for _, m := range []*map[string]string{&state.Chains, &state.Data, &state.Services, &state.Actions} {
+		if *m == nil {
+			*m = make(map[string]string)
+		}
+	}
+	return state, nil
+}
+
+func writeState(state *stackState) error {
+	if err := os.MkdirAll(util.StacksPath(), 0755); err != nil {
+		return err
+	}
+	body, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile(state.Name), append(body, '\n'), 0644)
+}
+
+// specHash hashes the JSON form of entry and the contents of the files
+// it refers to. Directories are hashed by the names, sizes and
+// modification times of their files.
+func specHash(entry interface{}, files ...string) string {
+	h := sha256.New()
+	body, _ := json.Marshal(entry)
+	h.Write(body)
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		filepath.Walk(file, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				fmt.Fprintf(h, "%s:missing\n", path)
+				return nil
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if path == file {
+				if body, err := ioutil.ReadFile(path); err == nil {
+					h.Write(body)
+				}
+				return nil
+			}
+			fmt.Fprintf(h, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// serviceHash hashes a service entry along with the definition file of
+// the service, so that changes to either update the service.
+func serviceHash(srv *def.StackService) string {
+	return specHash(srv, util.GetFileByNameAndType("services", srv.Name))
+}
+
+func chainHash(chain *def.StackChain) string {
+	return specHash(chain, chain.Genesis)
+}
+
+func dataHash(data *def.StackData) string {
+	return specHash(data, data.Source)
+}
+
+func actionHash(act *def.StackAction) string {
+	return specHash(act, util.GetFileByNameAndType("actions", strings.Replace(act.Name, " ", "_", -1)))
+}
+
+func dataKey(data *def.StackData) string {
+	return data.Name + ":" + data.Destination
+}
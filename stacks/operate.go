@@ -0,0 +1,319 @@
+package stacks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/actions"
+	"github.com/eris-ltd/eris-cli/chains"
+	"github.com/eris-ltd/eris-cli/data"
+	def "github.com/eris-ltd/eris-cli/definitions"
+	"github.com/eris-ltd/eris-cli/loaders"
+	"github.com/eris-ltd/eris-cli/perform"
+	"github.com/eris-ltd/eris-cli/services"
+	"github.com/eris-ltd/eris-cli/util"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/olekukonko/tablewriter"
+)
+
+// Up brings the stack of the manifest do.Path up. Only what differs from
+// what the stack was last brought up with is changed, so Up can be run
+// again after editing the manifest: chains and services which are new
+// are created, those which changed are recreated, stopped ones are
+// started, and those dropped from the manifest are stopped and removed.
+// Data is imported and actions are performed when they are new or
+// changed. do.Result is set to the number of changes made.
+//
+//	do.Path                       - stack manifest file
+//	do.Operations.ContainerNumber - container number
+//	do.Timeout                    - timeout to stop containers
+//	do.RmD                        - remove the data containers of dropped chains
+func Up(do *def.Do) error {
+	stack, err := LoadStack(do.Path)
+	if err != nil {
+		return err
+	}
+	state, err := readState(stack.Name)
+	if err != nil {
+		return err
+	}
+	if state.File, err = filepath.Abs(do.Path); err != nil {
+		return err
+	}
+
+	changes := diffStack(stack, state, containerExists(do.Operations.ContainerNumber))
+	if !do.Quiet {
+		logger.Printf("%s", planTable(changes))
+	}
+
+	made := 0
+	for _, c := range changes {
+		if c.Action != actionUnchanged {
+			logger.Infof("Stack change =>\t\t%s %s %s\n", c.Action, c.Type, c.Name)
+			if err := applyChange(stack, c, do); err != nil {
+				// keep what was done so far for the next run
+				writeState(state)
+				return fmt.Errorf("The marmots could not %s the %s %s of the stack %s: %v", c.Action, c.Type, c.Name, stack.Name, err)
+			}
+			made++
+		}
+		recordChange(state, c)
+	}
+
+	if err := writeState(state); err != nil {
+		return err
+	}
+	do.Result = fmt.Sprintf("%d", made)
+	return nil
+}
+
+// Down stops and removes the services and then the chains of the stack
+// of the manifest do.Path, dependents first. The data containers of the
+// chains are removed with do.RmD. The stack is taken down as it was last
+// brought up, or as the manifest gives it if it never was.
+//
+//	do.Path                       - stack manifest file
+//	do.Operations.ContainerNumber - container number
+//	do.Timeout                    - timeout to stop containers
+//	do.RmD                        - remove the data containers as well
+func Down(do *def.Do) error {
+	stack, err := LoadStack(do.Path)
+	if err != nil {
+		return err
+	}
+	state, err := readState(stack.Name)
+	if err != nil {
+		return err
+	}
+
+	var srvNames, chainNames []string
+	if len(state.Services) > 0 || len(state.Chains) > 0 {
+		srvNames = sortedNames(state.Services)
+		chainNames = sortedNames(state.Chains)
+	} else {
+		for _, srv := range stack.Services {
+			srvNames = append(srvNames, srv.Name)
+		}
+		for _, chain := range stack.Chains {
+			chainNames = append(chainNames, chain.Name)
+		}
+	}
+
+	exists := containerExists(do.Operations.ContainerNumber)
+	var existing []string
+	for _, name := range srvNames {
+		if ok, _ := exists(def.TypeService, name); ok {
+			existing = append(existing, name)
+		}
+	}
+	if len(existing) > 0 {
+		// stopped together so that dependents go first
+		if err := removeService(do, existing...); err != nil {
+			return err
+		}
+	}
+	for i := len(chainNames) - 1; i >= 0; i-- {
+		if ok, _ := exists(def.TypeChain, chainNames[i]); !ok {
+			continue
+		}
+		if err := removeChain(chainNames[i], do.RmD, do); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Remove(stateFile(stack.Name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	do.Result = "success"
+	return nil
+}
+
+func applyChange(stack *def.Stack, c *change, do *def.Do) error {
+	if c.Action == actionRemove {
+		switch c.Type {
+		case def.TypeService:
+			return removeService(do, c.Name)
+		case def.TypeChain:
+			return removeChain(c.Name, do.RmD, do)
+		case def.TypeData:
+			logger.Infof("Leaving imported data =>\t%s\n", c.Name)
+		}
+		return nil
+	}
+
+	switch c.Type {
+	case def.TypeChain:
+		for _, chain := range stack.Chains {
+			if chain.Name == c.Name {
+				return upChain(chain, c.Action, do)
+			}
+		}
+	case def.TypeData:
+		for _, d := range stack.Data {
+			if dataKey(d) == c.Name {
+				return importData(d, do)
+			}
+		}
+	case def.TypeService:
+		for _, srv := range stack.Services {
+			if srv.Name == c.Name {
+				return upService(srv, c.Action, do)
+			}
+		}
+	case typeAction:
+		for _, act := range stack.Actions {
+			if act.Name == c.Name {
+				return performAction(act, do)
+			}
+		}
+	}
+	return nil
+}
+
+// upChain makes a new chain, or starts it. A changed chain is made anew
+// from its genesis: its container and data container are removed first.
+func upChain(chain *def.StackChain, action string, do *def.Do) error {
+	if action == actionUpdate {
+		if err := removeChain(chain.Name, true, do); err != nil {
+			return err
+		}
+	}
+
+	doChain := def.NowDo()
+	doChain.Name = chain.Name
+	doChain.Operations.ContainerNumber = do.Operations.ContainerNumber
+	if action == actionStart {
+		return chains.StartChain(doChain)
+	}
+	doChain.GenesisFile = chain.Genesis
+	doChain.ConfigOpts = chain.Options
+	return chains.NewChain(doChain)
+}
+
+// upService starts the service and its dependencies with the overrides
+// of the stack. A changed service's container is removed first so that
+// it is created with the new overrides.
+func upService(srv *def.StackService, action string, do *def.Do) error {
+	cNum := do.Operations.ContainerNumber
+	if action == actionUpdate {
+		top, err := loaders.LoadServiceDefinition(srv.Name, false, cNum)
+		if err != nil {
+			return err
+		}
+		if services.IsServiceRunning(top.Service, top.Operations) {
+			if err := perform.DockerStop(top.Service, top.Operations, do.Timeout); err != nil {
+				return err
+			}
+		}
+		if err := perform.DockerRemove(top.Service, top.Operations, false, false); err != nil {
+			return err
+		}
+	}
+
+	group, err := services.BuildServicesGroup(srv.Name, cNum)
+	if err != nil {
+		return err
+	}
+	top := group[len(group)-1]
+	if srv.Service != nil {
+		if err := util.Merge(top.Service, srv.Service); err != nil {
+			return err
+		}
+	}
+	if srv.Chain != "" {
+		top.Chain = srv.Chain
+	}
+	if group, err = services.BuildChainGroup(srv.Chain, group); err != nil {
+		return err
+	}
+	return services.StartGroup(group)
+}
+
+func importData(d *def.StackData, do *def.Do) error {
+	doData := def.NowDo()
+	doData.Name = d.Name
+	doData.Source = d.Source
+	doData.Destination = d.Destination
+	doData.Operations.ContainerNumber = do.Operations.ContainerNumber
+	return data.ImportData(doData)
+}
+
+func performAction(act *def.StackAction, do *def.Do) error {
+	doAct := def.NowDo()
+	doAct.Operations.Args = append(strings.Fields(act.Name), act.Vars...)
+	doAct.ChainName = act.Chain
+	doAct.Quiet = do.Quiet
+	return actions.Do(doAct)
+}
+
+func removeService(do *def.Do, names ...string) error {
+	doSrv := def.NowDo()
+	doSrv.Operations.Args = names
+	doSrv.Operations.ContainerNumber = do.Operations.ContainerNumber
+	doSrv.Timeout = do.Timeout
+	doSrv.Rm = true
+	return services.KillService(doSrv)
+}
+
+func removeChain(name string, rmData bool, do *def.Do) error {
+	doChain := def.NowDo()
+	doChain.Name = name
+	doChain.Operations.ContainerNumber = do.Operations.ContainerNumber
+	doChain.Timeout = do.Timeout
+	doChain.Rm = true
+	doChain.RmD = rmData
+	return chains.KillChain(doChain)
+}
+
+// recordChange updates the state with a change made.
+func recordChange(state *stackState, c *change) {
+	var m map[string]string
+	switch c.Type {
+	case def.TypeChain:
+		m = state.Chains
+	case def.TypeData:
+		m = state.Data
+	case def.TypeService:
+		m = state.Services
+	case typeAction:
+		m = state.Actions
+	}
+	if c.Action == actionRemove {
+		delete(m, c.Name)
+	} else {
+		m[c.Name] = c.hash
+	}
+}
+
+func containerExists(cNum int) existsFunc {
+	return func(typ, name string) (bool, bool) {
+		switch typ {
+		case def.TypeChain:
+			return util.IsChainContainer(name, cNum, true), util.IsChainContainer(name, cNum, false)
+		case def.TypeService:
+			return util.IsServiceContainer(name, cNum, true), util.IsServiceContainer(name, cNum, false)
+		case def.TypeData:
+			return util.IsData(name, cNum), false
+		}
+		return false, false
+	}
+}
+
+func planTable(changes []*change) string {
+	buf := new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetHeader([]string{"TYPE", "NAME", "CHANGE"})
+	for _, c := range changes {
+		table.Append([]string{c.Type, c.Name, c.Action})
+	}
+	table.SetBorder(false)
+	table.SetCenterSeparator(" ")
+	table.SetColumnSeparator(" ")
+	table.SetRowSeparator("-")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+	return buf.String()
+}
@@ -0,0 +1,238 @@
+package stacks
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+	def "github.com/eris-ltd/eris-cli/definitions"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
+)
+
+var erisDir string
+
+func TestMain(m *testing.M) {
+	log.SetLoggers(0, os.Stdout, os.Stderr)
+
+	var err error
+	erisDir, err = ioutil.TempDir("", "eris_stacks")
+	if err != nil {
+		panic(err)
+	}
+	config.ChangeErisDir(erisDir)
+	config.GlobalConfig, err = config.SetGlobalObject(os.Stdout, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(erisDir)
+	os.Exit(code)
+}
+
+func TestLoadStack(t *testing.T) {
+	dir := filepath.Join(erisDir, "manifests")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	file := filepath.Join(dir, "dev.toml")
+	if err := ioutil.WriteFile(file, []byte(`
+[[chains]]
+name = "simplechain"
+genesis = "genesis.json"
+options = ["log_level=info"]
+
+[[data]]
+name = "ipfs"
+source = "files"
+destination = "/home/eris/.eris/files"
+
+[[services]]
+name = "ipfs"
+chain = "simplechain"
+  [services.service]
+  environment = ["IPFS_DEBUG=1"]
+  volumes_from = ["keys"]
+
+[[actions]]
+name = "deploy it"
+vars = ["env:test"]
+`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	stack, err := LoadStack(file)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if stack.Name != "dev" {
+		t.Fatalf("expected the stack to be named after the file, got %q", stack.Name)
+	}
+	if len(stack.Chains) != 1 || stack.Chains[0].Genesis != filepath.Join(dir, "genesis.json") || len(stack.Chains[0].Options) != 1 {
+		t.Fatalf("expected a chain with a genesis relative to the manifest, got %v", stack.Chains)
+	}
+	if len(stack.Data) != 1 || stack.Data[0].Source != filepath.Join(dir, "files") {
+		t.Fatalf("expected data with a source relative to the manifest, got %v", stack.Data)
+	}
+	if len(stack.Services) != 1 || stack.Services[0].Service == nil {
+		t.Fatalf("expected a service with overrides, got %v", stack.Services)
+	}
+	if srv := stack.Services[0].Service; len(srv.Environment) != 1 || len(srv.VolumesFrom) != 1 {
+		t.Fatalf("expected the service overrides to be read, got %v", srv)
+	}
+	if len(stack.Actions) != 1 || stack.Actions[0].Name != "deploy it" || stack.Actions[0].Vars[0] != "env:test" {
+		t.Fatalf("expected an action, got %v", stack.Actions)
+	}
+
+	file = filepath.Join(dir, "named.json")
+	if err := ioutil.WriteFile(file, []byte(`{
+  "name": "prod",
+  "chains": [{"name": "mainchain", "genesis": "/etc/genesis.json"}],
+  "services": [{"name": "keys"}]
+}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if stack, err = LoadStack(file); err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if stack.Name != "prod" || stack.Chains[0].Genesis != "/etc/genesis.json" || stack.Services[0].Service != nil {
+		t.Fatalf("expected the JSON stack to be read as given, got %v", stack)
+	}
+
+	file = filepath.Join(dir, "twice.toml")
+	if err := ioutil.WriteFile(file, []byte(`
+[[services]]
+name = "keys"
+
+[[services]]
+name = "keys"
+`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := LoadStack(file); err == nil {
+		t.Fatalf("expected an error for a service given twice")
+	}
+}
+
+func TestDiffStack(t *testing.T) {
+	source := filepath.Join(erisDir, "diff_files")
+	if err := os.MkdirAll(source, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	stack := &def.Stack{
+		Name:     "diff",
+		Chains:   []*def.StackChain{{Name: "chainy"}},
+		Data:     []*def.StackData{{Name: "ipfs", Source: source, Destination: "/data"}},
+		Services: []*def.StackService{{Name: "keys"}, {Name: "ipfs", Chain: "chainy"}},
+		Actions:  []*def.StackAction{{Name: "deploy"}},
+	}
+
+	containers := map[string]string{} // type/name -> "stopped" or "running"
+	exists := func(typ, name string) (bool, bool) {
+		state, ok := containers[typ+"/"+name]
+		return ok, state == "running"
+	}
+	plan := func(changes []*change) string {
+		var out []string
+		for _, c := range changes {
+			out = append(out, c.Action+" "+c.Type+" "+c.Name)
+		}
+		return strings.Join(out, ", ")
+	}
+	apply := func(state *stackState, changes []*change) {
+		for _, c := range changes {
+			recordChange(state, c)
+			key := c.Type + "/" + c.Name
+			if c.Type == def.TypeData {
+				key = c.Type + "/" + strings.Split(c.Name, ":")[0]
+			}
+			if c.Action == actionRemove {
+				delete(containers, key)
+			} else if c.Type != typeAction {
+				containers[key] = "running"
+			}
+		}
+	}
+
+	state := newStackState("diff")
+	changes := diffStack(stack, state, exists)
+	expected := "create chain chainy, create data ipfs:/data, create service keys, create service ipfs, create action deploy"
+	if p := plan(changes); p != expected {
+		t.Fatalf("expected the first plan\n%s\ngot\n%s", expected, p)
+	}
+	apply(state, changes)
+
+	changes = diffStack(stack, state, exists)
+	expected = "unchanged chain chainy, unchanged data ipfs:/data, unchanged service keys, unchanged service ipfs, unchanged action deploy"
+	if p := plan(changes); p != expected {
+		t.Fatalf("expected nothing to change\n%s\ngot\n%s", expected, p)
+	}
+
+	// a stopped service is started, a changed one is updated, a dropped
+	// one is removed and a new action is performed
+	containers["service/keys"] = "stopped"
+	stack.Services = stack.Services[:1]
+	stack.Chains[0].Options = []string{"log_level=debug"}
+	stack.Actions = append(stack.Actions, &def.StackAction{Name: "test", Vars: []string{"n:1"}})
+	changes = diffStack(stack, state, exists)
+	expected = "remove service ipfs, update chain chainy, unchanged data ipfs:/data, start service keys, unchanged action deploy, create action test"
+	if p := plan(changes); p != expected {
+		t.Fatalf("expected the changed plan\n%s\ngot\n%s", expected, p)
+	}
+	apply(state, changes)
+	if _, ok := state.Services["ipfs"]; ok {
+		t.Fatalf("expected the removed service to be dropped from the state")
+	}
+
+	// changed data is imported again, and data is imported again into
+	// a data container which went away
+	if err := ioutil.WriteFile(filepath.Join(source, "hello.txt"), []byte("hi"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	changes = diffStack(stack, state, exists)
+	if c := changes[1]; c.Type != def.TypeData || c.Action != actionUpdate {
+		t.Fatalf("expected changed data to be updated, got %s %s", c.Action, c.Type)
+	}
+	apply(state, changes)
+	delete(containers, "data/ipfs")
+	changes = diffStack(stack, state, exists)
+	if c := changes[1]; c.Type != def.TypeData || c.Action != actionCreate {
+		t.Fatalf("expected data to be imported into a new data container, got %s %s", c.Action, c.Type)
+	}
+
+	// containers existing before the stack are taken over as they are
+	containers = map[string]string{"chain/chainy": "running", "service/keys": "running", "data/ipfs": "stopped"}
+	changes = diffStack(stack, newStackState("diff"), exists)
+	expected = "unchanged chain chainy, create data ipfs:/data, unchanged service keys, create action deploy, create action test"
+	if p := plan(changes); p != expected {
+		t.Fatalf("expected existing containers to be taken over\n%s\ngot\n%s", expected, p)
+	}
+}
+
+func TestStackState(t *testing.T) {
+	state, err := readState("nope")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(state.Services) != 0 || state.Services == nil {
+		t.Fatalf("expected an empty state for an unknown stack, got %v", state)
+	}
+
+	state.File = "/tmp/stack.toml"
+	state.Chains["chainy"] = "abc"
+	if err := writeState(state); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if state, err = readState("nope"); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if state.File != "/tmp/stack.toml" || state.Chains["chainy"] != "abc" || state.Actions == nil {
+		t.Fatalf("expected the state to be read back, got %v", state)
+	}
+}
@@ -210,9 +210,9 @@ packagesToTest() {
   # go test ./projects/...
   # passed Projects
   # if [ $? -ne 0 ]; then return 1; fi
-  # go test ./remotes/...
-  # passed Remotes
-  # if [ $? -ne 0 ]; then return 1; fi
+  go test ./remotes/...
+  passed Remotes
+  if [ $? -ne 0 ]; then return 1; fi
 
   # The final push....
   go test ./commands/...
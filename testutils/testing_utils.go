@@ -2,11 +2,13 @@ package testings
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
 
+	dirs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/log"
 	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
 	"github.com/eris-ltd/eris-cli/config"
@@ -58,6 +60,41 @@ func TestsInit(testType string) (err error) {
 	return nil
 }
 
+// FakeInit sets up the tests of a package which run without a Docker
+// daemon: logging is quiet, image pulls are approved and the eris
+// directory is a new temporary directory (prefixed with name) with the
+// chains and services directories in it. The directory is returned to
+// be removed once the tests are done.
+func FakeInit(name string) (string, error) {
+	log.SetLoggers(0, os.Stdout, os.Stderr)
+	os.Setenv("ERIS_PULL_APPROVE", "true")
+
+	dir, err := ioutil.TempDir("", name)
+	if err != nil {
+		return "", err
+	}
+	config.ChangeErisDir(dir)
+	config.GlobalConfig, err = config.SetGlobalObject(os.Stdout, os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range []string{dirs.ChainsPath, dirs.ServicesPath} {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// UseFake points util.Backend at a new fake backend until the returned
+// function is called.
+func UseFake() (*util.FakeBackend, func()) {
+	saved := util.Backend
+	fake := util.NewFakeBackend()
+	util.Backend = fake
+	return fake, func() { util.Backend = saved }
+}
+
 //return to handle failings in each pkg
 //typ = type of test for dealing with do.() details
 func TestExistAndRun(name, typ string, contNum int, toExist, toRun bool) bool {
@@ -143,7 +180,7 @@ func RemoveContainer(name string, t string, n int) error {
 		Force:         true,
 	}
 
-	if err := util.DockerClient.RemoveContainer(opts); err != nil {
+	if err := util.Backend.RemoveContainer(opts); err != nil {
 		return err
 	}
 
@@ -158,7 +195,7 @@ func RemoveAllContainers() error {
 // Return container links. For sake of simplicity, don't expose
 // anything else.
 func Links(name string, t string, n int) []string {
-	container, err := util.DockerClient.InspectContainer(util.ContainersName(t, name, n))
+	container, err := util.Backend.InspectContainer(util.ContainersName(t, name, n))
 	if err != nil {
 		return []string{}
 	}
@@ -53,9 +53,9 @@ func Clean(prompt, all, rmd, images bool) error {
 
 // stops and removes containers and their volumes
 func defaultClean(prompt bool) error {
-	contns, err := DockerClient.ListContainers(docker.ListContainersOptions{All: true})
+	contns, err := Backend.ListContainers(docker.ListContainersOptions{All: true})
 	if err != nil {
-		fmt.Printf("List containers error: %v\n", err)
+		return err
 	}
 
 	if !prompt || canWeRemove([]string{}, "all") {
@@ -67,15 +67,16 @@ func defaultClean(prompt bool) error {
 					RemoveVolumes: true,
 					Force:         true,
 				}
-				if err := DockerClient.RemoveContainer(removeOpts); err != nil {
+				if err := Backend.RemoveContainer(removeOpts); err != nil {
 					return err
 				}
 			}
 
 		}
 	}
-	return nil
 
+	// Networks left without containers.
+	return RemoveOrphanedNetworks()
 }
 
 func removeErisDir(prompt bool) error {
@@ -100,12 +101,17 @@ func removeErisDir(prompt bool) error {
 }
 
 func removeErisImages(prompt bool) error {
+	images := Images()
+	if images == nil {
+		return fmt.Errorf("The marmots cannot remove images with this backend. Please clean without --images and --all")
+	}
+
 	opts := docker.ListImagesOptions{
 		All:     true,
 		Filters: nil,
 		Digests: false,
 	}
-	allTheImages, err := DockerClient.ListImages(opts)
+	allTheImages, err := images.ListImages(opts)
 	if err != nil {
 		return err
 	}
@@ -140,7 +146,7 @@ func removeErisImages(prompt bool) error {
 	if !prompt || canWeRemove(erisImages, "images") {
 		for i, imageID := range erisImageIDs {
 			logger.Debugf("removing image: %s with ID\t%s ", erisImages[i], imageID)
-			if err := DockerClient.RemoveImage(imageID); err != nil {
+			if err := images.RemoveImage(imageID); err != nil {
 				return err
 			}
 		}
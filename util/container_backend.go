@@ -0,0 +1,72 @@
+package util
+
+import (
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// ContainerBackend is the set of container operations the perform, data
+// and util packages need from a container engine. *docker.Client satisfies
+// it as is; FakeBackend is an in-memory implementation for tests which
+// have no Docker daemon to talk to.
+type ContainerBackend interface {
+	CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error)
+	StartContainer(id string, hostConfig *docker.HostConfig) error
+	StopContainer(id string, timeout uint) error
+	RemoveContainer(opts docker.RemoveContainerOptions) error
+	InspectContainer(id string) (*docker.Container, error)
+	ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error)
+	WaitContainer(id string) (int, error)
+	AttachToContainer(opts docker.AttachToContainerOptions) error
+	Logs(opts docker.LogsOptions) error
+	UploadToContainer(id string, opts docker.UploadToContainerOptions) error
+	DownloadFromContainer(id string, opts docker.DownloadFromContainerOptions) error
+	PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error
+	Version() (*docker.Env, error)
+}
+
+// NetworkBackend is the set of network operations of the container
+// backends which manage user-defined networks: *docker.Client and
+// FakeBackend do, the native backend and eris agents do not.
+type NetworkBackend interface {
+	CreateNetwork(opts docker.CreateNetworkOptions) (*docker.Network, error)
+	ListNetworks() ([]docker.Network, error)
+	NetworkInfo(id string) (*docker.Network, error)
+	RemoveNetwork(id string) error
+	ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+	DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+}
+
+// VolumeBackend is the set of volume operations of the container
+// backends which manage named volumes: *docker.Client and FakeBackend.
+type VolumeBackend interface {
+	CreateVolume(opts docker.CreateVolumeOptions) (*docker.Volume, error)
+	InspectVolume(name string) (*docker.Volume, error)
+	ListVolumes(opts docker.ListVolumesOptions) ([]docker.Volume, error)
+	RemoveVolume(name string) error
+}
+
+// ImageBackend is the set of image operations of the container backends
+// which build, save, load and remove images: *docker.Client and
+// FakeBackend.
+type ImageBackend interface {
+	BuildImage(opts docker.BuildImageOptions) error
+	InspectImage(name string) (*docker.Image, error)
+	ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error)
+	RemoveImage(name string) error
+	ExportImages(opts docker.ExportImagesOptions) error
+	LoadImage(opts docker.LoadImageOptions) error
+}
+
+// ExecBackend is the set of operations of the container backends which
+// run commands in running containers: *docker.Client, FakeBackend and
+// the native backend (which runs them on this host).
+type ExecBackend interface {
+	CreateExec(opts docker.CreateExecOptions) (*docker.Exec, error)
+	StartExec(id string, opts docker.StartExecOptions) error
+	InspectExec(id string) (*docker.ExecInspect, error)
+}
+
+// Backend is the container backend every container operation goes
+// through. DockerConnect points it at the Docker client; tests may
+// replace it with NewFakeBackend() before calling into eris packages.
+var Backend ContainerBackend
@@ -118,12 +118,12 @@ func ErisContainersByType(typ string, running bool) []*ContainerName {
 	r := erisRegExp(typ)      // eris containers
 	q := erisRegExpLinks(typ) // skip past these -- they're containers docker makes especially to handle links
 
-	contns, err := DockerClient.ListContainers(docker.ListContainersOptions{All: running})
+	contns, err := Backend.ListContainers(docker.ListContainersOptions{All: running})
 
 	if len(contns) == 0 || err != nil {
 		logger.Infoln("There are no containers.")
 		if err != nil {
-			logger.Debugf("Marmot error duing DockerClient.ListContainers: %v\n", err)
+			logger.Debugf("Marmot error duing Backend.ListContainers: %v\n", err)
 		}
 		return containers
 	}
@@ -219,11 +219,18 @@ func DataContainers() []*ContainerName {
 	return ErisContainersByType("data", true)
 }
 
+// DataContainerNames returns the names of the data containers and of
+// the data volumes.
 func DataContainerNames() []string {
-	a := DataContainers()
+	a := append(DataContainers(), DataVolumes()...)
 	b := []string{}
+	seen := make(map[string]bool)
 	for _, c := range a {
-		b = append(b, strings.Replace(c.ShortName, "_", " ", -1))
+		name := strings.Replace(c.ShortName, "_", " ", -1)
+		if !seen[name] {
+			b = append(b, name)
+			seen[name] = true
+		}
 	}
 	return b
 }
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// ParseUlimit returns the ulimit of a NAME=SOFT[:HARD] spec (as docker
+// run --ulimit takes it). The hard limit is the soft one if not given.
+func ParseUlimit(spec string) (docker.ULimit, error) {
+	parts := strings.SplitN(spec, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return docker.ULimit{}, fmt.Errorf("not of the form NAME=SOFT[:HARD]")
+	}
+
+	limits := strings.SplitN(parts[1], ":", 2)
+	soft, err := strconv.ParseInt(limits[0], 10, 64)
+	if err != nil {
+		return docker.ULimit{}, fmt.Errorf("the soft limit %q is not a number", limits[0])
+	}
+	hard := soft
+	if len(limits) == 2 {
+		if hard, err = strconv.ParseInt(limits[1], 10, 64); err != nil {
+			return docker.ULimit{}, fmt.Errorf("the hard limit %q is not a number", limits[1])
+		}
+	}
+	if soft > hard {
+		return docker.ULimit{}, fmt.Errorf("the soft limit %d is above the hard limit %d", soft, hard)
+	}
+
+	return docker.ULimit{Name: parts[0], Soft: soft, Hard: hard}, nil
+}
+
+// ParseTmpfs returns the path and mount options of a PATH[:OPTIONS]
+// tmpfs spec (as docker run --tmpfs takes it).
+func ParseTmpfs(spec string) (path, options string, err error) {
+	parts := strings.SplitN(spec, ":", 2)
+	if !strings.HasPrefix(parts[0], "/") {
+		return "", "", fmt.Errorf("the path %q is not absolute", parts[0])
+	}
+	if len(parts) == 2 {
+		options = parts[1]
+	}
+	return parts[0], options, nil
+}
+
+// CheckExtraHost checks a HOST:IP spec (as docker run --add-host takes
+// it).
+func CheckExtraHost(spec string) error {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("not of the form HOST:IP")
+	}
+	if net.ParseIP(parts[1]) == nil {
+		return fmt.Errorf("%q is not an IP address", parts[1])
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+func TestParseUlimit(t *testing.T) {
+	for spec, want := range map[string]docker.ULimit{
+		"nofile=1024:4096": {Name: "nofile", Soft: 1024, Hard: 4096},
+		"nproc=512":        {Name: "nproc", Soft: 512, Hard: 512},
+	} {
+		got, err := ParseUlimit(spec)
+		if err != nil || got != want {
+			t.Fatalf("ParseUlimit(%q): expected %v, got %v (%v)", spec, want, got, err)
+		}
+	}
+	for _, spec := range []string{"nofile", "=1", "nofile=x", "nofile=1:y", "nofile=10:5"} {
+		if _, err := ParseUlimit(spec); err == nil {
+			t.Fatalf("ParseUlimit(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestParseTmpfs(t *testing.T) {
+	if path, options, err := ParseTmpfs("/run:rw,size=64m"); err != nil || path != "/run" || options != "rw,size=64m" {
+		t.Fatalf("expected /run with options, got %q %q (%v)", path, options, err)
+	}
+	if path, options, err := ParseTmpfs("/tmp"); err != nil || path != "/tmp" || options != "" {
+		t.Fatalf("expected /tmp without options, got %q %q (%v)", path, options, err)
+	}
+	if _, _, err := ParseTmpfs("tmp:rw"); err == nil {
+		t.Fatalf("expected an error for a relative path")
+	}
+}
+
+func TestCheckExtraHost(t *testing.T) {
+	for _, spec := range []string{"db:10.0.0.2", "v6:::1"} {
+		if err := CheckExtraHost(spec); err != nil {
+			t.Fatalf("CheckExtraHost(%q): %v", spec, err)
+		}
+	}
+	for _, spec := range []string{"db", ":10.0.0.2", "db:nohost"} {
+		if err := CheckExtraHost(spec); err == nil {
+			t.Fatalf("CheckExtraHost(%q): expected an error", spec)
+		}
+	}
+}
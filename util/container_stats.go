@@ -3,6 +3,9 @@ package util
 import (
 	"bytes"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
@@ -27,6 +30,7 @@ type Parts struct {
 	FullName    string
 	Number      int
 	PortsOutput string
+	Networks    string
 }
 
 func PrintInspectionReport(cont *docker.Container, field string) error {
@@ -64,7 +68,7 @@ func PrintTableReport(typ string, existing, all bool) (string, error) {
 
 	buf := new(bytes.Buffer)
 	table := tablewriter.NewWriter(buf)
-	table.SetHeader([]string{fmt.Sprintf("%s NAME", strings.ToUpper(typ)), "TYPE", "RUNNING", "CONTAINER NAME", "CONTAINER #", "PORTS"})
+	table.SetHeader([]string{fmt.Sprintf("%s NAME", strings.ToUpper(typ)), "TYPE", "RUNNING", "CONTAINER NAME", "CONTAINER #", "PORTS", "NETWORKS"})
 
 	if all { //get all the things
 		parts, _ := AssembleTable(typ)
@@ -104,7 +108,7 @@ func PrintLineByContainerName(containerName string, existing bool) ([]string, er
 }
 
 func PrintLineByContainerID(containerID string, existing bool) ([]string, error) {
-	cont, err := DockerClient.InspectContainer(containerID)
+	cont, err := Backend.InspectContainer(containerID)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +116,7 @@ func PrintLineByContainerID(containerID string, existing bool) ([]string, error)
 }
 
 func PrintPortMappings(id string, ports []string) error {
-	cont, err := DockerClient.InspectContainer(id)
+	cont, err := Backend.InspectContainer(id)
 	if err != nil {
 		return err
 	}
@@ -158,6 +162,79 @@ func PrintPortMappings(id string, ports []string) error {
 	return nil
 }
 
+// ContainerPortAddress returns the host:port address at which the port
+// (e.g. 46657 or 46657/tcp) of the container id is reached from this
+// host: the port's binding on the Docker host if it is published, or the
+// container's own address otherwise.
+func ContainerPortAddress(id, port string) (string, error) {
+	cont, err := Backend.InspectContainer(id)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.Contains(port, "/") {
+		port = port + "/tcp"
+	}
+	number := strings.Split(port, "/")[0]
+
+	if cont.NetworkSettings == nil {
+		// native containers run on this host
+		return net.JoinHostPort("127.0.0.1", number), nil
+	}
+
+	for _, binding := range cont.NetworkSettings.Ports[docker.Port(port)] {
+		if binding.HostPort == "" {
+			continue
+		}
+		host := binding.HostIP
+		if host == "" || host == "0.0.0.0" {
+			host = DockerHostIP()
+		}
+		return net.JoinHostPort(host, binding.HostPort), nil
+	}
+
+	address := containerAddress(cont)
+	if address == "" {
+		return "", fmt.Errorf("The port %s of %s is not published and the container has no address", port, strings.TrimPrefix(cont.Name, "/"))
+	}
+	return net.JoinHostPort(address, number), nil
+}
+
+// containerAddress returns the address of the container on the default
+// bridge or, for containers on user-defined networks (which have no such
+// address), on the eris network or the first other network it has one.
+func containerAddress(cont *docker.Container) string {
+	if cont.NetworkSettings.IPAddress != "" {
+		return cont.NetworkSettings.IPAddress
+	}
+	if network, ok := cont.NetworkSettings.Networks[DefaultNetwork]; ok && network.IPAddress != "" {
+		return network.IPAddress
+	}
+	for _, name := range ContainerNetworks(cont) {
+		if address := cont.NetworkSettings.Networks[name].IPAddress; address != "" {
+			return address
+		}
+	}
+	return ""
+}
+
+// DockerHostIP returns the address of the Docker host: the host of a
+// tcp:// DOCKER_HOST or of the docker-machine in use, or 127.0.0.1 for
+// a local daemon.
+func DockerHostIP() string {
+	for _, env := range []string{"DOCKER_HOST", "ERIS_IPFS_HOST"} {
+		u, err := url.Parse(os.Getenv(env))
+		if err != nil || u.Host == "" || u.Scheme == "unix" {
+			continue
+		}
+		if host, _, err := net.SplitHostPort(u.Host); err == nil {
+			return host
+		}
+		return u.Host
+	}
+	return "127.0.0.1"
+}
+
 // this function populates the listing functions only for flags/tests
 func printLine(container *docker.Container, existing bool) ([]string, error) {
 	tmp, err := reflections.GetField(container, "Name")
@@ -175,7 +252,7 @@ func printLine(container *docker.Container, existing bool) ([]string, error) {
 
 	Names := ContainerDisassemble(n)
 
-	parts := []string{Names.ShortName, Names.Type, running, Names.FullName, fmt.Sprintf("%d", Names.Number), formulatePortsOutput(container)}
+	parts := []string{Names.ShortName, Names.Type, running, Names.FullName, fmt.Sprintf("%d", Names.Number), formulatePortsOutput(container), strings.Join(ContainerNetworks(container), ",")}
 	return parts, nil
 }
 
@@ -312,7 +389,7 @@ func listContainers(all bool) []docker.APIContainers {
 	var container []docker.APIContainers
 	r := regexp.MustCompile(`\/eris_(?:service|chain|data)_(.+)_\d`)
 
-	contns, _ := DockerClient.ListContainers(docker.ListContainersOptions{All: all})
+	contns, _ := Backend.ListContainers(docker.ListContainersOptions{All: all})
 	for _, con := range contns {
 		for _, c := range con.Names {
 			match := r.FindAllStringSubmatch(c, 1)
@@ -338,7 +415,12 @@ func AssembleTable(typ string) ([]Parts, error) {
 	contsR := ErisContainersByType(typ, false) //running
 	contsE := ErisContainersByType(typ, true)  //existing
 
-	if len(contsE) == 0 && len(contsR) == 0 && len(known) == 0 {
+	var volumes []*ContainerName
+	if typ == "data" {
+		volumes = DataVolumes()
+	}
+
+	if len(contsE) == 0 && len(contsR) == 0 && len(known) == 0 && len(volumes) == 0 {
 		return []Parts{}, nil
 	}
 
@@ -364,6 +446,15 @@ func AssembleTable(typ string) ([]Parts, error) {
 		}
 	}
 
+	for _, name := range volumes {
+		myTable = append(myTable, Parts{
+			ShortName: name.ShortName,
+			Type:      "volume",
+			FullName:  name.FullName,
+			Number:    name.Number,
+		})
+	}
+
 	if typ != "data" {
 		for _, name := range known {
 			if addedAlready[name] == true { //name from known == part.ShortName
@@ -388,7 +479,7 @@ func formatLine(p Parts) []string {
 	number := fmt.Sprintf("%d", p.Number)
 
 	//must match header
-	part := []string{p.ShortName, p.Type, running, p.FullName, number, p.PortsOutput}
+	part := []string{p.ShortName, p.Type, running, p.FullName, number, p.PortsOutput, p.Networks}
 
 	if p.Type == "chain" {
 		head, _ := GetHead()
@@ -408,7 +499,7 @@ func makePartFromContainer(name string, defs bool) (v Parts, err error) {
 		var contID *docker.Container
 		cont, exists := ParseContainers(name, true)
 		if exists {
-			contID, err = DockerClient.InspectContainer(cont.ID)
+			contID, err = Backend.InspectContainer(cont.ID)
 			if err != nil {
 				return Parts{}, err
 			}
@@ -432,6 +523,7 @@ func makePartFromContainer(name string, defs bool) (v Parts, err error) {
 			FullName:    Names.FullName,
 			Number:      Names.Number,
 			PortsOutput: formulatePortsOutput(contID),
+			Networks:    strings.Join(ContainerNetworks(contID), ","),
 		}
 	}
 	return v, nil
@@ -0,0 +1,128 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// Volumes returns the backend as a VolumeBackend or nil if it is not
+// able to manage named volumes.
+func Volumes() VolumeBackend {
+	volumes, _ := Backend.(VolumeBackend)
+	return volumes
+}
+
+// DataVolumes returns the named volumes eris keeps data in (those
+// labelled eris:TYPE=data), named as data containers are.
+func DataVolumes() []*ContainerName {
+	names := []*ContainerName{}
+	if Volumes() == nil {
+		return names
+	}
+
+	volumes, err := Volumes().ListVolumes(docker.ListVolumesOptions{
+		Filters: map[string][]string{
+			"label": {def.Namespace + ":" + def.LabelType + "=" + def.TypeData},
+		},
+	})
+	if err != nil {
+		logger.Debugf("Marmot error during ListVolumes: %v\n", err)
+		return names
+	}
+
+	for _, volume := range volumes {
+		name := ContainerDisassemble(volume.Name)
+		if name.FullName == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Sort(containerNames(names))
+	return names
+}
+
+// FindDataVolume returns the data volume of name and number or nil.
+func FindDataVolume(name string, number int) *ContainerName {
+	for _, volume := range DataVolumes() {
+		if volume.ShortName == name && volume.Number == number {
+			logger.Debugf("Found Data volume =>\t\t%s:%d\n", name, number)
+			return volume
+		}
+	}
+	return nil
+}
+
+// IsDataVolume returns true if the data of name and number is kept in
+// a named volume.
+func IsDataVolume(name string, number int) bool {
+	return FindDataVolume(name, number) != nil
+}
+
+// IsData returns true if the data of name and number exists, kept in
+// a data container or in a named volume.
+func IsData(name string, number int) bool {
+	return IsDataContainer(name, number) || IsDataVolume(name, number)
+}
+
+// DataVolumeExists returns true if the data volume with the full name
+// (eg. eris_data_ipfs_1) exists.
+func DataVolumeExists(fullName string) bool {
+	if Volumes() == nil {
+		return false
+	}
+	volume, err := Volumes().InspectVolume(fullName)
+	if err != nil {
+		return false
+	}
+	return volume.Labels[def.Namespace+":"+def.LabelType] == def.TypeData
+}
+
+// CreateDataVolume creates the data volume fullName with the labels
+// given (which are marked as those of data).
+func CreateDataVolume(fullName string, labels map[string]string) error {
+	volumeLabels := make(map[string]string)
+	for k, v := range labels {
+		volumeLabels[k] = v
+	}
+	volumeLabels = SetLabel(volumeLabels, def.LabelEris, "true")
+	volumeLabels = SetLabel(volumeLabels, def.LabelType, def.TypeData)
+	if name := ContainerDisassemble(fullName); name.FullName != "" {
+		volumeLabels = SetLabel(volumeLabels, def.LabelShortName, name.ShortName)
+		volumeLabels = SetLabel(volumeLabels, def.LabelNumber, fmt.Sprint(name.Number))
+	}
+
+	logger.Infof("Creating data volume =>\t\t%s\n", fullName)
+	_, err := Volumes().CreateVolume(docker.CreateVolumeOptions{
+		Name:   fullName,
+		Labels: volumeLabels,
+	})
+	return err
+}
+
+// PrintVolumeReport displays the field of the volume (or all of them).
+func PrintVolumeReport(volume *docker.Volume, field string) error {
+	if field == "all" {
+		for _, f := range []string{"Name", "Driver", "Mountpoint", "Labels"} {
+			if err := printReport(volume, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return printField(volume, field)
+}
+
+type containerNames []*ContainerName
+
+func (n containerNames) Len() int      { return len(n) }
+func (n containerNames) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
+func (n containerNames) Less(i, j int) bool {
+	if n[i].ShortName != n[j].ShortName {
+		return n[i].ShortName < n[j].ShortName
+	}
+	return n[i].Number < n[j].Number
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/BurntSushi/toml"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/gopkg.in/yaml.v2"
+)
+
+// WriteDefinitionFile writes the definition (a project, a remote, etc.)
+// to fileName in the format given by its extension: JSON for .json,
+// YAML for .yaml and TOML otherwise. The directory of fileName is
+// created if needed.
+func WriteDefinitionFile(definition interface{}, fileName string) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+
+	writer, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	switch filepath.Ext(fileName) {
+	case ".json":
+		mar, err := json.MarshalIndent(definition, "", "  ")
+		if err != nil {
+			return err
+		}
+		mar = append(mar, '\n')
+		_, err = writer.Write(mar)
+		return err
+	case ".yaml":
+		mar, err := yaml.Marshal(definition)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(mar)
+		return err
+	default:
+		writer.Write([]byte("# This is a TOML config file.\n# For more information, see https://github.com/toml-lang/toml\n\n"))
+		return toml.NewEncoder(writer).Encode(definition)
+	}
+}
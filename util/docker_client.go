@@ -42,6 +42,7 @@ func DockerConnect(verbose bool, machName string) { // TODO: return an error...?
 			if err != nil {
 				IfExit(fmt.Errorf("%v\n", mustInstallError()))
 			}
+			Backend = DockerClient
 		} else {
 			//if machName !="eris/default" OR if docker env vars set (via, e.g., eval)
 			logger.Debugf("Connecting to the Docker Client via =>\t%s:%s\n", os.Getenv("DOCKER_HOST"), os.Getenv("DOCKER_CERT_PATH"))
@@ -94,6 +95,26 @@ func DockerConnect(verbose bool, machName string) { // TODO: return an error...?
 	}
 }
 
+// DockerConnectHost connects to the Docker daemon at endpoint, over TLS
+// if certPath (holding cert.pem, key.pem and ca.pem) is given.
+func DockerConnectHost(endpoint, certPath string) error {
+	if certPath != "" {
+		return connectDockerTLS(endpoint, certPath)
+	}
+
+	client, err := docker.NewClient(endpoint)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Version(); err != nil {
+		return err
+	}
+
+	DockerClient = client
+	Backend = DockerClient
+	return nil
+}
+
 func CheckDockerClient() error {
 	if runtime.GOOS == "linux" {
 		return nil
@@ -210,7 +231,7 @@ func getMachineDeets(machName string) (string, string, error) {
 }
 
 func DockerClientVersion() (float64, error) {
-	verR, err := DockerClient.Version()
+	verR, err := Backend.Version()
 	if err != nil {
 		return 0, err
 	}
@@ -223,7 +244,7 @@ func DockerClientVersion() (float64, error) {
 }
 
 func DockerAPIVersion() (float64, error) {
-	verR, err := DockerClient.Version()
+	verR, err := Backend.Version()
 	if err != nil {
 		return 0, err
 	}
@@ -282,6 +303,7 @@ func connectDockerTLS(dockerHost, dockerCertPath string) error {
 	if err != nil {
 		return err
 	}
+	Backend = DockerClient
 
 	logger.Debugf("Connected over TLS.\n")
 	return nil
@@ -0,0 +1,825 @@
+package util
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// FakeBackend is an in-memory ContainerBackend. Containers are kept in a
+// map and never actually run; starting one simply flips its state. It
+// mirrors the Docker errors callers check for (NoSuchContainer,
+// ContainerAlreadyRunning, etc.) so code paths behave the same as
+// against a real daemon.
+type FakeBackend struct {
+	sync.Mutex
+
+	containers map[string]*docker.Container
+	images     map[string]bool
+	pullAuths  map[string]docker.AuthConfiguration
+	logs       map[string]string
+	exitCodes  map[string]int
+	execCodes  map[string]int
+	execs      map[string]*docker.ExecInspect
+	files      map[string]map[string][]byte
+	networks   map[string]*docker.Network
+	volumes    map[string]*docker.Volume
+	builds     int
+	counter    int
+}
+
+// NewFakeBackend returns an empty in-memory backend. Assign it to
+// util.Backend to have eris packages operate against it.
+func NewFakeBackend() *FakeBackend {
+	return &FakeBackend{
+		containers: make(map[string]*docker.Container),
+		images:     make(map[string]bool),
+		pullAuths:  make(map[string]docker.AuthConfiguration),
+		logs:       make(map[string]string),
+		exitCodes:  make(map[string]int),
+		execCodes:  make(map[string]int),
+		execs:      make(map[string]*docker.ExecInspect),
+		files:      make(map[string]map[string][]byte),
+		networks:   make(map[string]*docker.Network),
+		volumes:    make(map[string]*docker.Volume),
+	}
+}
+
+// SetLogs sets the output returned by Logs for a container.
+func (f *FakeBackend) SetLogs(id, logs string) {
+	f.Lock()
+	defer f.Unlock()
+	if c := f.find(id); c != nil {
+		f.logs[c.ID] = logs
+	}
+}
+
+// SetExitCode sets the code WaitContainer returns for a container.
+func (f *FakeBackend) SetExitCode(id string, code int) {
+	f.Lock()
+	defer f.Unlock()
+	if c := f.find(id); c != nil {
+		f.exitCodes[c.ID] = code
+	}
+}
+
+// SetExecExitCode sets the code the commands exec'd in a container exit
+// with.
+func (f *FakeBackend) SetExecExitCode(id string, code int) {
+	f.Lock()
+	defer f.Unlock()
+	if c := f.find(id); c != nil {
+		f.execCodes[c.ID] = code
+	}
+}
+
+// Images returns the images pulled or used by created containers.
+func (f *FakeBackend) Images() []string {
+	f.Lock()
+	defer f.Unlock()
+	var images []string
+	for image := range f.images {
+		images = append(images, image)
+	}
+	return images
+}
+
+func (f *FakeBackend) CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if opts.Config == nil {
+		return nil, fmt.Errorf("cannot create a container without a config")
+	}
+	if opts.Name != "" && f.find(opts.Name) != nil {
+		return nil, docker.ErrContainerAlreadyExists
+	}
+
+	f.counter++
+	id := fmt.Sprintf("%064x", f.counter)
+	name := opts.Name
+	if name == "" {
+		name = id[:12]
+	}
+
+	config := *opts.Config
+	hostConfig := &docker.HostConfig{}
+	if opts.HostConfig != nil {
+		*hostConfig = *opts.HostConfig
+	}
+
+	ports := make(map[docker.Port][]docker.PortBinding)
+	for port := range config.ExposedPorts {
+		ports[port] = nil
+	}
+	for port, bindings := range hostConfig.PortBindings {
+		ports[port] = bindings
+	}
+
+	// Containers only get an address of their own on the default
+	// bridge; on user-defined networks they have one per network.
+	var address string
+	switch hostConfig.NetworkMode {
+	case "", "bridge", "default":
+		address = fmt.Sprintf("172.17.0.%d", f.counter+1)
+	}
+
+	container := &docker.Container{
+		ID:         id,
+		Name:       "/" + name,
+		Created:    time.Now(),
+		Path:       strings.Join(config.Entrypoint, " "),
+		Args:       config.Cmd,
+		Config:     &config,
+		Image:      config.Image,
+		HostConfig: hostConfig,
+		NetworkSettings: &docker.NetworkSettings{
+			IPAddress: address,
+			Ports:     ports,
+		},
+	}
+	f.containers[id] = container
+	f.files[id] = make(map[string][]byte)
+	// The files of a container with a volume are those of the volume.
+	for _, bind := range hostConfig.Binds {
+		if volume := strings.Split(bind, ":")[0]; f.volumes[volume] != nil {
+			f.files[id] = f.files[volumeKey(volume)]
+		}
+	}
+	f.images[config.Image] = true
+
+	if opts.NetworkingConfig != nil {
+		for name, endpoint := range opts.NetworkingConfig.EndpointsConfig {
+			if err := f.connect(name, container, endpoint); err != nil {
+				delete(f.containers, id)
+				return nil, err
+			}
+		}
+	}
+
+	return container, nil
+}
+
+func (f *FakeBackend) StartContainer(id string, hostConfig *docker.HostConfig) error {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(id)
+	if c == nil {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	if c.State.Running {
+		return &docker.ContainerAlreadyRunning{ID: id}
+	}
+	if hostConfig != nil {
+		c.HostConfig = hostConfig
+	}
+	c.State.Running = true
+	c.State.StartedAt = time.Now()
+	c.State.ExitCode = 0
+	return nil
+}
+
+func (f *FakeBackend) StopContainer(id string, timeout uint) error {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(id)
+	if c == nil {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	if !c.State.Running {
+		return &docker.ContainerNotRunning{ID: id}
+	}
+	f.stop(c)
+	return nil
+}
+
+func (f *FakeBackend) RemoveContainer(opts docker.RemoveContainerOptions) error {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(opts.ID)
+	if c == nil {
+		return &docker.NoSuchContainer{ID: opts.ID}
+	}
+	if c.State.Running && !opts.Force {
+		return fmt.Errorf("Conflict, You cannot remove a running container. Stop the container before attempting removal or use -f")
+	}
+	for _, n := range f.networks {
+		delete(n.Containers, c.ID)
+	}
+	delete(f.containers, c.ID)
+	delete(f.logs, c.ID)
+	delete(f.exitCodes, c.ID)
+	delete(f.files, c.ID)
+	return nil
+}
+
+func (f *FakeBackend) InspectContainer(id string) (*docker.Container, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(id)
+	if c == nil {
+		return nil, &docker.NoSuchContainer{ID: id}
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (f *FakeBackend) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var out []docker.APIContainers
+	for _, c := range f.containers {
+		if !opts.All && !c.State.Running {
+			continue
+		}
+		if !MatchLabelFilters(c.Config.Labels, opts.Filters["label"]) {
+			continue
+		}
+
+		status := "Exited (" + fmt.Sprint(c.State.ExitCode) + ")"
+		if c.State.Running {
+			status = "Up"
+		}
+
+		var ports []docker.APIPort
+		for port, bindings := range c.NetworkSettings.Ports {
+			for _, b := range bindings {
+				var public int64
+				fmt.Sscan(b.HostPort, &public)
+				var private int64
+				fmt.Sscan(port.Port(), &private)
+				ports = append(ports, docker.APIPort{
+					PrivatePort: private,
+					PublicPort:  public,
+					Type:        port.Proto(),
+					IP:          b.HostIP,
+				})
+			}
+		}
+
+		out = append(out, docker.APIContainers{
+			ID:      c.ID,
+			Image:   c.Image,
+			Command: strings.TrimSpace(c.Path + " " + strings.Join(c.Args, " ")),
+			Created: c.Created.Unix(),
+			Status:  status,
+			Ports:   ports,
+			Names:   []string{c.Name},
+			Labels:  c.Config.Labels,
+		})
+	}
+	return out, nil
+}
+
+func (f *FakeBackend) WaitContainer(id string) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(id)
+	if c == nil {
+		return 0, &docker.NoSuchContainer{ID: id}
+	}
+	f.stop(c)
+	return c.State.ExitCode, nil
+}
+
+func (f *FakeBackend) AttachToContainer(opts docker.AttachToContainerOptions) error {
+	f.Lock()
+	c := f.find(opts.Container)
+	var logs string
+	if c != nil {
+		logs = f.logs[c.ID]
+	}
+	f.Unlock()
+
+	if c == nil {
+		return &docker.NoSuchContainer{ID: opts.Container}
+	}
+
+	// Same handshake the Docker client performs once the connection
+	// has been hijacked.
+	if opts.Success != nil {
+		opts.Success <- struct{}{}
+		<-opts.Success
+	}
+
+	if opts.Stdout && opts.OutputStream != nil {
+		io.WriteString(opts.OutputStream, logs)
+	}
+	return nil
+}
+
+func (f *FakeBackend) Logs(opts docker.LogsOptions) error {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(opts.Container)
+	if c == nil {
+		return &docker.NoSuchContainer{ID: opts.Container}
+	}
+	if opts.Stdout && opts.OutputStream != nil {
+		io.WriteString(opts.OutputStream, f.logs[c.ID])
+	}
+	return nil
+}
+
+func (f *FakeBackend) UploadToContainer(id string, opts docker.UploadToContainerOptions) error {
+	f.Lock()
+	c := f.find(id)
+	f.Unlock()
+
+	if c == nil {
+		return &docker.NoSuchContainer{ID: id}
+	}
+
+	content, err := ioutil.ReadAll(opts.InputStream)
+	if err != nil {
+		return err
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	f.files[c.ID][opts.Path] = content
+	return nil
+}
+
+func (f *FakeBackend) DownloadFromContainer(id string, opts docker.DownloadFromContainerOptions) error {
+	f.Lock()
+	c := f.find(id)
+	var content []byte
+	var ok bool
+	if c != nil {
+		content, ok = f.files[c.ID][opts.Path]
+	}
+	f.Unlock()
+
+	if c == nil {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	if !ok {
+		return fmt.Errorf("Could not find the file %s in container %s", opts.Path, id)
+	}
+
+	_, err := io.Copy(opts.OutputStream, bytes.NewReader(content))
+	return err
+}
+
+func (f *FakeBackend) PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error {
+	f.Lock()
+	defer f.Unlock()
+
+	image := opts.Repository
+	if opts.Tag != "" {
+		image = image + ":" + opts.Tag
+	}
+	f.images[image] = true
+	f.pullAuths[image] = auth
+	return nil
+}
+
+// PullAuth returns the credentials the image was last pulled with.
+func (f *FakeBackend) PullAuth(image string) docker.AuthConfiguration {
+	f.Lock()
+	defer f.Unlock()
+	return f.pullAuths[image]
+}
+
+// BuildImage reads the build context and records the image opts.Name as
+// available. Builds are counted by Builds.
+func (f *FakeBackend) BuildImage(opts docker.BuildImageOptions) error {
+	if opts.InputStream == nil {
+		return docker.ErrMissingRepo
+	}
+	if _, err := ioutil.ReadAll(opts.InputStream); err != nil {
+		return err
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	f.images[opts.Name] = true
+	f.builds++
+	return nil
+}
+
+// Builds returns the number of images BuildImage has built.
+func (f *FakeBackend) Builds() int {
+	f.Lock()
+	defer f.Unlock()
+	return f.builds
+}
+
+func (f *FakeBackend) InspectImage(name string) (*docker.Image, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !strings.Contains(name[strings.LastIndex(name, "/")+1:], ":") {
+		name = name + ":latest"
+	}
+	if !f.images[name] {
+		return nil, docker.ErrNoSuchImage
+	}
+	return &docker.Image{ID: name}, nil
+}
+
+// ListImages lists the images pulled, built or loaded, each under its
+// name as ID.
+func (f *FakeBackend) ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var images []docker.APIImages
+	for name := range f.images {
+		images = append(images, docker.APIImages{ID: name, RepoTags: []string{name}})
+	}
+	return images, nil
+}
+
+func (f *FakeBackend) RemoveImage(name string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if !f.images[name] {
+		return docker.ErrNoSuchImage
+	}
+	delete(f.images, name)
+	return nil
+}
+
+// ExportImages writes the images as docker save does: a tar with the
+// manifest.json listing them (and a blank config for each).
+func (f *FakeBackend) ExportImages(opts docker.ExportImagesOptions) error {
+	if len(opts.Names) == 0 {
+		return docker.ErrMustSpecifyNames
+	}
+
+	type entry struct {
+		Config   string
+		RepoTags []string
+		Layers   []string
+	}
+	var manifest []entry
+	f.Lock()
+	for i, name := range opts.Names {
+		if !strings.Contains(name[strings.LastIndex(name, "/")+1:], ":") {
+			name = name + ":latest"
+		}
+		if !f.images[name] {
+			f.Unlock()
+			return docker.ErrNoSuchImage
+		}
+		manifest = append(manifest, entry{Config: fmt.Sprintf("%d.json", i), RepoTags: []string{name}, Layers: []string{}})
+	}
+	f.Unlock()
+
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	files := tar.NewWriter(opts.OutputStream)
+	write := func(name string, body []byte) error {
+		if err := files.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(body))}); err != nil {
+			return err
+		}
+		_, err := files.Write(body)
+		return err
+	}
+	for _, e := range manifest {
+		if err := write(e.Config, []byte("{}")); err != nil {
+			return err
+		}
+	}
+	if err := write("manifest.json", body); err != nil {
+		return err
+	}
+	return files.Close()
+}
+
+// LoadImage records the images of the manifest.json of the tar read as
+// available.
+func (f *FakeBackend) LoadImage(opts docker.LoadImageOptions) error {
+	files := tar.NewReader(opts.InputStream)
+	for {
+		header, err := files.Next()
+		if err == io.EOF {
+			return fmt.Errorf("open manifest.json: no such file or directory")
+		}
+		if err != nil {
+			return err
+		}
+		if header.Name != "manifest.json" {
+			continue
+		}
+
+		var manifest []struct{ RepoTags []string }
+		if err := json.NewDecoder(files).Decode(&manifest); err != nil {
+			return err
+		}
+		f.Lock()
+		defer f.Unlock()
+		for _, e := range manifest {
+			for _, tag := range e.RepoTags {
+				f.images[tag] = true
+			}
+		}
+		return nil
+	}
+}
+
+func (f *FakeBackend) CreateExec(opts docker.CreateExecOptions) (*docker.Exec, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(opts.Container)
+	if c == nil {
+		return nil, &docker.NoSuchContainer{ID: opts.Container}
+	}
+	if !c.State.Running {
+		return nil, fmt.Errorf("Container %s is not running", opts.Container)
+	}
+	if len(opts.Cmd) == 0 {
+		return nil, fmt.Errorf("No exec command specified")
+	}
+
+	f.counter++
+	id := fmt.Sprintf("exec%d", f.counter)
+	f.execs[id] = &docker.ExecInspect{
+		ID:            id,
+		ProcessConfig: docker.ExecProcessConfig{EntryPoint: opts.Cmd[0], Arguments: opts.Cmd[1:]},
+		ContainerID:   c.ID,
+	}
+	return &docker.Exec{ID: id}, nil
+}
+
+// StartExec completes the exec with the code set by SetExecExitCode.
+func (f *FakeBackend) StartExec(id string, opts docker.StartExecOptions) error {
+	f.Lock()
+	defer f.Unlock()
+
+	e, ok := f.execs[id]
+	if !ok {
+		return &docker.NoSuchExec{ID: id}
+	}
+	e.ExitCode = f.execCodes[e.ContainerID]
+	return nil
+}
+
+func (f *FakeBackend) InspectExec(id string) (*docker.ExecInspect, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	e, ok := f.execs[id]
+	if !ok {
+		return nil, &docker.NoSuchExec{ID: id}
+	}
+	inspect := *e
+	return &inspect, nil
+}
+
+func (f *FakeBackend) Version() (*docker.Env, error) {
+	return &docker.Env{"Version=1.9.1", "APIVersion=1.21"}, nil
+}
+
+func (f *FakeBackend) CreateNetwork(opts docker.CreateNetworkOptions) (*docker.Network, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.findNetwork(opts.Name) != nil {
+		return nil, docker.ErrNetworkAlreadyExists
+	}
+	f.counter++
+	n := &docker.Network{
+		Name:       opts.Name,
+		ID:         fmt.Sprintf("%064x", f.counter),
+		Driver:     opts.Driver,
+		Labels:     opts.Labels,
+		Containers: make(map[string]docker.Endpoint),
+	}
+	f.networks[n.ID] = n
+	return n, nil
+}
+
+func (f *FakeBackend) ListNetworks() ([]docker.Network, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var networks []docker.Network
+	for _, n := range f.networks {
+		networks = append(networks, *n)
+	}
+	return networks, nil
+}
+
+func (f *FakeBackend) NetworkInfo(id string) (*docker.Network, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	n := f.findNetwork(id)
+	if n == nil {
+		return nil, &docker.NoSuchNetwork{ID: id}
+	}
+	info := *n
+	info.Containers = make(map[string]docker.Endpoint)
+	for id, endpoint := range n.Containers {
+		info.Containers[id] = endpoint
+	}
+	return &info, nil
+}
+
+func (f *FakeBackend) RemoveNetwork(id string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	n := f.findNetwork(id)
+	if n == nil {
+		return &docker.NoSuchNetwork{ID: id}
+	}
+	if len(n.Containers) != 0 {
+		return fmt.Errorf("network %s has active endpoints", n.Name)
+	}
+	delete(f.networks, n.ID)
+	return nil
+}
+
+func (f *FakeBackend) ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	f.Lock()
+	defer f.Unlock()
+
+	c := f.find(opts.Container)
+	if c == nil {
+		return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: opts.Container}
+	}
+	return f.connect(id, c, opts.EndpointConfig)
+}
+
+func (f *FakeBackend) DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	f.Lock()
+	defer f.Unlock()
+
+	n, c := f.findNetwork(id), f.find(opts.Container)
+	if n == nil || c == nil {
+		return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: opts.Container}
+	}
+	delete(n.Containers, c.ID)
+	delete(c.NetworkSettings.Networks, n.Name)
+	return nil
+}
+
+func (f *FakeBackend) CreateVolume(opts docker.CreateVolumeOptions) (*docker.Volume, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	// As Docker does, creating an existing volume is fine.
+	if v, ok := f.volumes[opts.Name]; ok {
+		copied := *v
+		return &copied, nil
+	}
+	v := &docker.Volume{
+		Name:       opts.Name,
+		Driver:     "local",
+		Mountpoint: "/var/lib/docker/volumes/" + opts.Name + "/_data",
+		Labels:     opts.Labels,
+	}
+	f.volumes[v.Name] = v
+	f.files[volumeKey(v.Name)] = make(map[string][]byte)
+	copied := *v
+	return &copied, nil
+}
+
+func (f *FakeBackend) InspectVolume(name string) (*docker.Volume, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	v, ok := f.volumes[name]
+	if !ok {
+		return nil, docker.ErrNoSuchVolume
+	}
+	copied := *v
+	return &copied, nil
+}
+
+func (f *FakeBackend) ListVolumes(opts docker.ListVolumesOptions) ([]docker.Volume, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var volumes []docker.Volume
+	for _, v := range f.volumes {
+		if MatchLabelFilters(v.Labels, opts.Filters["label"]) {
+			volumes = append(volumes, *v)
+		}
+	}
+	return volumes, nil
+}
+
+func (f *FakeBackend) RemoveVolume(name string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if _, ok := f.volumes[name]; !ok {
+		return docker.ErrNoSuchVolume
+	}
+	for _, c := range f.containers {
+		for _, bind := range c.HostConfig.Binds {
+			if strings.Split(bind, ":")[0] == name {
+				return docker.ErrVolumeInUse
+			}
+		}
+	}
+	delete(f.volumes, name)
+	delete(f.files, volumeKey(name))
+	return nil
+}
+
+// volumeKey is the key of the files of the volume name.
+func volumeKey(name string) string {
+	return "volume:" + name
+}
+
+// connect puts the container on the network id (or name). The caller
+// must hold the lock.
+func (f *FakeBackend) connect(id string, c *docker.Container, endpoint *docker.EndpointConfig) error {
+	n := f.findNetwork(id)
+	if n == nil {
+		return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: c.ID}
+	}
+	var aliases []string
+	if endpoint != nil {
+		aliases = endpoint.Aliases
+	}
+	n.Containers[c.ID] = docker.Endpoint{Name: strings.TrimPrefix(c.Name, "/")}
+	if c.NetworkSettings.Networks == nil {
+		c.NetworkSettings.Networks = make(map[string]docker.ContainerNetwork)
+	}
+	c.NetworkSettings.Networks[n.Name] = docker.ContainerNetwork{
+		NetworkID: n.ID,
+		Aliases:   aliases,
+		IPAddress: fmt.Sprintf("172.18.%d.%d", len(c.NetworkSettings.Networks), len(n.Containers)+1),
+	}
+	return nil
+}
+
+// findNetwork looks a network up by ID or name. The caller must hold
+// the lock.
+func (f *FakeBackend) findNetwork(id string) *docker.Network {
+	if n, ok := f.networks[id]; ok {
+		return n
+	}
+	for _, n := range f.networks {
+		if n.Name == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// find looks a container up by ID, short ID, or name (with or
+// without the leading slash). The caller must hold the lock.
+func (f *FakeBackend) find(id string) *docker.Container {
+	if id == "" {
+		return nil
+	}
+	if c, ok := f.containers[id]; ok {
+		return c
+	}
+	name := "/" + strings.TrimPrefix(id, "/")
+	for _, c := range f.containers {
+		if c.Name == name || strings.HasPrefix(c.ID, id) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (f *FakeBackend) stop(c *docker.Container) {
+	c.State.Running = false
+	c.State.FinishedAt = time.Now()
+	c.State.ExitCode = f.exitCodes[c.ID]
+}
+
+// MatchLabelFilters reports whether labels satisfy all "key" or
+// "key=value" filters.
+func MatchLabelFilters(labels map[string]string, filters []string) bool {
+	for _, filter := range filters {
+		parts := strings.SplitN(filter, "=", 2)
+		value, ok := labels[parts[0]]
+		if !ok {
+			return false
+		}
+		if len(parts) == 2 && value != parts[1] {
+			return false
+		}
+	}
+	return true
+}
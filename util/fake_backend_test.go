@@ -0,0 +1,335 @@
+package util
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+func TestFakeBackendLifecycle(t *testing.T) {
+	f := NewFakeBackend()
+
+	opts := docker.CreateContainerOptions{
+		Name: "eris_service_keys_1",
+		Config: &docker.Config{
+			Image:  "quay.io/eris/keys",
+			Labels: map[string]string{"eris:ERIS": "true", "eris:TYPE": "service"},
+		},
+	}
+	cont, err := f.CreateContainer(opts)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := f.CreateContainer(opts); err != docker.ErrContainerAlreadyExists {
+		t.Fatalf("expected ErrContainerAlreadyExists, got %v", err)
+	}
+
+	if err := f.StartContainer(opts.Name, nil); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := f.StartContainer(cont.ID, nil); err == nil {
+		t.Fatalf("expected an error starting a running container")
+	}
+
+	running, _ := f.ListContainers(docker.ListContainersOptions{Filters: map[string][]string{"label": {"eris:TYPE=service"}}})
+	if len(running) != 1 || running[0].Names[0] != "/"+opts.Name {
+		t.Fatalf("expected one running service container, got %v", running)
+	}
+	none, _ := f.ListContainers(docker.ListContainersOptions{Filters: map[string][]string{"label": {"eris:TYPE=chain"}}})
+	if len(none) != 0 {
+		t.Fatalf("expected no chain containers, got %v", none)
+	}
+
+	if err := f.RemoveContainer(docker.RemoveContainerOptions{ID: cont.ID}); err == nil {
+		t.Fatalf("expected an error removing a running container")
+	}
+	if err := f.StopContainer(cont.ID, 10); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if _, ok := f.StopContainer(cont.ID, 10).(*docker.ContainerNotRunning); !ok {
+		t.Fatalf("expected ContainerNotRunning stopping a stopped container")
+	}
+
+	stopped, _ := f.ListContainers(docker.ListContainersOptions{All: false})
+	if len(stopped) != 0 {
+		t.Fatalf("expected no running containers, got %d", len(stopped))
+	}
+
+	if err := f.RemoveContainer(docker.RemoveContainerOptions{ID: cont.ID}); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, ok := f.InspectContainer(cont.ID); ok == nil {
+		t.Fatalf("expected the container to be gone")
+	}
+}
+
+func TestFakeBackendFilesAndLogs(t *testing.T) {
+	f := NewFakeBackend()
+	cont, err := f.CreateContainer(docker.CreateContainerOptions{
+		Name:   "eris_data_keys_1",
+		Config: &docker.Config{Image: "quay.io/eris/data"},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	in := []byte("tarball")
+	if err := f.UploadToContainer(cont.ID, docker.UploadToContainerOptions{InputStream: bytes.NewReader(in), Path: "/home/eris/.eris"}); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	out := new(bytes.Buffer)
+	if err := f.DownloadFromContainer("eris_data_keys_1", docker.DownloadFromContainerOptions{OutputStream: out, Path: "/home/eris/.eris"}); err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	if out.String() != string(in) {
+		t.Fatalf("downloaded %q, expected %q", out.String(), in)
+	}
+
+	f.SetLogs(cont.ID, "hello marmots\n")
+	logs := new(bytes.Buffer)
+	if err := f.Logs(docker.LogsOptions{Container: cont.ID, OutputStream: logs, Stdout: true}); err != nil {
+		t.Fatalf("logs: %v", err)
+	}
+	if logs.String() != "hello marmots\n" {
+		t.Fatalf("got logs %q", logs.String())
+	}
+
+	f.SetExitCode(cont.ID, 3)
+	f.StartContainer(cont.ID, nil)
+	if code, _ := f.WaitContainer(cont.ID); code != 3 {
+		t.Fatalf("expected exit code 3, got %d", code)
+	}
+}
+
+func TestContainerPortAddress(t *testing.T) {
+	saved := Backend
+	defer func() { Backend = saved }()
+	f := NewFakeBackend()
+	Backend = f
+
+	cont, err := f.CreateContainer(docker.CreateContainerOptions{
+		Name: "eris_chain_mint_1",
+		Config: &docker.Config{
+			Image:        "quay.io/eris/erisdb",
+			ExposedPorts: map[docker.Port]struct{}{"46656/tcp": {}, "46657/tcp": {}, "1337/tcp": {}},
+		},
+		HostConfig: &docker.HostConfig{
+			PortBindings: map[docker.Port][]docker.PortBinding{
+				"46657/tcp": {{HostIP: "0.0.0.0", HostPort: "32768"}},
+				"1337/tcp":  {{HostIP: "10.0.0.5", HostPort: "1337"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	os.Setenv("DOCKER_HOST", "")
+	os.Setenv("ERIS_IPFS_HOST", "")
+	for _, tt := range []struct{ port, addr string }{
+		{"46657", "127.0.0.1:32768"},
+		{"46657/tcp", "127.0.0.1:32768"},
+		{"1337", "10.0.0.5:1337"},
+		{"46656", cont.NetworkSettings.IPAddress + ":46656"},
+	} {
+		addr, err := ContainerPortAddress(cont.ID, tt.port)
+		if err != nil {
+			t.Fatalf("address of %s: %v", tt.port, err)
+		}
+		if addr != tt.addr {
+			t.Fatalf("wrong address of %s. Got %s, expected %s", tt.port, addr, tt.addr)
+		}
+	}
+
+	os.Setenv("DOCKER_HOST", "tcp://192.168.99.100:2376")
+	defer os.Setenv("DOCKER_HOST", "")
+	if addr, _ := ContainerPortAddress(cont.ID, "46657"); addr != "192.168.99.100:32768" {
+		t.Fatalf("wrong address on a remote Docker host %s", addr)
+	}
+
+	if _, err := ContainerPortAddress("nonexistent", "46657"); err == nil {
+		t.Fatalf("expected an error for a missing container")
+	}
+
+	// Containers on user-defined networks have an address per network.
+	for _, name := range []string{DefaultNetwork, ChainNetwork("mint")} {
+		if err := EnsureNetwork(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, networks := range [][]string{
+		{ChainNetwork("mint"), DefaultNetwork},
+		{ChainNetwork("mint")},
+	} {
+		opts := docker.CreateContainerOptions{
+			Config:           &docker.Config{Image: "quay.io/eris/erisdb"},
+			HostConfig:       &docker.HostConfig{NetworkMode: networks[0]},
+			NetworkingConfig: &docker.NetworkingConfig{EndpointsConfig: make(map[string]*docker.EndpointConfig)},
+		}
+		for _, network := range networks {
+			opts.NetworkingConfig.EndpointsConfig[network] = &docker.EndpointConfig{}
+		}
+		cont, err := f.CreateContainer(opts)
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if cont.NetworkSettings.IPAddress != "" {
+			t.Fatalf("expected no bridge address on a user-defined network, got %s", cont.NetworkSettings.IPAddress)
+		}
+
+		preferred := DefaultNetwork
+		if len(networks) == 1 {
+			preferred = networks[0]
+		}
+		expected := net.JoinHostPort(cont.NetworkSettings.Networks[preferred].IPAddress, "46656")
+		if addr, err := ContainerPortAddress(cont.ID, "46656"); err != nil || addr != expected {
+			t.Fatalf("wrong address on %v. Got %s (%v), expected %s", networks, addr, err, expected)
+		}
+	}
+}
+
+func TestRemoveOrphanedNetworks(t *testing.T) {
+	saved := Backend
+	defer func() { Backend = saved }()
+	f := NewFakeBackend()
+	Backend = f
+
+	for _, name := range []string{DefaultNetwork, ChainNetwork("simplechain")} {
+		if err := EnsureNetwork(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := EnsureNetwork(DefaultNetwork); err != nil {
+		t.Fatalf("expected an existing network to be fine, got %v", err)
+	}
+	if _, err := f.CreateNetwork(docker.CreateNetworkOptions{Name: "not_eris"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cont, err := f.CreateContainer(docker.CreateContainerOptions{
+		Name:   "eris_service_keys_1",
+		Config: &docker.Config{Image: "quay.io/eris/keys"},
+		NetworkingConfig: &docker.NetworkingConfig{EndpointsConfig: map[string]*docker.EndpointConfig{
+			DefaultNetwork: {Aliases: []string{"keys"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if networks := ContainerNetworks(cont); len(networks) != 1 || networks[0] != DefaultNetwork {
+		t.Fatalf("expected the container on the eris network, got %v", networks)
+	}
+
+	if err := RemoveOrphanedNetworks(); err != nil {
+		t.Fatal(err)
+	}
+	left, _ := f.ListNetworks()
+	var names []string
+	for _, n := range left {
+		names = append(names, n.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, " ") != "eris not_eris" {
+		t.Fatalf("expected the orphaned chain network removed only, got %v", names)
+	}
+}
+
+func TestDataVolumes(t *testing.T) {
+	saved := Backend
+	defer func() { Backend = saved }()
+	f := NewFakeBackend()
+	Backend = f
+
+	if err := CreateDataVolume("eris_data_ipfs_1", map[string]string{"eris:SERVICE": "eris_service_ipfs_1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.CreateVolume(docker.CreateVolumeOptions{Name: "eris_data_other_1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsDataVolume("ipfs", 1) || IsDataVolume("ipfs", 2) || IsDataVolume("other", 1) {
+		t.Fatalf("expected the labelled volume only to be a data volume, got %v", DataVolumes())
+	}
+	if !IsData("ipfs", 1) || IsData("ipfs", 2) {
+		t.Fatalf("expected the data of ipfs in the volume")
+	}
+	if !DataVolumeExists("eris_data_ipfs_1") || DataVolumeExists("eris_data_other_1") {
+		t.Fatalf("expected the data volume to exist")
+	}
+	volume, _ := f.InspectVolume("eris_data_ipfs_1")
+	for label, value := range map[string]string{"eris:TYPE": "data", "eris:NAME": "ipfs", "eris:CONTAINER_NUMBER": "1", "eris:SERVICE": "eris_service_ipfs_1"} {
+		if volume.Labels[label] != value {
+			t.Fatalf("expected the label %s=%s, got %v", label, value, volume.Labels)
+		}
+	}
+	if names := DataContainerNames(); len(names) != 1 || names[0] != "ipfs" {
+		t.Fatalf("expected the data volume listed, got %v", names)
+	}
+
+	// The files of a container with the volume are kept in the volume.
+	cont, _ := f.CreateContainer(docker.CreateContainerOptions{
+		Config:     &docker.Config{Image: "quay.io/eris/data"},
+		HostConfig: &docker.HostConfig{Binds: []string{"eris_data_ipfs_1:/home/eris/.eris"}},
+	})
+	f.UploadToContainer(cont.ID, docker.UploadToContainerOptions{Path: "/home/eris", InputStream: bytes.NewBufferString("tar")})
+	if err := f.RemoveVolume("eris_data_ipfs_1"); err != docker.ErrVolumeInUse {
+		t.Fatalf("expected the volume in use, got %v", err)
+	}
+	f.RemoveContainer(docker.RemoveContainerOptions{ID: cont.ID})
+
+	again, _ := f.CreateContainer(docker.CreateContainerOptions{
+		Config:     &docker.Config{Image: "quay.io/eris/data"},
+		HostConfig: &docker.HostConfig{Binds: []string{"eris_data_ipfs_1:/home/eris/.eris"}},
+	})
+	var out bytes.Buffer
+	if err := f.DownloadFromContainer(again.ID, docker.DownloadFromContainerOptions{Path: "/home/eris", OutputStream: &out}); err != nil || out.String() != "tar" {
+		t.Fatalf("expected the files kept in the volume, got %q (%v)", out.String(), err)
+	}
+}
+
+func TestClean(t *testing.T) {
+	saved := Backend
+	defer func() { Backend = saved }()
+	f := NewFakeBackend()
+	Backend = f
+
+	for name, labels := range map[string]map[string]string{
+		"eris_service_keys_1": {"eris:ERIS": "true"},
+		"mine":                nil,
+	} {
+		if _, err := f.CreateContainer(docker.CreateContainerOptions{
+			Name:   name,
+			Config: &docker.Config{Image: "quay.io/eris/keys", Labels: labels},
+		}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		f.StartContainer(name, nil)
+	}
+	f.PullImage(docker.PullImageOptions{Repository: "quay.io/eris/keys", Tag: "latest"}, docker.AuthConfiguration{})
+	f.PullImage(docker.PullImageOptions{Repository: "alpine", Tag: "3.3"}, docker.AuthConfiguration{})
+
+	if err := Clean(false, false, false, true); err != nil {
+		t.Fatalf("clean: %v", err)
+	}
+	containers, _ := f.ListContainers(docker.ListContainersOptions{All: true})
+	if len(containers) != 1 || containers[0].Names[0] != "/mine" {
+		t.Fatalf("expected the eris containers only to be removed, got %v", containers)
+	}
+	if images := f.Images(); len(images) != 1 || images[0] != "alpine:3.3" {
+		t.Fatalf("expected the eris images only to be removed, got %v", images)
+	}
+
+	// Backends which cannot remove images refuse to clean them.
+	Backend = struct{ ContainerBackend }{f}
+	if err := Clean(false, false, false, false); err != nil {
+		t.Fatalf("clean: %v", err)
+	}
+	if err := Clean(false, false, false, true); err == nil {
+		t.Fatalf("expected an error cleaning images without an image backend")
+	}
+}
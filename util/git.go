@@ -0,0 +1,246 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/common"
+)
+
+// GitLocation is a definition file location in a git repository. It is
+// given as
+//
+//	git:URL[#REF[:PATH]]
+//	git://HOST/REPO[#REF[:PATH]]
+//	github:ORG/REPO[#REF[:PATH]]
+//
+// where URL is anything the local git binary can clone (including
+// file:// remotes), REF is a branch, tag or commit (the default branch
+// if empty), and PATH is the definition file or the directory holding
+// it in the repository (the root if empty).
+type GitLocation struct {
+	URL  string
+	Ref  string
+	Path string
+
+	// Github is ORG/REPO of github: locations.
+	Github string
+}
+
+// IsGitLocation returns true if location is a git:, git:// or github:
+// location.
+func IsGitLocation(location string) bool {
+	return strings.HasPrefix(location, "git:") || strings.HasPrefix(location, "github:")
+}
+
+func ParseGitLocation(location string) (*GitLocation, error) {
+	loc := &GitLocation{}
+
+	var remote string
+	switch {
+	case strings.HasPrefix(location, "github:"):
+		remote = strings.TrimPrefix(location, "github:")
+	case strings.HasPrefix(location, "git://"):
+		// The git:// scheme is part of the remote.
+		remote = location
+	case strings.HasPrefix(location, "git:"):
+		remote = strings.TrimPrefix(location, "git:")
+	default:
+		return nil, fmt.Errorf("%s is not a git: or github: location", location)
+	}
+
+	if i := strings.Index(remote, "#"); i != -1 {
+		loc.Ref = remote[i+1:]
+		remote = remote[:i]
+		if j := strings.Index(loc.Ref, ":"); j != -1 {
+			loc.Path = strings.Trim(loc.Ref[j+1:], "/")
+			loc.Ref = loc.Ref[:j]
+		}
+	}
+
+	if strings.HasPrefix(location, "github:") {
+		if len(strings.Split(strings.Trim(remote, "/"), "/")) != 2 {
+			return nil, fmt.Errorf("Please give github locations as github:ORG/REPO (got %s)", location)
+		}
+		loc.Github = strings.Trim(remote, "/")
+		remote = "https://github.com/" + loc.Github + ".git"
+	}
+	if remote == "" {
+		return nil, fmt.Errorf("There is no repository in %s", location)
+	}
+
+	loc.URL = remote
+	return loc, nil
+}
+
+// CloneGitLocation clones the repository of loc into a temporary
+// directory, checks out loc.Ref and returns the directory. The caller
+// removes it. git's output goes to w.
+func CloneGitLocation(loc *GitLocation, w io.Writer) (string, error) {
+	dir, err := ioutil.TempDir(os.TempDir(), "eris_git")
+	if err != nil {
+		return "", err
+	}
+
+	logger.Debugf("Cloning =>\t\t\t%s:%s\n", loc.URL, dir)
+	if err := runGit(w, "", "clone", "--quiet", loc.URL, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("The marmots could not clone %s (is git installed?): %v", loc.URL, err)
+	}
+
+	if loc.Ref != "" {
+		logger.Debugf("Checking out =>\t\t%s\n", loc.Ref)
+		if err := runGit(w, dir, "checkout", "--quiet", loc.Ref); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("The marmots could not check out %s of %s: %v", loc.Ref, loc.URL, err)
+		}
+	}
+	return dir, nil
+}
+
+// GetFromGit copies the definition file of name from the git: or
+// github: location to dir, keeping its extension, and returns the
+// path of the copy. If the location has no path or its path is a
+// directory, the definition is looked up there as NAME.toml (or
+// .json, .yaml), and then in its subdirectory named like dir (e.g.
+// services/NAME.toml). The files in related (e.g. genesis.json) found
+// next to the definition file, or in a directory called name next to
+// it, are copied to dir/NAME. Definition files on GitHub are
+// downloaded with GetFromGithub rather than cloned.
+func GetFromGit(location, name, dir string, related []string, w io.Writer) (string, error) {
+	loc, err := ParseGitLocation(location)
+	if err != nil {
+		return "", err
+	}
+
+	if loc.Github != "" && isDefinitionFile(loc.Path) {
+		return getFromGithub(loc, name, dir, related, w)
+	}
+
+	repo, err := CloneGitLocation(loc, w)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(repo)
+
+	def, err := findGitDefinition(filepath.Join(repo, filepath.FromSlash(loc.Path)), name, filepath.Base(dir))
+	if err != nil {
+		return "", fmt.Errorf("%v in %s", err, location)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	dst := filepath.Join(dir, name+filepath.Ext(def))
+	logger.Infof("Importing definition =>\t%s:%s\n", def, dst)
+	if err := Copy(def, dst); err != nil {
+		return "", err
+	}
+
+	for _, file := range related {
+		for _, src := range []string{
+			filepath.Join(filepath.Dir(def), file),
+			filepath.Join(filepath.Dir(def), name, file),
+		} {
+			if _, err := os.Stat(src); err != nil {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+				return "", err
+			}
+			logger.Infof("Importing file =>\t\t%s:%s\n", src, filepath.Join(dir, name, file))
+			if err := Copy(src, filepath.Join(dir, name, file)); err != nil {
+				return "", err
+			}
+			break
+		}
+	}
+
+	return dst, nil
+}
+
+func getFromGithub(loc *GitLocation, name, dir string, related []string, w io.Writer) (string, error) {
+	parts := strings.Split(loc.Github, "/")
+	ref := loc.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	dst := filepath.Join(dir, name+filepath.Ext(loc.Path))
+	logger.Infof("Importing definition =>\t%s:%s\n", loc.Path, dst)
+	if err := GetFromGithub(parts[0], parts[1], ref, loc.Path, dst, w); err != nil {
+		return "", err
+	}
+
+	// Related files are optional; those not there are skipped.
+	for _, file := range related {
+		for _, src := range []string{
+			path.Join(path.Dir(loc.Path), file),
+			path.Join(path.Dir(loc.Path), name, file),
+		} {
+			if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+				return "", err
+			}
+			to := filepath.Join(dir, name, file)
+			if err := GetFromGithub(parts[0], parts[1], ref, src, to, w); err != nil {
+				logger.Debugf("Not importing file =>\t\t%s: %v\n", src, err)
+				continue
+			}
+			logger.Infof("Importing file =>\t\t%s:%s\n", src, to)
+			break
+		}
+	}
+	if len(related) != 0 {
+		// Removed if none of the related files were there.
+		os.Remove(filepath.Join(dir, name))
+	}
+
+	return dst, nil
+}
+
+// isDefinitionFile returns true if path names a definition file rather
+// than a directory.
+func isDefinitionFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".toml", ".json", ".yaml":
+		return true
+	}
+	return false
+}
+
+func findGitDefinition(path, name, typ string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("There is no %s", filepath.Base(path))
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+
+	for _, dir := range []string{path, filepath.Join(path, typ)} {
+		for _, ext := range []string{".toml", ".json", ".yaml"} {
+			file := filepath.Join(dir, name+ext)
+			if _, err := os.Stat(file); err == nil {
+				return file, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("There is no definition file for %s", name)
+}
+
+func runGit(w io.Writer, dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
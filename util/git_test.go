@@ -0,0 +1,174 @@
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+var gitLocations = []struct {
+	input string
+	loc   GitLocation
+}{
+	{"github:eris-ltd/eris-services", GitLocation{"https://github.com/eris-ltd/eris-services.git", "", "", "eris-ltd/eris-services"}},
+	{"github:eris-ltd/eris-services#v0.1", GitLocation{"https://github.com/eris-ltd/eris-services.git", "v0.1", "", "eris-ltd/eris-services"}},
+	{"github:eris-ltd/eris-services#master:services/", GitLocation{"https://github.com/eris-ltd/eris-services.git", "master", "services", "eris-ltd/eris-services"}},
+	{"git:file:///srv/defs.git", GitLocation{"file:///srv/defs.git", "", "", ""}},
+	{"git:file:///srv/defs.git#:chains/mint.toml", GitLocation{"file:///srv/defs.git", "", "chains/mint.toml", ""}},
+	{"git:git@example.com:defs.git#abc123:chains", GitLocation{"git@example.com:defs.git", "abc123", "chains", ""}},
+	{"git://example.com/defs.git", GitLocation{"git://example.com/defs.git", "", "", ""}},
+	{"git://example.com/defs.git#v1:chains/mint.toml", GitLocation{"git://example.com/defs.git", "v1", "chains/mint.toml", ""}},
+}
+
+func TestParseGitLocation(t *testing.T) {
+	for _, tt := range gitLocations {
+		if !IsGitLocation(tt.input) {
+			t.Fatalf("%s not recognized as a git location", tt.input)
+		}
+		loc, err := ParseGitLocation(tt.input)
+		if err != nil {
+			t.Fatalf("error parsing %s: %v", tt.input, err)
+		}
+		if *loc != tt.loc {
+			t.Fatalf("wrong location from %s. Got %v, expected %v", tt.input, *loc, tt.loc)
+		}
+	}
+
+	for _, bad := range []string{"ipfs:Qm", "github:eris-ltd", "git:#master"} {
+		if _, err := ParseGitLocation(bad); err == nil {
+			t.Fatalf("expected an error parsing %s", bad)
+		}
+	}
+}
+
+func TestGetFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	tmp, err := ioutil.TempDir(os.TempDir(), "eris_git_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	// a repository with chains/mint.toml and chains/mint/genesis.json
+	// on a v1 tag, and a changed mint.toml on master
+	repo := filepath.Join(tmp, "repo")
+	writeTestFile(t, filepath.Join(repo, "chains", "mint.toml"), "name = \"mint\"\n")
+	writeTestFile(t, filepath.Join(repo, "chains", "mint", "genesis.json"), "{}\n")
+	runTestGit(t, repo, "init", "--quiet")
+	runTestGit(t, repo, "add", ".")
+	runTestGit(t, repo, "commit", "--quiet", "-m", "mint")
+	runTestGit(t, repo, "tag", "v1")
+	writeTestFile(t, filepath.Join(repo, "chains", "mint.toml"), "name = \"mint2\"\n")
+	runTestGit(t, repo, "commit", "--quiet", "-am", "mint2")
+
+	dir := filepath.Join(tmp, "chains")
+	location := "git:file://" + filepath.ToSlash(repo) + "#v1"
+	dst, err := GetFromGit(location, "mint", dir, []string{"genesis.json", "config.toml"}, new(bytes.Buffer))
+	if err != nil {
+		t.Fatalf("error getting %s: %v", location, err)
+	}
+	if dst != filepath.Join(dir, "mint.toml") {
+		t.Fatalf("wrong destination. Got %s, expected %s", dst, filepath.Join(dir, "mint.toml"))
+	}
+	if b, _ := ioutil.ReadFile(dst); string(b) != "name = \"mint\"\n" {
+		t.Fatalf("wrong definition file from %s: %q", location, b)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mint", "genesis.json")); err != nil {
+		t.Fatalf("genesis.json not imported: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mint", "config.toml")); err == nil {
+		t.Fatalf("unexpected config.toml imported")
+	}
+
+	location = "git:file://" + filepath.ToSlash(repo) + "#master:chains/mint.toml"
+	if _, err := GetFromGit(location, "other", dir, nil, new(bytes.Buffer)); err != nil {
+		t.Fatalf("error getting %s: %v", location, err)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(dir, "other.toml")); string(b) != "name = \"mint2\"\n" {
+		t.Fatalf("wrong definition file from %s: %q", location, b)
+	}
+
+	if _, err := GetFromGit("git:file://"+filepath.ToSlash(repo), "missing", dir, nil, new(bytes.Buffer)); err == nil {
+		t.Fatalf("expected an error importing a missing definition")
+	}
+}
+
+func TestGetFromGithub(t *testing.T) {
+	files := map[string]string{
+		"/eris-ltd/defs/v1/chains/mint.toml":         "name = \"mint\"\n",
+		"/eris-ltd/defs/v1/chains/mint/genesis.json": "{}\n",
+		"/eris-ltd/defs/HEAD/services/ipfs.toml":     "name = \"ipfs\"\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	saved := githubRaw
+	githubRaw = server.URL
+	defer func() { githubRaw = saved }()
+
+	tmp, err := ioutil.TempDir(os.TempDir(), "eris_github_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "chains")
+	location := "github:eris-ltd/defs#v1:chains/mint.toml"
+	dst, err := GetFromGit(location, "mint", dir, []string{"genesis.json", "config.toml"}, new(bytes.Buffer))
+	if err != nil {
+		t.Fatalf("error getting %s: %v", location, err)
+	}
+	if b, _ := ioutil.ReadFile(dst); string(b) != "name = \"mint\"\n" {
+		t.Fatalf("wrong definition file from %s: %q", location, b)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mint", "genesis.json")); err != nil {
+		t.Fatalf("genesis.json not imported: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mint", "config.toml")); err == nil {
+		t.Fatalf("unexpected config.toml imported")
+	}
+
+	location = "github:eris-ltd/defs#:services/ipfs.toml"
+	if _, err := GetFromGit(location, "ipfs", filepath.Join(tmp, "services"), nil, new(bytes.Buffer)); err != nil {
+		t.Fatalf("error getting %s: %v", location, err)
+	}
+
+	location = "github:eris-ltd/defs#v1:chains/missing.toml"
+	if _, err := GetFromGit(location, "missing", dir, nil, new(bytes.Buffer)); err == nil {
+		t.Fatalf("expected an error importing a missing definition")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "missing.toml")); err == nil {
+		t.Fatalf("unexpected file written for a missing definition")
+	}
+}
+
+func writeTestFile(t *testing.T, file, contents string) {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runTestGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=eris", "-c", "user.email=eris@localhost"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
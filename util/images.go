@@ -0,0 +1,15 @@
+package util
+
+// Images returns the backend as an ImageBackend or nil if it is not able
+// to build, save and load images.
+func Images() ImageBackend {
+	images, _ := Backend.(ImageBackend)
+	return images
+}
+
+// Execs returns the backend as an ExecBackend or nil if it is not able
+// to run commands in containers.
+func Execs() ExecBackend {
+	execs, _ := Backend.(ExecBackend)
+	return execs
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"sort"
+
+	def "github.com/eris-ltd/eris-cli/definitions"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// DefaultNetwork is the user-defined network eris containers join
+// unless their service definition says otherwise. Containers on it can
+// reach each other by their short names (eg. keys or ipfs).
+const DefaultNetwork = "eris"
+
+// ChainNetwork returns the name of the network of the chain name. The
+// chain container is known as "chain" on it to the services which
+// depend on the chain.
+func ChainNetwork(name string) string {
+	return DefaultNetwork + "_chain_" + name
+}
+
+// Networks returns the backend as a NetworkBackend or nil if it is
+// not able to manage networks.
+func Networks() NetworkBackend {
+	networks, _ := Backend.(NetworkBackend)
+	return networks
+}
+
+// EnsureNetwork creates the eris network name if it does not exist.
+func EnsureNetwork(name string) error {
+	networks := Networks()
+	if networks == nil {
+		return nil
+	}
+	if _, err := networks.NetworkInfo(name); err == nil {
+		return nil
+	}
+
+	logger.Infof("Creating network =>\t\t%s\n", name)
+	_, err := networks.CreateNetwork(docker.CreateNetworkOptions{
+		Name:           name,
+		Driver:         "bridge",
+		CheckDuplicate: true,
+		Labels: map[string]string{
+			def.Namespace + ":" + def.LabelEris: "true",
+		},
+	})
+	if err == docker.ErrNetworkAlreadyExists {
+		return nil
+	}
+	return err
+}
+
+// ErisNetworks returns the networks created by eris.
+func ErisNetworks() ([]docker.Network, error) {
+	networks := Networks()
+	if networks == nil {
+		return nil, nil
+	}
+	all, err := networks.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	var eris []docker.Network
+	for _, network := range all {
+		if network.Labels[def.Namespace+":"+def.LabelEris] == "true" {
+			eris = append(eris, network)
+		}
+	}
+	return eris, nil
+}
+
+// ContainerNetworks returns the sorted names of the networks the
+// container is connected to.
+func ContainerNetworks(container *docker.Container) []string {
+	if container == nil || container.NetworkSettings == nil {
+		return nil
+	}
+	var names []string
+	for name := range container.NetworkSettings.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RemoveOrphanedNetworks removes the eris networks no container is
+// connected to.
+func RemoveOrphanedNetworks() error {
+	networks, err := ErisNetworks()
+	if err != nil {
+		return err
+	}
+	for _, network := range networks {
+		info, err := Networks().NetworkInfo(network.ID)
+		if err != nil {
+			return err
+		}
+		if len(info.Containers) != 0 {
+			continue
+		}
+		logger.Infof("Removing network =>\t\t%s\n", network.Name)
+		if err := Networks().RemoveNetwork(network.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -18,6 +18,30 @@ func ChainsPathChecker(name string) (string, error) {
 	return pathS, nil
 }
 
+// RemotesPath is the directory holding remote definition files. It
+// follows ErisRoot, so it is a function rather than a common path.
+func RemotesPath() string {
+	return filepath.Join(ErisRoot, "remotes")
+}
+
+// ProjectsPath is the directory holding project definition files and
+// the projects HEAD file.
+func ProjectsPath() string {
+	return filepath.Join(ErisRoot, "projects")
+}
+
+// StacksPath is the directory holding the state of the stacks brought
+// up with [eris stack up].
+func StacksPath() string {
+	return filepath.Join(ErisRoot, "stacks")
+}
+
+// ProfilesPath is the directory holding the values files of the
+// profiles selected with [--profile].
+func ProfilesPath() string {
+	return filepath.Join(ErisRoot, "profiles")
+}
+
 func GetFileByNameAndType(typ, name string) string {
 	logger.Debugf("Looking for file =>\t\t%s:%s\n", typ, name)
 	files := GetGlobalLevelConfigFilesByType(typ, true)
@@ -44,6 +68,10 @@ func GetGlobalLevelConfigFilesByType(typ string, withExt bool) []string {
 		path = ChainsPath
 	case "actions":
 		path = ActionsPath
+	case "remotes":
+		path = RemotesPath()
+	case "projects":
+		path = ProjectsPath()
 	}
 
 	files := []string{}
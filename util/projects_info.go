@@ -0,0 +1,81 @@
+package util
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// check if given project is known
+func IsKnownProject(name string) bool {
+	known := GetGlobalLevelConfigFilesByType("projects", false)
+	for _, prj := range known {
+		if prj == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectHeadFile is the HEAD file of the projects; like the chains
+// HEAD file it records the checked out project on its first line.
+func ProjectHeadFile() string {
+	return filepath.Join(ProjectsPath(), "HEAD")
+}
+
+// Change the project head to null (no head)
+func NullProjectHead() error {
+	return ChangeProjectHead("")
+}
+
+// Get the current active project (top of the projects HEAD file)
+// Returns project name
+func GetProjectHead() (string, error) {
+	f, err := ioutil.ReadFile(ProjectHeadFile())
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	head := strings.Split(string(f), "\n")[0]
+	if head == "" {
+		return "", fmt.Errorf("There is no project checked out")
+	}
+
+	return head, nil
+}
+
+// Add a new entry (name) to the top of the projects HEAD file
+func ChangeProjectHead(name string) error {
+	if !IsKnownProject(name) && name != "" {
+		logger.Debugf("Project name not known. Not saving.\n")
+		return nil
+	}
+
+	logger.Debugf("Project name known (or blank). Saving to head file.\n")
+	if err := os.MkdirAll(ProjectsPath(), 0755); err != nil {
+		return err
+	}
+
+	// read in the entire head file and clip
+	// if we have reached the max length
+	b, err := ioutil.ReadFile(ProjectHeadFile())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	bspl := strings.Split(string(b), "\n")
+	var bsp string
+	if len(bspl) >= MaxHead {
+		bsp = strings.Join(bspl[:MaxHead-1], "\n")
+	} else {
+		bsp = string(b)
+	}
+
+	if err := ioutil.WriteFile(ProjectHeadFile(), []byte(name+"\n"+bsp), 0666); err != nil {
+		return err
+	}
+
+	logger.Debugf("Project head file saved.\n")
+	return nil
+}
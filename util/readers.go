@@ -1,11 +1,13 @@
 package util
 
 import (
+	"fmt"
 	"io"
+	"net/http"
+	"os"
 	"strings"
 
-	ipfs "github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/eris-ltd/common/go/ipfs"
-	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient/external/github.com/docker/docker/pkg/archive"
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/docker/docker/pkg/archive"
 )
 
 //these were in writers.go but that got moved to /ipfs
@@ -17,8 +19,31 @@ func Untar(reader io.Reader, name, dest string) error {
 	return archive.Untar(reader, dest, &archive.TarOptions{NoLchown: true}) //, Name: name})
 }
 
+// githubRaw serves the files of GitHub repositories.
+var githubRaw = "https://raw.githubusercontent.com"
+
+// GetFromGithub downloads the file at path of the branch (or tag or
+// commit) of the GitHub repository org/repo to fileName.
 func GetFromGithub(org, repo, branch, path, fileName string, w io.Writer) error {
-	url := "https://rawgit.com/" + strings.Join([]string{org, repo, branch, path}, "/")
-	w.Write([]byte("Will download from url -> " + url))
-	return ipfs.DownloadFromUrlToFile(url, fileName, "", w)
+	url := githubRaw + "/" + strings.Join([]string{org, repo, branch, path}, "/")
+	w.Write([]byte("Will download from url -> " + url + "\n"))
+
+	response, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("The marmots could not download %s: %s", url, response.Status)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, response.Body); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
 }
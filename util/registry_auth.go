@@ -0,0 +1,197 @@
+package util
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/eris-ltd/eris-cli/config"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+// dockerHub is the hostname of the images which name no registry.
+const dockerHub = "docker.io"
+
+// dockerHubServer is the address Docker keeps the Docker Hub
+// credentials under.
+const dockerHubServer = "https://index.docker.io/v1/"
+
+// dockerConfigFile is the part of ~/.docker/config.json with the
+// registry credentials.
+type dockerConfigFile struct {
+	Auths       map[string]dockerAuth `json:"auths"`
+	CredsStore  string                `json:"credsStore"`
+	CredHelpers map[string]string     `json:"credHelpers"`
+}
+
+type dockerAuth struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	Email         string `json:"email"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// ImageRegistry returns the hostname of the registry of the image (eg.
+// quay.io for quay.io/eris/data or docker.io for images on Docker Hub).
+func ImageRegistry(image string) string {
+	i := strings.Index(image, "/")
+	if i < 0 {
+		return dockerHub
+	}
+	host := image[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return dockerHub
+	}
+	return registryHost(host)
+}
+
+// RegistryAuth returns the credentials to pull the image with. Those of
+// its registry in the [registries] table of eris.toml are used first,
+// then those Docker keeps in ~/.docker/config.json ($DOCKER_CONFIG),
+// given there or by a credential helper. If there are none, no
+// credentials are returned.
+func RegistryAuth(image string) (docker.AuthConfiguration, error) {
+	host := ImageRegistry(image)
+	server := host
+	if host == dockerHub {
+		server = dockerHubServer
+	}
+
+	if config.GlobalConfig != nil && config.GlobalConfig.Config != nil {
+		for name, registry := range config.GlobalConfig.Config.Registries {
+			if registry != nil && registryHost(name) == host {
+				logger.Debugf("Using eris.toml credentials =>\t%s\n", host)
+				return docker.AuthConfiguration{
+					Username:      registry.Username,
+					Password:      registry.Password,
+					Email:         registry.Email,
+					ServerAddress: server,
+				}, nil
+			}
+		}
+	}
+
+	conf, err := readDockerConfig()
+	if err != nil || conf == nil {
+		return docker.AuthConfiguration{}, err
+	}
+
+	for name, helper := range conf.CredHelpers {
+		if registryHost(name) == host {
+			return helperAuth(helper, server)
+		}
+	}
+	for name, auth := range conf.Auths {
+		if registryHost(name) != host || (auth.Auth == "" && auth.Username == "" && auth.IdentityToken == "") {
+			continue
+		}
+		logger.Debugf("Using Docker credentials =>\t%s\n", host)
+		return decodeDockerAuth(name, auth)
+	}
+	if conf.CredsStore != "" {
+		return helperAuth(conf.CredsStore, server)
+	}
+	return docker.AuthConfiguration{}, nil
+}
+
+// registryHost strips the scheme and path of a registry address and
+// names the Docker Hub addresses docker.io.
+func registryHost(address string) string {
+	host := strings.ToLower(address)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	switch host {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return dockerHub
+	}
+	return host
+}
+
+func readDockerConfig() (*dockerConfigFile, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		dir = filepath.Join(os.Getenv("HOME"), ".docker")
+	}
+	file := filepath.Join(dir, "config.json")
+
+	body, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &dockerConfigFile{}
+	if err := json.Unmarshal(body, conf); err != nil {
+		return nil, fmt.Errorf("The marmots could not read the registry credentials in %s: %v", file, err)
+	}
+	return conf, nil
+}
+
+func decodeDockerAuth(server string, auth dockerAuth) (docker.AuthConfiguration, error) {
+	creds := docker.AuthConfiguration{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		Email:         auth.Email,
+		IdentityToken: auth.IdentityToken,
+		ServerAddress: server,
+	}
+	if auth.Auth != "" {
+		userpass, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return docker.AuthConfiguration{}, fmt.Errorf("The marmots could not decode the Docker credentials for %s: %v", server, err)
+		}
+		parts := strings.SplitN(string(userpass), ":", 2)
+		if len(parts) != 2 {
+			return docker.AuthConfiguration{}, fmt.Errorf("The marmots could not decode the Docker credentials for %s.", server)
+		}
+		creds.Username, creds.Password = parts[0], parts[1]
+	}
+	return creds, nil
+}
+
+// helperAuth asks the Docker credential helper (docker-credential-NAME)
+// for the credentials of server.
+func helperAuth(helper, server string) (docker.AuthConfiguration, error) {
+	logger.Debugf("Asking credential helper =>\t%s for %s\n", helper, server)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		out := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(out, "credentials not found") {
+			return docker.AuthConfiguration{}, nil
+		}
+		return docker.AuthConfiguration{}, fmt.Errorf("The docker-credential-%s helper failed for %s: %v %s", helper, server, err, out)
+	}
+
+	var creds struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return docker.AuthConfiguration{}, fmt.Errorf("The docker-credential-%s helper gave no credentials for %s: %v", helper, server, err)
+	}
+
+	// Helpers return identity tokens with the <token> user name.
+	if creds.Username == "<token>" {
+		return docker.AuthConfiguration{IdentityToken: creds.Secret, ServerAddress: server}, nil
+	}
+	return docker.AuthConfiguration{Username: creds.Username, Password: creds.Secret, ServerAddress: server}, nil
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eris-ltd/eris-cli/config"
+
+	"github.com/eris-ltd/eris-cli/Godeps/_workspace/src/github.com/fsouza/go-dockerclient"
+)
+
+func TestImageRegistry(t *testing.T) {
+	for image, want := range map[string]string{
+		"ubuntu":                         "docker.io",
+		"eris/ipfs:0.12":                 "docker.io",
+		"quay.io/eris/data":              "quay.io",
+		"Localhost:5000/eris/ipfs:0.12":  "localhost:5000",
+		"localhost/eris/ipfs":            "localhost",
+		"registry.example.com/ipfs:0.12": "registry.example.com",
+	} {
+		if got := ImageRegistry(image); got != want {
+			t.Fatalf("ImageRegistry(%q): expected %q, got %q", image, want, got)
+		}
+	}
+}
+
+func TestRegistryAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eris_auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A credential helper answering for one registry only.
+	helper := "#!/bin/sh\nread server\nif [ \"$server\" = \"helped.example.com\" ]; then\n  echo '{\"ServerURL\":\"helped.example.com\",\"Username\":\"helper\",\"Secret\":\"s3cret\"}'\nelse\n  echo 'credentials not found in native keychain'\n  exit 1\nfi\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "docker-credential-test"), []byte(helper), 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	defer os.Setenv("DOCKER_CONFIG", os.Getenv("DOCKER_CONFIG"))
+	os.Setenv("DOCKER_CONFIG", dir)
+
+	// No config.json, no credentials.
+	if auth, err := RegistryAuth("quay.io/eris/data"); err != nil || auth != (docker.AuthConfiguration{}) {
+		t.Fatalf("expected no credentials, got %+v (%v)", auth, err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(`{
+	"auths": {
+		"https://index.docker.io/v1/": {"auth": "aHViOmh1YnBhc3M="},
+		"https://quay.io": {"auth": "cXVheTpxdWF5cGFzcw==", "email": "quay@example.com"},
+		"store.example.com": {}
+	},
+	"credHelpers": {"helped.example.com": "test"},
+	"credsStore": "test"
+}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for image, want := range map[string]docker.AuthConfiguration{
+		"eris/ipfs:0.12":                   {Username: "hub", Password: "hubpass", ServerAddress: "https://index.docker.io/v1/"},
+		"quay.io/eris/data":                {Username: "quay", Password: "quaypass", Email: "quay@example.com", ServerAddress: "https://quay.io"},
+		"helped.example.com/eris/ipfs:1.0": {Username: "helper", Password: "s3cret", ServerAddress: "helped.example.com"},
+		"store.example.com/eris/ipfs:1.0":  {},
+	} {
+		auth, err := RegistryAuth(image)
+		if err != nil || auth != want {
+			t.Fatalf("RegistryAuth(%q): expected %+v, got %+v (%v)", image, want, auth, err)
+		}
+	}
+
+	// The [registries] table of eris.toml goes first.
+	saved := config.GlobalConfig
+	defer func() { config.GlobalConfig = saved }()
+	config.GlobalConfig = &config.ErisCli{Config: &config.ErisConfig{
+		Registries: map[string]*config.Registry{
+			"Quay.io": {Username: "eris", Password: "erispass"},
+		},
+	}}
+	auth, err := RegistryAuth("quay.io/eris/data")
+	if err != nil || auth != (docker.AuthConfiguration{Username: "eris", Password: "erispass", ServerAddress: "quay.io"}) {
+		t.Fatalf("expected the eris.toml credentials, got %+v (%v)", auth, err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"auths": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RegistryAuth("eris/ipfs:0.12"); err == nil {
+		t.Fatalf("expected an error for a malformed config.json")
+	}
+}